@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/security"
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/log"
+	"github.com/cockroachdb/cockroach/util/randutil"
 	"github.com/samalba/dockerclient"
 )
 
@@ -102,8 +103,10 @@ type LocalCluster struct {
 	mu             sync.Mutex // Protects the fields below
 	dns            *Container
 	vols           *Container
+	dataVols       map[string]struct{} // Data directories held by vols
 	numLocal       int
-	Nodes          []*Container
+	Nodes          []*Container   // Removed nodes are nil
+	retired        map[string]int // Maps IDs of removed nodes to their index
 	events         chan Event
 	expectedEvents chan Event
 	CertsDir       string
@@ -135,12 +138,13 @@ func CreateLocal(numLocal int, logDir string, stopper chan struct{}) *LocalClust
 		events:         make(chan Event, 1000),
 		expectedEvents: make(chan Event, 1000),
 		logDir:         logDir,
+		retired:        map[string]int{},
 	}
 }
 
 func (l *LocalCluster) expectEvent(c *Container, msgs ...string) {
 	for index, ctr := range l.Nodes {
-		if c.ID != ctr.ID {
+		if ctr == nil || c.ID != ctr.ID {
 			continue
 		}
 		for _, status := range msgs {
@@ -287,14 +291,22 @@ func (l *LocalCluster) initCluster() {
 	maybePanic(c.Wait())
 	l.vols = c
 	l.vols.Name = "volumes"
+	l.dataVols = vols
 }
 
 func (l *LocalCluster) createRoach(i int, cmd ...string) *Container {
 	l.panicOnStop()
 
 	var hostname string
+	var vols map[string]struct{}
 	if i >= 0 {
 		hostname = fmt.Sprintf("roach%d", i)
+		if _, ok := l.dataVols[dataStr(i)]; !ok {
+			// Nodes added to the running cluster keep their data in a volume
+			// of their own, as the volumes container can't gain volumes once
+			// it has been created.
+			vols = map[string]struct{}{dataStr(i): {}}
+		}
 	}
 	var entrypoint []string
 	if *cockroachImage == builderImage {
@@ -307,6 +319,7 @@ func (l *LocalCluster) createRoach(i int, cmd ...string) *Container {
 		Domainname:   domain,
 		Image:        *cockroachImage,
 		ExposedPorts: map[string]struct{}{fmt.Sprintf("%d/tcp", cockroachPort): {}},
+		Volumes:      vols,
 		Entrypoint:   entrypoint,
 		Cmd:          cmd,
 		Labels: map[string]string{
@@ -335,9 +348,24 @@ func (l *LocalCluster) createNodeCerts() {
 	maybePanic(security.RunCreateNodeCert(l.CertsDir, keyLen, nodes))
 }
 
+// isRemoved returns whether the i-th node has been removed from the
+// cluster. The cluster's lock must be held.
+func (l *LocalCluster) isRemoved(i int) bool {
+	for _, j := range l.retired {
+		if j == i {
+			return true
+		}
+	}
+	return false
+}
+
 func (l *LocalCluster) startNode(i int) *Container {
 	gossipNodes := []string{}
 	for i := 0; i < l.numLocal; i++ {
+		// Removed nodes keep their index, but must not be joined.
+		if l.isRemoved(i) {
+			continue
+		}
 		gossipNodes = append(gossipNodes, fmt.Sprintf("%s:%d", nodeStr(i), cockroachPort))
 	}
 
@@ -398,10 +426,12 @@ func (l *LocalCluster) processEvent(e dockerclient.EventOrError, monitorStopper
 			return true
 		}
 	}
-
-	// TODO(pmattis): When we add the ability to start/stop/restart nodes we'll
-	// need to keep around a map of old node container ids in order to ignore
-	// events on those containers.
+	// Events on removed nodes, such as those triggered by their removal, are
+	// attributed to the index the node had.
+	if i, ok := l.retired[e.Id]; ok {
+		l.events <- Event{NodeIndex: i, Status: e.Status}
+		return true
+	}
 
 	// An event on any other container is unexpected. Die.
 	select {
@@ -544,6 +574,9 @@ func (l *LocalCluster) stop() {
 		l.CertsDir = ""
 	}
 	for i, n := range l.Nodes {
+		if n == nil {
+			continue
+		}
 		ci, err := n.Inspect()
 		crashed := err != nil || (!ci.State.Running && ci.State.ExitCode != 0)
 		maybePanic(n.Kill())
@@ -585,6 +618,8 @@ func (l *LocalCluster) ConnString(i int) string {
 
 // NumNodes returns the number of nodes in the cluster.
 func (l *LocalCluster) NumNodes() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	return len(l.Nodes)
 }
 
@@ -598,6 +633,97 @@ func (l *LocalCluster) Restart(i int) error {
 	return l.Nodes[i].Restart(5)
 }
 
+// AddNode starts an additional node which joins the running cluster and
+// returns its index.
+func (l *LocalCluster) AddNode() int {
+	defer l.stopOnPanic()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	i := len(l.Nodes)
+	l.numLocal++
+	// Reissue the node certificate so that it covers the new node's
+	// hostname. Running nodes keep using the certificate they loaded.
+	l.createNodeCerts()
+	l.Nodes = append(l.Nodes, l.startNode(i))
+	return i
+}
+
+// RemoveNode stops the i-th node, allowing it to shut down gracefully, and
+// removes it from the cluster. The index of a removed node must not be
+// used again.
+func (l *LocalCluster) RemoveNode(i int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	c := l.Nodes[i]
+	if c == nil {
+		return util.Errorf("node %d has already been removed", i)
+	}
+	if err := c.Stop(10); err != nil {
+		return err
+	}
+	l.retired[c.ID] = i
+	l.Nodes[i] = nil
+	return c.Remove()
+}
+
+// Chaos repeatedly kills a randomly chosen node, waits for the given
+// interval and restarts it, until the stopper is closed. The node is
+// always restarted before Chaos returns, unless it was removed from the
+// cluster in the meantime. Only one node is down at any time, so a
+// cluster of at least three nodes never loses its quorum; smaller clusters
+// are left alone.
+func (l *LocalCluster) Chaos(interval time.Duration, stopper chan struct{}) {
+	if n := l.NumNodes(); n < 3 {
+		log.Warningf("not running chaos against a cluster of %d nodes", n)
+		return
+	}
+	rnd, seed := randutil.NewPseudoRand()
+	log.Infof("chaos starts (seed %d)", seed)
+	for {
+		select {
+		case <-stopper:
+			return
+		case <-l.stopper:
+			return
+		case <-time.After(interval):
+		}
+		// Nodes may be added or removed concurrently, so the victim is
+		// picked and killed while holding the lock.
+		i, c := func() (int, *Container) {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			i := rnd.Intn(len(l.Nodes))
+			c := l.Nodes[i]
+			if c != nil {
+				log.Infof("chaos: killing node %d", i)
+				maybePanic(c.Kill())
+			}
+			return i, c
+		}()
+		if c == nil {
+			continue
+		}
+		select {
+		case <-time.After(interval):
+		case <-stopper:
+		case <-l.stopper:
+		}
+		func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			if l.Nodes[i] != c {
+				log.Infof("chaos: node %d was removed while down", i)
+				return
+			}
+			log.Infof("chaos: restarting node %d", i)
+			maybePanic(c.Restart(5))
+		}()
+	}
+}
+
 // URL returns the base url.
 func (l *LocalCluster) URL(i int) string {
 	return "https://" + l.Nodes[i].Addr("26257/tcp").String()
@@ -0,0 +1,107 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build acceptance
+
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/acceptance/cluster"
+	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+)
+
+// checkKeys verifies that scanning the given prefix returns exactly the
+// expected keys and values.
+func checkKeys(db *client.DB, prefix string, expected map[string]string) error {
+	rows, err := db.Scan(prefix, prefix+"\xff", 0)
+	if err != nil {
+		return err
+	}
+	if len(rows) != len(expected) {
+		return fmt.Errorf("expected %d rows, found %d", len(expected), len(rows))
+	}
+	for _, row := range rows {
+		if v, ok := expected[string(row.Key)]; !ok {
+			return fmt.Errorf("unexpected key %s", row.Key)
+		} else if string(row.ValueBytes()) != v {
+			return fmt.Errorf("key %s: expected %q, found %q", row.Key, v, row.ValueBytes())
+		}
+	}
+	return nil
+}
+
+// TestPartitionRecovery partitions a minority of the nodes of a local
+// cluster from the remainder by pausing their containers, writes data
+// through the majority and then heals the partition. The previously
+// partitioned nodes must then serve the data written in their absence.
+func TestPartitionRecovery(t *testing.T) {
+	if *numLocal < 3 {
+		t.Skip("skipping since not run against a local cluster of at least three nodes")
+	}
+	l := cluster.CreateLocal(*numLocal, *logDir, stopper) // intentionally using local cluster
+	l.Start()
+	defer l.AssertAndStop(t)
+
+	checkRangeReplication(t, l, 20*time.Second)
+
+	num := l.NumNodes()
+	minority := (num - 1) / 2
+	log.Infof("partitioning nodes %d through %d", num-minority, num-1)
+	for i := num - minority; i < num; i++ {
+		if err := l.Nodes[i].Pause(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	db, dbStopper := makeClient(t, l.ConnString(0))
+	defer dbStopper.Stop()
+
+	// Writes may stall until any leases held by the partitioned nodes
+	// have expired and been acquired by the majority.
+	const prefix = "partition-"
+	expected := map[string]string{}
+	for i := 0; i < 100; i++ {
+		key, value := fmt.Sprintf("%s%03d", prefix, i), fmt.Sprintf("value-%d", i)
+		util.SucceedsWithin(t, time.Minute, func() error {
+			select {
+			case <-stopper:
+				t.Fatalf("interrupted")
+			default:
+			}
+			return db.Put(key, value)
+		})
+		expected[key] = value
+	}
+
+	log.Infof("healing partition")
+	for i := num - minority; i < num; i++ {
+		if err := l.Nodes[i].Unpause(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := num - minority; i < num; i++ {
+		db, dbStopper := makeClient(t, l.ConnString(i))
+		util.SucceedsWithin(t, time.Minute, func() error {
+			return checkKeys(db, prefix, expected)
+		})
+		dbStopper.Stop()
+	}
+}
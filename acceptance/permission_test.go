@@ -0,0 +1,66 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build acceptance
+
+package acceptance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/acceptance/cluster"
+	"github.com/cockroachdb/cockroach/security"
+)
+
+// TestKVPermissions verifies that KV requests are accepted from the node
+// user and rejected from any other user, on every node of a local
+// cluster. Only the node user holds KV permissions; even the root user,
+// whose client certificate is valid, must be refused.
+func TestKVPermissions(t *testing.T) {
+	if *numLocal == 0 {
+		t.Skip("skipping since not run against local cluster")
+	}
+	l := cluster.CreateLocal(*numLocal, *logDir, stopper) // intentionally using local cluster
+	l.Start()
+	defer l.AssertAndStop(t)
+
+	checkRangeReplication(t, l, 20*time.Second)
+
+	if err := security.RunCreateClientCert(l.CertsDir, 1024, security.RootUser); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < l.NumNodes(); i++ {
+		nodeDB, nodeStopper := makeClient(t, l.ConnString(i))
+		if err := nodeDB.Put("permissions", "node"); err != nil {
+			t.Errorf("node %d: expected put as %s to succeed; got %s", i, security.NodeUser, err)
+		}
+		if _, err := nodeDB.Get("permissions"); err != nil {
+			t.Errorf("node %d: expected get as %s to succeed; got %s", i, security.NodeUser, err)
+		}
+		nodeStopper.Stop()
+
+		rootConn := strings.Replace(l.ConnString(i), security.NodeUser+"@", security.RootUser+"@", 1)
+		rootDB, rootStopper := makeClient(t, rootConn)
+		if err := rootDB.Put("permissions", "root"); err == nil {
+			t.Errorf("node %d: expected put as %s to fail", i, security.RootUser)
+		}
+		if _, err := rootDB.Get("permissions"); err == nil {
+			t.Errorf("node %d: expected get as %s to fail", i, security.RootUser)
+		}
+		rootStopper.Stop()
+	}
+}
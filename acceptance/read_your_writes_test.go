@@ -0,0 +1,87 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build acceptance
+
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/acceptance/cluster"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+)
+
+// TestReadYourWrites writes a key through one node and verifies that
+// every node returns the written value, both immediately and after
+// another node, which may hold the leader lease, has been restarted. A
+// read which succeeds must never return a stale value.
+func TestReadYourWrites(t *testing.T) {
+	if *numLocal == 0 {
+		t.Skip("skipping since not run against local cluster")
+	}
+	l := cluster.CreateLocal(*numLocal, *logDir, stopper) // intentionally using local cluster
+	l.Start()
+	defer l.AssertAndStop(t)
+
+	checkRangeReplication(t, l, 20*time.Second)
+
+	const key = "read-your-writes"
+	num := l.NumNodes()
+	checkRead := func(i int, expected string) {
+		db, dbStopper := makeClient(t, l.ConnString(i))
+		defer dbStopper.Stop()
+		util.SucceedsWithin(t, time.Minute, func() error {
+			r, err := db.Get(key)
+			if err != nil {
+				return err
+			}
+			if v := string(r.ValueBytes()); v != expected {
+				t.Fatalf("node %d: read stale value %q; expected %q", i, v, expected)
+			}
+			return nil
+		})
+	}
+
+	for round := 0; round < num; round++ {
+		select {
+		case <-stopper:
+			t.Fatalf("interrupted")
+		default:
+		}
+		value := fmt.Sprintf("value-%d", round)
+		writer := (round + 1) % num
+		db, dbStopper := makeClient(t, l.ConnString(writer))
+		util.SucceedsWithin(t, time.Minute, func() error {
+			return db.Put(key, value)
+		})
+		dbStopper.Stop()
+
+		for i := 0; i < num; i++ {
+			checkRead(i, value)
+		}
+
+		log.Infof("round %d: restarting node %d", round, round)
+		if err := l.Restart(round); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < num; i++ {
+			checkRead(i, value)
+		}
+		l.Assert(t)
+	}
+}
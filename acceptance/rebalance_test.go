@@ -0,0 +1,112 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build acceptance
+
+package acceptance
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/acceptance/cluster"
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+)
+
+// replicasPerStore returns the number of replicas held by each store
+// according to the given range descriptors.
+func replicasPerStore(descs []roachpb.RangeDescriptor) map[roachpb.StoreID]int {
+	counts := map[roachpb.StoreID]int{}
+	for _, desc := range descs {
+		for _, rep := range desc.Replicas {
+			counts[rep.StoreID]++
+		}
+	}
+	return counts
+}
+
+// TestRebalanceOnSkew loads a local cluster so that its ranges are
+// replicated onto only some of its stores, and verifies that replicas
+// migrate until all stores hold a similar number of them. The skew is
+// created by splitting while only a three-node subset of the cluster is
+// running.
+func TestRebalanceOnSkew(t *testing.T) {
+	if *numLocal < 4 {
+		t.Skip("skipping since not run against a local cluster of at least four nodes")
+	}
+	const numRanges = 20
+	// tolerance is the allowed deviation of any store's replica count
+	// from the mean, as a fraction of the mean.
+	const tolerance = 0.5
+
+	l := cluster.CreateLocal(*numLocal, *logDir, stopper) // intentionally using local cluster
+	l.Start()
+	defer l.AssertAndStop(t)
+
+	checkRangeReplication(t, l, 20*time.Second)
+
+	// Stop all but the first three nodes, so that the new ranges are
+	// replicated onto those only.
+	for i := 3; i < l.NumNodes(); i++ {
+		if err := l.Kill(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	db, dbStopper := makeClient(t, l.ConnString(0))
+	defer dbStopper.Stop()
+	for i := 0; i < numRanges; i++ {
+		if err := db.AdminSplit(fmt.Sprintf("rebalance-%03d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 3; i < l.NumNodes(); i++ {
+		if err := l.Restart(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	util.SucceedsWithin(t, 5*time.Minute, func() error {
+		select {
+		case <-stopper:
+			t.Fatalf("interrupted")
+		case <-time.After(time.Second):
+		}
+		descs, err := getRangeDescriptors(db)
+		if err != nil {
+			return err
+		}
+		counts := replicasPerStore(descs)
+		if len(counts) < l.NumNodes() {
+			return fmt.Errorf("only %d of %d stores hold replicas: %v", len(counts), l.NumNodes(), counts)
+		}
+		var total int
+		for _, n := range counts {
+			total += n
+		}
+		mean := float64(total) / float64(len(counts))
+		for storeID, n := range counts {
+			if math.Abs(float64(n)-mean) > tolerance*mean {
+				return fmt.Errorf("store %d holds %d replicas, mean is %.1f: %v", storeID, n, mean, counts)
+			}
+		}
+		log.Infof("replicas balanced: %v", counts)
+		return nil
+	})
+}
@@ -82,3 +82,56 @@ func TestRangeReplication(t *testing.T) {
 
 	checkRangeReplication(t, l, 20*time.Second)
 }
+
+// TestRangeReplicationChaos verifies that the first range regains full
+// replication after a period during which nodes are continuously killed
+// and restarted.
+func TestRangeReplicationChaos(t *testing.T) {
+	if *numLocal < 3 {
+		t.Skip("skipping since not run against a local cluster of at least three nodes")
+	}
+	l := cluster.CreateLocal(*numLocal, *logDir, stopper) // intentionally using local cluster
+	l.Start()
+	defer l.AssertAndStop(t)
+
+	checkRangeReplication(t, l, 20*time.Second)
+
+	chaosStopper := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		l.Chaos(2*time.Second, chaosStopper)
+	}()
+	select {
+	case <-stopper:
+		t.Fatalf("interrupted")
+	case <-time.After(*duration):
+	}
+	close(chaosStopper)
+	<-done
+	l.Assert(t)
+
+	checkRangeReplication(t, l, time.Minute)
+}
+
+// TestRangeReplicationAddNode starts a two node cluster, adds a third
+// node and verifies that the first range up-replicates onto it.
+func TestRangeReplicationAddNode(t *testing.T) {
+	if *numLocal == 0 {
+		t.Skip("skipping since not run against local cluster")
+	}
+	l := cluster.CreateLocal(2, *logDir, stopper) // intentionally using local cluster
+	l.Start()
+	defer l.AssertAndStop(t)
+
+	checkRangeReplication(t, l, 20*time.Second)
+
+	i := l.AddNode()
+	log.Infof("added node %d", i)
+	checkRangeReplication(t, l, time.Minute)
+	l.Assert(t)
+
+	if err := l.RemoveNode(i); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build acceptance
+
+package acceptance
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+)
+
+// TestRestartDurability writes keys to a cluster, restarts every node
+// in turn and verifies that all keys remain readable with their
+// original values.
+func TestRestartDurability(t *testing.T) {
+	c := StartCluster(t)
+	defer c.AssertAndStop(t)
+
+	db, dbStopper := makeClient(t, c.ConnString(0))
+	const prefix = "restart-"
+	expected := map[string]string{}
+	for i := 0; i < 100; i++ {
+		key, value := fmt.Sprintf("%s%03d", prefix, i), fmt.Sprintf("value-%d", i)
+		if err := db.Put(key, value); err != nil {
+			t.Fatal(err)
+		}
+		expected[key] = value
+	}
+	dbStopper.Stop()
+
+	for i := 0; i < c.NumNodes(); i++ {
+		select {
+		case <-stopper:
+			t.Fatalf("interrupted")
+		default:
+		}
+		log.Infof("restarting node %d", i)
+		if err := c.Restart(i); err != nil {
+			t.Fatal(err)
+		}
+		// Wait for the restarted node to serve the data again before moving
+		// on to the next one, so that a quorum is always available.
+		db, dbStopper := makeClient(t, c.ConnString(i))
+		util.SucceedsWithin(t, time.Minute, func() error {
+			return checkKeys(db, prefix, expected)
+		})
+		dbStopper.Stop()
+		c.Assert(t)
+	}
+
+	for i := 0; i < c.NumNodes(); i++ {
+		db, dbStopper := makeClient(t, c.ConnString(i))
+		if err := checkKeys(db, prefix, expected); err != nil {
+			t.Errorf("node %d: %s", i, err)
+		}
+		dbStopper.Stop()
+	}
+}
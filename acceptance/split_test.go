@@ -0,0 +1,128 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// +build acceptance
+
+package acceptance
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/config"
+	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+	"github.com/cockroachdb/cockroach/util/randutil"
+)
+
+// getRangeDescriptors returns the descriptors of all ranges, as recorded
+// in the second level of range addressing, ordered by start key.
+func getRangeDescriptors(db *client.DB) ([]roachpb.RangeDescriptor, error) {
+	rows, err := db.Scan(keys.Meta2Prefix, keys.MetaMax, 0)
+	if err != nil {
+		return nil, err
+	}
+	descs := make([]roachpb.RangeDescriptor, len(rows))
+	for i := range rows {
+		if err := rows[i].ValueProto(&descs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return descs, nil
+}
+
+// checkRangeTiling verifies that the given descriptors, ordered by start
+// key, cover the entire key space without gaps or overlaps.
+func checkRangeTiling(descs []roachpb.RangeDescriptor) error {
+	if len(descs) == 0 {
+		return fmt.Errorf("no range descriptors found")
+	}
+	if !descs[0].StartKey.Equal(roachpb.RKeyMin) {
+		return fmt.Errorf("first range starts at %s, not at KeyMin", descs[0].StartKey)
+	}
+	for i := 1; i < len(descs); i++ {
+		if !descs[i-1].EndKey.Equal(descs[i].StartKey) {
+			return fmt.Errorf("range %d ends at %s but range %d starts at %s",
+				descs[i-1].RangeID, descs[i-1].EndKey, descs[i].RangeID, descs[i].StartKey)
+		}
+	}
+	if last := descs[len(descs)-1]; !last.EndKey.Equal(roachpb.RKeyMax) {
+		return fmt.Errorf("last range ends at %s, not at KeyMax", last.EndKey)
+	}
+	return nil
+}
+
+// TestSplitOnSize writes more than the maximum range size worth of data
+// and verifies that the range containing it is split, with the
+// resulting ranges tiling the key space.
+func TestSplitOnSize(t *testing.T) {
+	c := StartCluster(t)
+	defer c.AssertAndStop(t)
+
+	db, dbStopper := makeClient(t, c.ConnString(0))
+	defer dbStopper.Stop()
+
+	descs, err := getRangeDescriptors(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkRangeTiling(descs); err != nil {
+		t.Fatal(err)
+	}
+	initialRanges := len(descs)
+
+	// Write enough data under a common prefix to exceed the maximum size
+	// of the range it lands in.
+	const prefix = "split-"
+	r, _ := randutil.NewPseudoRand()
+	value := randutil.RandBytes(r, 8192)
+	var written int64
+	for i := 0; written < config.DefaultZoneConfig.RangeMaxBytes*5/4; i++ {
+		select {
+		case <-stopper:
+			t.Fatalf("interrupted")
+		default:
+		}
+		if err := db.Put(fmt.Sprintf("%s%08d", prefix, i), value); err != nil {
+			t.Fatal(err)
+		}
+		written += int64(len(value))
+	}
+	log.Infof("wrote %d bytes; waiting for split", written)
+
+	util.SucceedsWithin(t, time.Minute, func() error {
+		descs, err := getRangeDescriptors(db)
+		if err != nil {
+			return err
+		}
+		if err := checkRangeTiling(descs); err != nil {
+			t.Fatal(err)
+		}
+		if len(descs) <= initialRanges {
+			return fmt.Errorf("expected more than %d ranges, found %d", initialRanges, len(descs))
+		}
+		for _, desc := range descs[1:] {
+			if bytes.HasPrefix(desc.StartKey, []byte(prefix)) {
+				log.Infof("found split at %s; %d ranges", desc.StartKey, len(descs))
+				return nil
+			}
+		}
+		return fmt.Errorf("no split within the written data yet")
+	})
+}
@@ -109,6 +109,18 @@ func (b *Batch) fillResults(br *roachpb.BatchResponse, pErr *roachpb.Error) erro
 				if result.Err == nil {
 					row.Value = reply.(*roachpb.GetResponse).Value
 				}
+			case *roachpb.GetMultiRequest:
+				var values []roachpb.Value
+				if result.Err == nil {
+					values = reply.(*roachpb.GetMultiResponse).Values
+				}
+				for j, key := range req.Keys {
+					row := &result.Rows[j]
+					row.Key = []byte(key)
+					if j < len(values) && values[j].RawBytes != nil {
+						row.Value = &values[j]
+					}
+				}
 			case *roachpb.PutRequest:
 				row := &result.Rows[k]
 				row.Key = []byte(req.Key)
@@ -228,6 +240,31 @@ func (b *Batch) Get(key interface{}) {
 	b.initResult(1, 1, nil)
 }
 
+// GetMulti retrieves the values for several keys with a single request. A
+// new result will be appended to the batch which will contain one row per
+// key, in the order in which the keys were supplied. Rows of keys which do
+// not exist have a nil Value.
+//
+// key can be either a byte slice or a string. At least one key must be
+// given.
+func (b *Batch) GetMulti(keys ...interface{}) {
+	if len(keys) == 0 {
+		b.initResult(0, 0, fmt.Errorf("GetMulti requires at least one key"))
+		return
+	}
+	var ks []roachpb.Key
+	for _, key := range keys {
+		k, err := marshalKey(key)
+		if err != nil {
+			b.initResult(0, len(keys), err)
+			return
+		}
+		ks = append(ks, k)
+	}
+	b.reqs = append(b.reqs, roachpb.NewGetMulti(ks...))
+	b.initResult(1, len(keys), nil)
+}
+
 // Put sets the value for a key.
 //
 // A new result will be appended to the batch which will contain a single row
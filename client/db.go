@@ -276,6 +276,18 @@ func (db *DB) Get(key interface{}) (KeyValue, error) {
 	return runOneRow(db, b)
 }
 
+// GetMulti retrieves the values for several keys with a single request,
+// returning one KeyValue per key in the order in which the keys were
+// supplied.
+//
+// key can be either a byte slice or a string.
+func (db *DB) GetMulti(keys ...interface{}) ([]KeyValue, error) {
+	b := db.NewBatch()
+	b.GetMulti(keys...)
+	r, err := runOneResult(db, b)
+	return r.Rows, err
+}
+
 // GetProto retrieves the value for a key and decodes the result as a proto
 // message.
 //
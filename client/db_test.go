@@ -332,6 +332,16 @@ func TestDebugName(t *testing.T) {
 	})
 }
 
+func TestGetMultiNoKeys(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	s, db := setup()
+	defer s.Stop()
+
+	if _, err := db.GetMulti(); err == nil {
+		t.Fatal("expected GetMulti without keys to fail")
+	}
+}
+
 func TestCommonMethods(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	batchType := reflect.TypeOf(&client.Batch{})
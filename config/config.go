@@ -243,26 +243,32 @@ func (s SystemConfig) GetLargestObjectID(maxID uint32) (uint32, error) {
 
 // GetZoneConfigForKey looks up the zone config for the range containing 'key'.
 // It is the caller's responsibility to ensure that the range does not need to be split.
+// A usable config is always returned: keys which aren't governed by a more
+// specific zone get the default zone config.
 func (s SystemConfig) GetZoneConfigForKey(key roachpb.RKey) (*ZoneConfig, error) {
 	if objectID, ok := ObjectIDForKey(key); ok {
 		return s.getZoneConfigForID(objectID)
 	}
 	// Not in the structured data namespace.
-	return DefaultZoneConfig, nil
+	return s.getZoneConfigForID(keys.RootNamespaceID)
 }
 
 // getZoneConfigForID looks up the zone config for the object (table or database)
-// with 'id'.
+// with 'id'. The zone config of the root namespace is the default zone config;
+// operators may override it, and in its absence DefaultZoneConfig applies.
 func (s SystemConfig) getZoneConfigForID(id uint32) (*ZoneConfig, error) {
 	testingLock.Lock()
 	hook := ZoneConfigHook
 	testingLock.Unlock()
-	if hook == nil {
-		return nil, util.Errorf("ZoneConfigHook not set, unable to lookup zone config")
-	}
 	// For now, only user databases and tables get custom zone configs.
 	if id <= keys.MaxReservedDescID {
-		return DefaultZoneConfig, nil
+		id = keys.RootNamespaceID
+	}
+	if hook == nil {
+		if id == keys.RootNamespaceID {
+			return DefaultZoneConfig, nil
+		}
+		return nil, util.Errorf("ZoneConfigHook not set, unable to lookup zone config")
 	}
 	return hook(s, id)
 }
@@ -346,6 +352,29 @@ func (s SystemConfig) ComputeSplitKeys(startKey, endKey roachpb.RKey) []roachpb.
 	return splitKeys
 }
 
+// VisitZoneConfigs invokes fn, in key order, for each contiguous sub-span
+// of [startKey, endKey) which is governed by a single zone config. The
+// sub-spans are delimited by the keys returned by ComputeSplitKeys, so no
+// sub-span crosses a zone config boundary. Iteration stops at the first
+// error returned by fn, which is returned.
+func (s SystemConfig) VisitZoneConfigs(startKey, endKey roachpb.RKey,
+	fn func(start, end roachpb.RKey, zone *ZoneConfig) error) error {
+	if !startKey.Less(endKey) {
+		return nil
+	}
+	for _, splitKey := range append(s.ComputeSplitKeys(startKey, endKey), endKey) {
+		zone, err := s.GetZoneConfigForKey(startKey)
+		if err != nil {
+			return err
+		}
+		if err := fn(startKey, splitKey, zone); err != nil {
+			return err
+		}
+		startKey = splitKey
+	}
+	return nil
+}
+
 // NeedsSplit returns whether the range [startKey, endKey) needs a split due
 // to zone configs.
 func (s SystemConfig) NeedsSplit(startKey, endKey roachpb.RKey) bool {
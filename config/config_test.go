@@ -29,8 +29,10 @@ import (
 	"github.com/cockroachdb/cockroach/sql"
 	"github.com/cockroachdb/cockroach/sql/privilege"
 	"github.com/cockroachdb/cockroach/testutils"
+	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/encoding"
 	"github.com/cockroachdb/cockroach/util/leaktest"
+	"github.com/cockroachdb/cockroach/util/stop"
 )
 
 func plainKV(k, v string) roachpb.KeyValue {
@@ -314,3 +316,76 @@ func TestComputeSplits(t *testing.T) {
 		}
 	}
 }
+
+func TestVisitZoneConfigs(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	stopper := stop.NewStopper()
+	defer stopper.Stop()
+	config.TestingSetupZoneConfigHook(stopper)
+
+	const start = keys.MaxReservedDescID + 1
+	// Tables start and start+1 have adjacent custom zones, start+2 uses the
+	// default and start+3 has a custom zone of its own.
+	zones := map[uint32]*config.ZoneConfig{
+		start:     {RangeMaxBytes: 1 << 21},
+		start + 1: {RangeMaxBytes: 1 << 22},
+		start + 3: {RangeMaxBytes: 1 << 23},
+	}
+	for id, zone := range zones {
+		config.TestingSetZoneConfig(id, zone)
+	}
+	tableKey := func(id uint32, suffix string) roachpb.RKey {
+		return keys.MakeKey(keys.MakeTablePrefix(id), roachpb.RKey(suffix))
+	}
+
+	type span struct {
+		start, end roachpb.RKey
+		zone       *config.ZoneConfig
+	}
+	testCases := []struct {
+		start, end roachpb.RKey
+		expected   []span
+	}{
+		// Empty span.
+		{tableKey(start, "b"), tableKey(start, "a"), nil},
+		// A span nested within a single table.
+		{tableKey(start, "a"), tableKey(start, "b"),
+			[]span{{tableKey(start, "a"), tableKey(start, "b"), zones[start]}}},
+		// A span crossing adjacent tables.
+		{tableKey(start, "a"), tableKey(start+1, "b"), []span{
+			{tableKey(start, "a"), keys.MakeTablePrefix(start + 1), zones[start]},
+			{keys.MakeTablePrefix(start + 1), tableKey(start+1, "b"), zones[start+1]},
+		}},
+		// A span crossing a table without a custom zone.
+		{keys.MakeTablePrefix(start + 1), roachpb.RKeyMax, []span{
+			{keys.MakeTablePrefix(start + 1), keys.MakeTablePrefix(start + 2), zones[start+1]},
+			{keys.MakeTablePrefix(start + 2), keys.MakeTablePrefix(start + 3), config.DefaultZoneConfig},
+			{keys.MakeTablePrefix(start + 3), roachpb.RKeyMax, zones[start+3]},
+		}},
+	}
+
+	cfg := config.SystemConfig{}
+	for tcNum, tc := range testCases {
+		var spans []span
+		if err := cfg.VisitZoneConfigs(tc.start, tc.end, func(start, end roachpb.RKey, zone *config.ZoneConfig) error {
+			spans = append(spans, span{start, end, zone})
+			return nil
+		}); err != nil {
+			t.Fatalf("#%d: %s", tcNum, err)
+		}
+		if !reflect.DeepEqual(spans, tc.expected) {
+			t.Errorf("#%d: expected %+v; got %+v", tcNum, tc.expected, spans)
+		}
+	}
+
+	// An error returned by the visitor stops the iteration.
+	var visited int
+	err := cfg.VisitZoneConfigs(keys.MakeTablePrefix(start), roachpb.RKeyMax,
+		func(_, _ roachpb.RKey, _ *config.ZoneConfig) error {
+			visited++
+			return util.Errorf("stop")
+		})
+	if !testutils.IsError(err, "stop") || visited != 1 {
+		t.Errorf("expected iteration to stop after one sub-span; got %d visits and error %v", visited, err)
+	}
+}
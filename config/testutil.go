@@ -19,6 +19,7 @@ package config
 import (
 	"sync"
 
+	"github.com/cockroachdb/cockroach/keys"
 	"github.com/cockroachdb/cockroach/util/stop"
 )
 
@@ -86,5 +87,8 @@ func testingZoneConfigHook(_ SystemConfig, id uint32) (*ZoneConfig, error) {
 	if zone, ok := testingZoneConfig[id]; ok {
 		return zone, nil
 	}
+	if zone, ok := testingZoneConfig[keys.RootNamespaceID]; ok {
+		return zone, nil
+	}
 	return DefaultZoneConfig, nil
 }
@@ -258,6 +258,25 @@ func RangeMetaKey(key roachpb.RKey) roachpb.Key {
 	}
 }
 
+// DecodeRangeMetaKey is the inverse of RangeMetaKey: it returns the key
+// whose range metadata key is the given meta1 or meta2 key. KeyMin, to
+// which RangeMetaKey maps all meta1 and local keys, has no inverse and
+// results in an error, as does any key which is not a valid meta key.
+func DecodeRangeMetaKey(key roachpb.Key) (roachpb.RKey, error) {
+	rk := roachpb.RKey(key)
+	if err := validateRangeMetaKey(rk); err != nil {
+		return nil, err
+	}
+	if len(rk) == 0 {
+		return nil, NewInvalidRangeMetaKeyError("KeyMin cannot be decoded", key)
+	}
+	body := rk[len(Meta1Prefix):]
+	if rk[0] == Meta1Prefix[0] {
+		return MakeKey(Meta2Prefix, body), nil
+	}
+	return append(roachpb.RKey(nil), body...), nil
+}
+
 // validateRangeMetaKey validates that the given key is a valid Range Metadata
 // key. This checks only the constraints common to forward and backwards scans:
 // correct prefix and not exceeding KeyMax.
@@ -113,6 +113,54 @@ func TestRangeMetaKey(t *testing.T) {
 	}
 }
 
+// TestRangeMetaKeyRoundTrip verifies that DecodeRangeMetaKey inverts
+// RangeMetaKey and that the resulting meta keys are addressable, that is,
+// they fall within the span of their level of meta keys.
+func TestRangeMetaKeyRoundTrip(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	testCases := []struct {
+		key              roachpb.RKey
+		minMeta, maxMeta roachpb.Key
+	}{
+		{roachpb.RKey("a"), Meta2Prefix, Meta2KeyMax},
+		{roachpb.RKey("\x04"), Meta2Prefix, Meta2KeyMax},
+		{roachpb.RKey("\xfe\xff"), Meta2Prefix, Meta2KeyMax},
+		{roachpb.RKeyMax, Meta2Prefix, Meta2KeyMax},
+		// Keys which themselves look like meta keys.
+		{roachpb.RKey("\x03"), Meta1Prefix, Meta1KeyMax},
+		{roachpb.RKey("\x03foo"), Meta1Prefix, Meta1KeyMax},
+		{roachpb.RKey("\x03\x02foo"), Meta1Prefix, Meta1KeyMax},
+		{roachpb.RKey(Meta2KeyMax), Meta1Prefix, Meta1KeyMax},
+	}
+	for i, test := range testCases {
+		metaKey := RangeMetaKey(test.key)
+		if bytes.Compare(metaKey, test.minMeta) < 0 || bytes.Compare(metaKey, test.maxMeta) > 0 {
+			t.Errorf("%d: meta key %q for %q is outside of [%q, %q]",
+				i, metaKey, test.key, test.minMeta, test.maxMeta)
+		}
+		key, err := DecodeRangeMetaKey(metaKey)
+		if err != nil {
+			t.Errorf("%d: unexpected error decoding %q: %s", i, metaKey, err)
+			continue
+		}
+		if !key.Equal(test.key) {
+			t.Errorf("%d: expected %q to decode to %q; got %q", i, metaKey, test.key, key)
+		}
+	}
+
+	// Keys without an inverse.
+	for i, key := range []roachpb.Key{
+		roachpb.KeyMin,
+		roachpb.Key("a"),
+		roachpb.Key("\x00\x00meta2a"),
+		roachpb.Key(roachpb.MakeKey(Meta2Prefix, roachpb.RKeyMax.Next())),
+	} {
+		if _, err := DecodeRangeMetaKey(key); err == nil {
+			t.Errorf("%d: expected error decoding %q", i, key)
+		}
+	}
+}
+
 // TestMetaPrefixLen asserts that both levels of meta keys have the same prefix length,
 // as MetaScanBounds, MetaReverseScanBounds and validateRangeMetaKey depend on this fact.
 func TestMetaPrefixLen(t *testing.T) {
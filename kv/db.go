@@ -28,18 +28,24 @@ import (
 )
 
 var allExternalMethods = [...]roachpb.Request{
-	roachpb.Get:              &roachpb.GetRequest{},
-	roachpb.Put:              &roachpb.PutRequest{},
-	roachpb.ConditionalPut:   &roachpb.ConditionalPutRequest{},
-	roachpb.Increment:        &roachpb.IncrementRequest{},
-	roachpb.Delete:           &roachpb.DeleteRequest{},
-	roachpb.DeleteRange:      &roachpb.DeleteRangeRequest{},
-	roachpb.Scan:             &roachpb.ScanRequest{},
-	roachpb.ReverseScan:      &roachpb.ReverseScanRequest{},
-	roachpb.BeginTransaction: &roachpb.BeginTransactionRequest{},
-	roachpb.EndTransaction:   &roachpb.EndTransactionRequest{},
-	roachpb.AdminSplit:       &roachpb.AdminSplitRequest{},
-	roachpb.AdminMerge:       &roachpb.AdminMergeRequest{},
+	roachpb.Get:                 &roachpb.GetRequest{},
+	roachpb.GetMulti:            &roachpb.GetMultiRequest{},
+	roachpb.Put:                 &roachpb.PutRequest{},
+	roachpb.WriteBatch:          &roachpb.WriteBatchRequest{},
+	roachpb.ConditionalPut:      &roachpb.ConditionalPutRequest{},
+	roachpb.ConditionalPutBatch: &roachpb.ConditionalPutBatchRequest{},
+	roachpb.Increment:           &roachpb.IncrementRequest{},
+	roachpb.Delete:              &roachpb.DeleteRequest{},
+	roachpb.DeleteRange:         &roachpb.DeleteRangeRequest{},
+	roachpb.Scan:                &roachpb.ScanRequest{},
+	roachpb.ScanVersions:        &roachpb.ScanVersionsRequest{},
+	roachpb.ContainsRange:       &roachpb.ContainsRangeRequest{},
+	roachpb.Verify:              &roachpb.VerifyRequest{},
+	roachpb.ReverseScan:         &roachpb.ReverseScanRequest{},
+	roachpb.BeginTransaction:    &roachpb.BeginTransactionRequest{},
+	roachpb.EndTransaction:      &roachpb.EndTransactionRequest{},
+	roachpb.AdminSplit:          &roachpb.AdminSplitRequest{},
+	roachpb.AdminMerge:          &roachpb.AdminMergeRequest{},
 }
 
 // A DBServer provides an HTTP server endpoint serving the key-value API.
@@ -92,7 +98,7 @@ func verifyRequest(ba *roachpb.BatchRequest) error {
 
 		method := req.Method()
 
-		if int(method) > len(allExternalMethods) || allExternalMethods[method] == nil {
+		if int(method) >= len(allExternalMethods) || allExternalMethods[method] == nil {
 			return util.Errorf("Batch contains an internal request %s", method)
 		}
 	}
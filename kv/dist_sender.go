@@ -487,6 +487,10 @@ func (ds *DistSender) Send(ctx context.Context, ba roachpb.BatchRequest) (*roach
 		panic("empty batch")
 	}
 
+	if err := verifyPairSpans(ba); err != nil {
+		return nil, roachpb.NewError(err)
+	}
+
 	var rplChunks []*roachpb.BatchResponse
 	parts := ba.Split(false /* don't split ET */)
 	for len(parts) > 0 {
@@ -524,6 +528,29 @@ func (ds *DistSender) Send(ctx context.Context, ba roachpb.BatchRequest) (*roach
 	return reply, nil
 }
 
+// verifyPairSpans returns an error if a request in the batch writes a key
+// outside of its own span. Each range only serves the keys within the
+// span truncated to it, so such a key would otherwise not be written.
+func verifyPairSpans(ba roachpb.BatchRequest) error {
+	for _, union := range ba.Requests {
+		switch args := union.GetInner().(type) {
+		case *roachpb.WriteBatchRequest:
+			for _, kv := range args.Pairs {
+				if !args.Span.ContainsKey(kv.Key) {
+					return util.Errorf("%s: key %s outside of span [%s,%s)", args.Method(), kv.Key, args.Key, args.EndKey)
+				}
+			}
+		case *roachpb.ConditionalPutBatchRequest:
+			for _, put := range args.Puts {
+				if !args.Span.ContainsKey(put.Key) {
+					return util.Errorf("%s: key %s outside of span [%s,%s)", args.Method(), put.Key, args.Key, args.EndKey)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // sendChunk is in charge of sending an "admissible" piece of batch, i.e. one
 // which doesn't need to be subdivided further before going to a range (so no
 // mixing of forward and reverse scans, etc). The parameters and return values
@@ -745,6 +772,28 @@ func (ds *DistSender) sendChunk(ctx context.Context, ba roachpb.BatchRequest) (*
 					// NoopRequests are skipped.
 					continue
 				}
+				if scanArg, ok := args.(*roachpb.ScanRequest); ok {
+					if scanReply, ok := curReply.Responses[i].GetInner().(*roachpb.ScanResponse); ok {
+						if len(scanReply.ResumeKey) > 0 {
+							// The scan stopped short of the end of this range,
+							// be it because of a limit or its time budget, so
+							// it must not continue on the next one; the caller
+							// resumes it from the resume key instead.
+							ba.Requests[i].Reset()
+							if !ba.Requests[i].SetValue(&roachpb.NoopRequest{}) {
+								panic("RequestUnion excludes NoopRequest")
+							}
+							continue
+						}
+						// Charge what this range returned against the byte
+						// limit for the remaining ranges.
+						if scanArg.MaxBytes > 0 {
+							for _, kv := range scanReply.Rows {
+								scanArg.MaxBytes -= int64(len(kv.Value.RawBytes))
+							}
+						}
+					}
+				}
 				boundedArg, ok := args.(roachpb.Bounded)
 				if !ok {
 					// Non-bounded request. We will have to query all ranges.
@@ -281,6 +281,171 @@ func TestMultiRangeScanReverseScanInconsistent(t *testing.T) {
 	}
 }
 
+// TestMultiRangeScanResumeReason verifies that a scan spanning several
+// ranges reports why the scan as a whole stopped, rather than why the
+// first range stopped, for both the row and the byte limit.
+func TestMultiRangeScanResumeReason(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	s, db := setupMultipleRanges(t, "b", "c")
+	defer s.Stop()
+
+	for _, key := range []string{"a", "b", "bb", "c"} {
+		if err := db.Put(key, "value"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ds := kv.NewDistSender(&kv.DistSenderContext{Clock: s.Clock(), RPCContext: s.RPCContext()}, s.Gossip())
+	valSize := int64(len(roachpb.MakeValueFromString("value").RawBytes))
+	testCases := []struct {
+		maxResults, maxBytes int64
+		expRows              int
+		expReason            roachpb.ScanResumeReason
+		expResume            roachpb.Key
+	}{
+		{0, 0, 4, roachpb.COMPLETE, nil},
+		{2, 0, 2, roachpb.MAX_RESULTS, roachpb.Key("b").Next()},
+		{3, 0, 3, roachpb.MAX_RESULTS, roachpb.Key("bb").Next()},
+		{0, valSize, 1, roachpb.MAX_BYTES, roachpb.Key("a").Next()},
+		{0, 2 * valSize, 2, roachpb.MAX_BYTES, roachpb.Key("b").Next()},
+		{0, 3 * valSize, 3, roachpb.MAX_BYTES, roachpb.Key("bb").Next()},
+		{0, 10 * valSize, 4, roachpb.COMPLETE, nil},
+		// Row limit reached before the byte limit.
+		{1, 3 * valSize, 1, roachpb.MAX_RESULTS, roachpb.Key("a").Next()},
+	}
+	for i, test := range testCases {
+		sArgs := roachpb.NewScan(roachpb.Key("a"), roachpb.Key("d"), test.maxResults).(*roachpb.ScanRequest)
+		sArgs.MaxBytes = test.maxBytes
+		reply, err := client.SendWrapped(ds, nil, sArgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sr := reply.(*roachpb.ScanResponse)
+		if len(sr.Rows) != test.expRows {
+			t.Errorf("%d: expected %d rows; got %d", i, test.expRows, len(sr.Rows))
+		}
+		if sr.ResumeReason != test.expReason {
+			t.Errorf("%d: expected resume reason %s; got %s", i, test.expReason, sr.ResumeReason)
+		}
+		if !sr.ResumeKey.Equal(test.expResume) {
+			t.Errorf("%d: expected resume key %q; got %q", i, test.expResume, sr.ResumeKey)
+		}
+	}
+}
+
+// TestMultiRangeWriteBatch verifies that a WriteBatch spanning several
+// ranges writes every pair, and that a WriteBatch with a pair outside of
+// its span is rejected.
+func TestMultiRangeWriteBatch(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	s, db := setupMultipleRanges(t, "b", "c")
+	defer s.Stop()
+
+	var pairs []roachpb.KeyValue
+	for _, key := range []string{"a", "b", "bb", "c"} {
+		pairs = append(pairs, roachpb.KeyValue{Key: roachpb.Key(key), Value: roachpb.MakeValueFromString("value-" + key)})
+	}
+	b := &client.Batch{}
+	b.InternalAddRequest(roachpb.NewWriteBatch(pairs...))
+	if err := db.Run(b); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := db.Scan("a", "d", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != len(pairs) {
+		t.Fatalf("expected %d rows; got %d", len(pairs), len(rows))
+	}
+	for i, row := range rows {
+		if exp := "value-" + string(pairs[i].Key); string(row.ValueBytes()) != exp {
+			t.Errorf("%s: expected %q; got %q", row.Key, exp, row.ValueBytes())
+		}
+	}
+
+	args := roachpb.NewWriteBatch(roachpb.KeyValue{Key: roachpb.Key("d"), Value: roachpb.MakeValueFromString("d")},
+		roachpb.KeyValue{Key: roachpb.Key("e"), Value: roachpb.MakeValueFromString("e")})
+	args.Header().EndKey = roachpb.Key("e")
+	b = &client.Batch{}
+	b.InternalAddRequest(args)
+	if err := db.Run(b); !testutils.IsError(err, "outside of span") {
+		t.Fatalf("expected error for key outside of span; got %v", err)
+	}
+	for _, key := range []string{"d", "e"} {
+		if gr, err := db.Get(key); err != nil {
+			t.Fatal(err)
+		} else if gr.Exists() {
+			t.Errorf("%s: expected no value; got %q", key, gr.ValueBytes())
+		}
+	}
+}
+
+// TestMultiRangeConditionalPutBatch verifies that a ConditionalPutBatch
+// spanning several ranges applies the puts of each range, and that a
+// failed condition on one range leaves the keys of all ranges unchanged.
+func TestMultiRangeConditionalPutBatch(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	s, db := setupMultipleRanges(t, "b", "c")
+	defer s.Stop()
+
+	cputKeys := []string{"a", "b", "c"}
+	cputBatch := func(value string, expValue *roachpb.Value) error {
+		var puts []roachpb.ConditionalPutRequest
+		for _, key := range cputKeys {
+			puts = append(puts, roachpb.ConditionalPutRequest{
+				Span:     roachpb.Span{Key: roachpb.Key(key)},
+				Value:    roachpb.MakeValueFromString(value),
+				ExpValue: expValue,
+			})
+		}
+		b := &client.Batch{}
+		b.InternalAddRequest(roachpb.NewConditionalPutBatch(puts...))
+		return db.Run(b)
+	}
+	verify := func(exp map[string]string) {
+		for key, expValue := range exp {
+			if gr, err := db.Get(key); err != nil {
+				t.Fatal(err)
+			} else if v := gr.ValueBytes(); string(v) != expValue {
+				t.Errorf("%s: expected %q; got %q", key, expValue, v)
+			}
+		}
+	}
+
+	if err := cputBatch("v1", nil); err != nil {
+		t.Fatal(err)
+	}
+	verify(map[string]string{"a": "v1", "b": "v1", "c": "v1"})
+
+	// Change the value on the middle range only, so that the conditions of
+	// the other ranges still hold.
+	if err := db.Put("b", "other"); err != nil {
+		t.Fatal(err)
+	}
+	expValue := roachpb.MakeValueFromString("v1")
+	if err := cputBatch("v2", &expValue); !testutils.IsError(err, "unexpected value") {
+		t.Fatalf("expected condition failure; got %v", err)
+	}
+	verify(map[string]string{"a": "v1", "b": "other", "c": "v1"})
+
+	// A batch with a put outside of its span is rejected.
+	var puts []roachpb.ConditionalPutRequest
+	for _, key := range []string{"d", "e"} {
+		puts = append(puts, roachpb.ConditionalPutRequest{
+			Span:  roachpb.Span{Key: roachpb.Key(key)},
+			Value: roachpb.MakeValueFromString("v3"),
+		})
+	}
+	args := roachpb.NewConditionalPutBatch(puts...)
+	args.Header().EndKey = roachpb.Key("e")
+	b := &client.Batch{}
+	b.InternalAddRequest(args)
+	if err := db.Run(b); !testutils.IsError(err, "outside of span") {
+		t.Fatalf("expected error for key outside of span; got %v", err)
+	}
+	verify(map[string]string{"d": "", "e": ""})
+}
+
 func initReverseScanTestEnv(t *testing.T) (*server.TestServer, *client.DB) {
 	s := server.StartTestServer(t)
 	db := createTestClient(t, s.Stopper(), s.ServingAddr())
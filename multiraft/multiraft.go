@@ -56,6 +56,11 @@ var ErrStopped = errors.New("raft processing stopped")
 // with that group.
 var ErrReplicaIDMismatch = errors.New("raft group replicaID mismatch")
 
+// ErrProposalDropped is returned for proposals which raft refused to
+// accept, e.g. while the group's leadership is in flux. Such a proposal
+// was never appended to the log and may safely be proposed again.
+var ErrProposalDropped = errors.New("raft proposal dropped")
+
 // Config contains the parameters necessary to construct a MultiRaft object.
 type Config struct {
 	Storage   Storage
@@ -368,10 +373,12 @@ func (m *MultiRaft) SubmitCommand(groupID roachpb.RangeID, commandID string, com
 	m.proposalChan <- &proposal{
 		groupID:   groupID,
 		commandID: commandID,
-		fn: func(rn *raft.RawNode) {
+		fn: func(rn *raft.RawNode) error {
 			if err := rn.Propose(encodeCommand(commandID, command)); err != nil {
 				log.Errorf("node %v: error proposing command to group %v: %s", m.nodeID, groupID, err)
+				return err
 			}
+			return nil
 		},
 		ch: ch,
 	}
@@ -393,7 +400,7 @@ func (m *MultiRaft) ChangeGroupMembership(groupID roachpb.RangeID, commandID str
 	m.proposalChan <- &proposal{
 		groupID:   groupID,
 		commandID: commandID,
-		fn: func(rn *raft.RawNode) {
+		fn: func(rn *raft.RawNode) error {
 			ctx := ConfChangeContext{
 				CommandID: commandID,
 				Payload:   payload,
@@ -402,7 +409,7 @@ func (m *MultiRaft) ChangeGroupMembership(groupID roachpb.RangeID, commandID str
 			encodedCtx, err := ctx.Marshal()
 			if err != nil {
 				log.Errorf("node %v: error encoding context protobuf", m.nodeID)
-				return
+				return nil
 			}
 			if err := rn.ProposeConfChange(raftpb.ConfChange{
 				Type:    changeType,
@@ -412,8 +419,9 @@ func (m *MultiRaft) ChangeGroupMembership(groupID roachpb.RangeID, commandID str
 			); err != nil {
 				log.Errorf("node %v: error proposing membership change to node %v: %s", m.nodeID,
 					groupID, err)
-				return
+				return err
 			}
+			return nil
 		},
 		ch: ch,
 	}
@@ -440,7 +448,7 @@ func (m *MultiRaft) Campaign(groupID roachpb.RangeID) {
 type proposal struct {
 	groupID   roachpb.RangeID
 	commandID string
-	fn        func(*raft.RawNode)
+	fn        func(*raft.RawNode) error
 	ch        chan<- error
 }
 
@@ -821,10 +829,9 @@ func (s *state) handleMessage(req *RaftMessageRequest) {
 		return
 
 	case raftpb.MsgSnap:
-		s.lockStorage()
-		canApply := s.Storage().CanApplySnapshot(req.GroupID, req.Message.Snapshot)
-		s.unlockStorage()
-		if !canApply {
+		// CanApplySnapshot is called without the storage lock, so bypass
+		// Storage()'s assertion.
+		if !s.MultiRaft.Storage.CanApplySnapshot(req.GroupID, req.Message.Snapshot) {
 			// If the storage cannot accept the snapshot, drop it before
 			// passing it to RawNode.Step, since our error handling
 			// options past that point are limited.
@@ -1073,8 +1080,14 @@ func (s *state) propose(p *proposal) {
 	if log.V(3) {
 		log.Infof("group %d: new proposal %x", p.groupID, p.commandID)
 	}
+	_, reproposal := g.pending[p.commandID]
 	g.pending[p.commandID] = p
-	p.fn(g.raftGroup)
+	if err := p.fn(g.raftGroup); err != nil && !reproposal {
+		// Raft refused the initial proposal, so it is not in the log and
+		// the caller may propose it again. A refused re-proposal stays
+		// pending, since the original may still commit.
+		s.removePending(g, p, ErrProposalDropped)
+	}
 }
 
 func (s *state) logRaftReady(readyGroups map[roachpb.RangeID]raft.Ready) {
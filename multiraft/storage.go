@@ -55,7 +55,9 @@ type Storage interface {
 	// CanApplySnapshot should return false if attempting to apply the
 	// given snapshot would result in an error. This allows snapshots to
 	// be dropped cleanly since errors deep inside raft often result in
-	// panics.
+	// panics. Unlike the other methods, it is called without the lock
+	// returned by RaftLocker, so that the snapshot can be inspected
+	// without blocking other users of the storage.
 	CanApplySnapshot(groupID roachpb.RangeID, snap raftpb.Snapshot) bool
 
 	// AppliedIndex returns the last index which has been applied to the given group's
@@ -64,7 +66,7 @@ type Storage interface {
 	AppliedIndex(groupID roachpb.RangeID) (uint64, error)
 
 	// RaftLocker returns a lock which (if non-nil) will be acquired
-	// before calling any other Storage method. Multiple calls may be
+	// before calling any other Storage method except CanApplySnapshot. Multiple calls may be
 	// made under a single lock. If it returns a non-nil value it must
 	// return the same value on every call.
 	// This lock *may or may not* be held when calling methods of
@@ -45,6 +45,7 @@ const (
 	isWrite                // write cmds go through raft and must be proposed on leader
 	isTxn                  // txn commands may be part of a transaction
 	isTxnWrite             // txn write cmds start heartbeat and are marked for intent resolution
+	isTxnOnly              // txn-only cmds must be part of a transaction
 	isRange                // range commands may span multiple keys
 	isReverse              // reverse commands traverse ranges in descending direction
 	isAlone                // requests which must be alone in a batch
@@ -57,6 +58,7 @@ var flagMap = map[int]string{
 	isWrite:    "Wr",
 	isTxn:      "", // not useful to print this
 	isTxnWrite: "", // not useful to print this
+	isTxnOnly:  "", // not useful to print this
 	isRange:    "Rg",
 	isReverse:  "Rv",
 	isAlone:    "Al",
@@ -74,6 +76,12 @@ func IsTransactionWrite(args Request) bool {
 	return (args.flags() & isTxnWrite) != 0
 }
 
+// RequiresTxn returns true if the request may only be carried out as part
+// of a transaction, that is with the transaction set in the batch header.
+func RequiresTxn(args Request) bool {
+	return (args.flags() & isTxnOnly) != 0
+}
+
 // IsRange returns true if the operation is range-based and must include
 // a start and an end key.
 func IsRange(args Request) bool {
@@ -130,6 +138,25 @@ func (rh *ResponseHeader) Combine(otherRH *ResponseHeader) error {
 // Combine implements the Combinable interface.
 func (sr *ScanResponse) Combine(c Response) error {
 	otherSR := c.(*ScanResponse)
+	if sr != nil {
+		sr.Rows = append(sr.Rows, otherSR.Rows...)
+		// The scan isn't continued past a range which set a resume key, so
+		// only the last range can have set one, and its reason for stopping
+		// is that of the scan as a whole.
+		sr.ResumeKey = otherSR.ResumeKey
+		sr.ResumeReason = otherSR.ResumeReason
+		sr.TimedOut = otherSR.TimedOut
+		if err := sr.Header().Combine(otherSR.Header()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Combine implements the Combinable interface. Ranges are visited in
+// ascending key order, so the versions of each key remain contiguous.
+func (sr *ScanVersionsResponse) Combine(c Response) error {
+	otherSR := c.(*ScanVersionsResponse)
 	if sr != nil {
 		sr.Rows = append(sr.Rows, otherSR.Rows...)
 		if err := sr.Header().Combine(otherSR.Header()); err != nil {
@@ -139,6 +166,52 @@ func (sr *ScanResponse) Combine(c Response) error {
 	return nil
 }
 
+// Combine implements the Combinable interface. A key exists in the
+// combined span if it exists in any of its parts.
+func (cr *ContainsRangeResponse) Combine(c Response) error {
+	otherCR := c.(*ContainsRangeResponse)
+	if cr != nil {
+		cr.Exists = cr.Exists || otherCR.Exists
+		if err := cr.Header().Combine(otherCR.Header()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Combine implements the Combinable interface.
+func (vr *VerifyResponse) Combine(c Response) error {
+	otherVR := c.(*VerifyResponse)
+	if vr != nil {
+		vr.Discrepancies = append(vr.Discrepancies, otherVR.Discrepancies...)
+		if err := vr.Header().Combine(otherVR.Header()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Combine implements the Combinable interface. Descriptors already
+// returned by another range are not repeated.
+func (rr *RangeLookupMultiResponse) Combine(c Response) error {
+	otherRR := c.(*RangeLookupMultiResponse)
+	if rr != nil {
+		seen := make(map[RangeID]struct{}, len(rr.Ranges))
+		for _, desc := range rr.Ranges {
+			seen[desc.RangeID] = struct{}{}
+		}
+		for _, desc := range otherRR.Ranges {
+			if _, ok := seen[desc.RangeID]; !ok {
+				rr.Ranges = append(rr.Ranges, desc)
+			}
+		}
+		if err := rr.Header().Combine(otherRR.Header()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Combine implements the Combinable interface.
 func (sr *ReverseScanResponse) Combine(c Response) error {
 	otherSR := c.(*ReverseScanResponse)
@@ -151,11 +224,33 @@ func (sr *ReverseScanResponse) Combine(c Response) error {
 	return nil
 }
 
+// Combine implements the Combinable interface. Each key is served by
+// exactly one range, so the values returned by different ranges are
+// merged position by position.
+func (gr *GetMultiResponse) Combine(c Response) error {
+	otherGR := c.(*GetMultiResponse)
+	if gr != nil {
+		if len(gr.Values) < len(otherGR.Values) {
+			gr.Values = append(gr.Values, make([]Value, len(otherGR.Values)-len(gr.Values))...)
+		}
+		for i := range otherGR.Values {
+			if otherGR.Values[i].RawBytes != nil {
+				gr.Values[i] = otherGR.Values[i]
+			}
+		}
+		if err := gr.Header().Combine(otherGR.Header()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Combine implements the Combinable interface.
 func (dr *DeleteRangeResponse) Combine(c Response) error {
 	otherDR := c.(*DeleteRangeResponse)
 	if dr != nil {
 		dr.NumDeleted += otherDR.NumDeleted
+		dr.Keys = append(dr.Keys, otherDR.Keys...)
 		if err := dr.Header().Combine(otherDR.Header()); err != nil {
 			return err
 		}
@@ -199,6 +294,19 @@ func (gr *GetResponse) Verify(req Request) error {
 	return nil
 }
 
+// Verify verifies the integrity of every value returned by GetMulti.
+func (gr *GetMultiResponse) Verify(req Request) error {
+	keys := req.(*GetMultiRequest).Keys
+	for i := range gr.Values {
+		if gr.Values[i].RawBytes != nil && i < len(keys) {
+			if err := gr.Values[i].Verify(keys[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Verify verifies the integrity of every value returned in the scan.
 func (sr *ScanResponse) Verify(req Request) error {
 	for _, kv := range sr.Rows {
@@ -209,6 +317,16 @@ func (sr *ScanResponse) Verify(req Request) error {
 	return nil
 }
 
+// Verify verifies the integrity of every version returned by ScanVersions.
+func (sr *ScanVersionsResponse) Verify(req Request) error {
+	for _, kv := range sr.Rows {
+		if err := kv.Value.Verify(kv.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Verify verifies the integrity of every value returned in the reverse scan.
 func (sr *ReverseScanResponse) Verify(req Request) error {
 	for _, kv := range sr.Rows {
@@ -275,9 +393,15 @@ func (sr *ReverseScanResponse) Count() int64 {
 // Method implements the Request interface.
 func (*GetRequest) Method() Method { return Get }
 
+// Method implements the Request interface.
+func (*GetMultiRequest) Method() Method { return GetMulti }
+
 // Method implements the Request interface.
 func (*PutRequest) Method() Method { return Put }
 
+// Method implements the Request interface.
+func (*WriteBatchRequest) Method() Method { return WriteBatch }
+
 // Method implements the Request interface.
 func (*ConditionalPutRequest) Method() Method { return ConditionalPut }
 
@@ -293,6 +417,21 @@ func (*DeleteRangeRequest) Method() Method { return DeleteRange }
 // Method implements the Request interface.
 func (*ScanRequest) Method() Method { return Scan }
 
+// Method implements the Request interface.
+func (*ScanVersionsRequest) Method() Method { return ScanVersions }
+
+// Method implements the Request interface.
+func (*ContainsRangeRequest) Method() Method { return ContainsRange }
+
+// Method implements the Request interface.
+func (*VerifyRequest) Method() Method { return Verify }
+
+// Method implements the Request interface.
+func (*ComputeChecksumRequest) Method() Method { return ComputeChecksum }
+
+// Method implements the Request interface.
+func (*ConditionalPutBatchRequest) Method() Method { return ConditionalPutBatch }
+
 // Method implements the Request interface.
 func (*ReverseScanRequest) Method() Method { return ReverseScan }
 
@@ -320,6 +459,9 @@ func (*PushTxnRequest) Method() Method { return PushTxn }
 // Method implements the Request interface.
 func (*RangeLookupRequest) Method() Method { return RangeLookup }
 
+// Method implements the Request interface.
+func (*RangeLookupMultiRequest) Method() Method { return RangeLookupMulti }
+
 // Method implements the Request interface.
 func (*ResolveIntentRequest) Method() Method { return ResolveIntent }
 
@@ -341,9 +483,15 @@ func (*LeaderLeaseRequest) Method() Method { return LeaderLease }
 // CreateReply implements the Request interface.
 func (*GetRequest) CreateReply() Response { return &GetResponse{} }
 
+// CreateReply implements the Request interface.
+func (*GetMultiRequest) CreateReply() Response { return &GetMultiResponse{} }
+
 // CreateReply implements the Request interface.
 func (*PutRequest) CreateReply() Response { return &PutResponse{} }
 
+// CreateReply implements the Request interface.
+func (*WriteBatchRequest) CreateReply() Response { return &WriteBatchResponse{} }
+
 // CreateReply implements the Request interface.
 func (*ConditionalPutRequest) CreateReply() Response { return &ConditionalPutResponse{} }
 
@@ -359,6 +507,21 @@ func (*DeleteRangeRequest) CreateReply() Response { return &DeleteRangeResponse{
 // CreateReply implements the Request interface.
 func (*ScanRequest) CreateReply() Response { return &ScanResponse{} }
 
+// CreateReply implements the Request interface.
+func (*ScanVersionsRequest) CreateReply() Response { return &ScanVersionsResponse{} }
+
+// CreateReply implements the Request interface.
+func (*ContainsRangeRequest) CreateReply() Response { return &ContainsRangeResponse{} }
+
+// CreateReply implements the Request interface.
+func (*VerifyRequest) CreateReply() Response { return &VerifyResponse{} }
+
+// CreateReply implements the Request interface.
+func (*ComputeChecksumRequest) CreateReply() Response { return &ComputeChecksumResponse{} }
+
+// CreateReply implements the Request interface.
+func (*ConditionalPutBatchRequest) CreateReply() Response { return &ConditionalPutBatchResponse{} }
+
 // CreateReply implements the Request interface.
 func (*ReverseScanRequest) CreateReply() Response { return &ReverseScanResponse{} }
 
@@ -386,6 +549,9 @@ func (*PushTxnRequest) CreateReply() Response { return &PushTxnResponse{} }
 // CreateReply implements the Request interface.
 func (*RangeLookupRequest) CreateReply() Response { return &RangeLookupResponse{} }
 
+// CreateReply implements the Request interface.
+func (*RangeLookupMultiRequest) CreateReply() Response { return &RangeLookupMultiResponse{} }
+
 // CreateReply implements the Request interface.
 func (*ResolveIntentRequest) CreateReply() Response { return &ResolveIntentResponse{} }
 
@@ -415,6 +581,22 @@ func NewGet(key Key) Request {
 	}
 }
 
+// NewGetMulti returns a Request initialized to get the values at the
+// given keys. The span of the request is set to cover all of the keys;
+// without any keys the span is empty and the request is rejected.
+func NewGetMulti(keys ...Key) Request {
+	args := &GetMultiRequest{Keys: keys}
+	for _, key := range keys {
+		if len(args.Key) == 0 || bytes.Compare(key, args.Key) < 0 {
+			args.Key = key
+		}
+		if end := key.Next(); len(args.EndKey) == 0 || bytes.Compare(end, args.EndKey) > 0 {
+			args.EndKey = end
+		}
+	}
+	return args
+}
+
 // NewIncrement returns a Request initialized to increment the value at
 // key by increment.
 func NewIncrement(key Key, increment int64) Request {
@@ -437,6 +619,23 @@ func NewPut(key Key, value Value) Request {
 	}
 }
 
+// NewWriteBatch returns a Request initialized to put the given key/value
+// pairs. The span of the request is set to cover the keys of all pairs.
+func NewWriteBatch(pairs ...KeyValue) Request {
+	args := &WriteBatchRequest{Pairs: append([]KeyValue(nil), pairs...)}
+	for i := range args.Pairs {
+		key := args.Pairs[i].Key
+		args.Pairs[i].Value.InitChecksum(key)
+		if len(args.Key) == 0 || bytes.Compare(key, args.Key) < 0 {
+			args.Key = key
+		}
+		if end := key.Next(); len(args.EndKey) == 0 || bytes.Compare(end, args.EndKey) > 0 {
+			args.EndKey = end
+		}
+	}
+	return args
+}
+
 // NewConditionalPut returns a Request initialized to put value as a byte
 // slice at key if the existing value at key equals expValueBytes.
 func NewConditionalPut(key Key, value, expValue Value) Request {
@@ -455,6 +654,29 @@ func NewConditionalPut(key Key, value, expValue Value) Request {
 	}
 }
 
+// NewConditionalPutBatch returns a Request initialized to apply the given
+// conditional puts atomically. The span of the request is set to cover
+// the keys of all puts.
+func NewConditionalPutBatch(puts ...ConditionalPutRequest) Request {
+	args := &ConditionalPutBatchRequest{Puts: append([]ConditionalPutRequest(nil), puts...)}
+	for i := range args.Puts {
+		put := &args.Puts[i]
+		put.Value.InitChecksum(put.Key)
+		if put.ExpValue != nil {
+			expValue := *put.ExpValue
+			expValue.InitChecksum(put.Key)
+			put.ExpValue = &expValue
+		}
+		if len(args.Key) == 0 || bytes.Compare(put.Key, args.Key) < 0 {
+			args.Key = put.Key
+		}
+		if end := put.Key.Next(); len(args.EndKey) == 0 || bytes.Compare(end, args.EndKey) > 0 {
+			args.EndKey = end
+		}
+	}
+	return args
+}
+
 // NewDelete returns a Request initialized to delete the value at key.
 func NewDelete(key Key) Request {
 	return &DeleteRequest{
@@ -499,6 +721,19 @@ func NewReverseScan(key, endKey Key, maxResults int64) Request {
 	}
 }
 
+// Matches returns whether v satisfies all conditions set on the predicate.
+// The value prefix is compared against the value's contents, excluding its
+// header.
+func (p *DeleteRangePredicate) Matches(v Value) bool {
+	if p.Before != nil && !v.Timestamp.Less(*p.Before) {
+		return false
+	}
+	if p.ValuePrefix != nil && !bytes.HasPrefix(v.dataBytes(), p.ValuePrefix) {
+		return false
+	}
+	return true
+}
+
 func flagsToStr(flags int) string {
 	var buf bytes.Buffer
 	for flag := 1; flag < flagMax; flag = flag << 1 {
@@ -510,24 +745,35 @@ func flagsToStr(flags int) string {
 }
 
 func (*GetRequest) flags() int                { return isRead | isTxn }
+func (*GetMultiRequest) flags() int           { return isRead | isRange | isTxn }
 func (*PutRequest) flags() int                { return isWrite | isTxn | isTxnWrite }
+func (*WriteBatchRequest) flags() int         { return isWrite | isRange | isTxn | isTxnWrite }
 func (*ConditionalPutRequest) flags() int     { return isRead | isWrite | isTxn | isTxnWrite }
 func (*IncrementRequest) flags() int          { return isRead | isWrite | isTxn | isTxnWrite }
 func (*DeleteRequest) flags() int             { return isWrite | isTxn | isTxnWrite }
 func (*DeleteRangeRequest) flags() int        { return isWrite | isTxn | isTxnWrite | isRange }
 func (*ScanRequest) flags() int               { return isRead | isRange | isTxn }
+func (*ScanVersionsRequest) flags() int       { return isRead | isRange }
+func (*ContainsRangeRequest) flags() int      { return isRead | isRange | isTxn }
+func (*VerifyRequest) flags() int             { return isRead | isRange }
+func (*ComputeChecksumRequest) flags() int    { return isRead | isAlone }
 func (*ReverseScanRequest) flags() int        { return isRead | isRange | isReverse | isTxn }
-func (*BeginTransactionRequest) flags() int   { return isWrite | isTxn }
-func (*EndTransactionRequest) flags() int     { return isWrite | isTxn | isAlone }
+func (*BeginTransactionRequest) flags() int   { return isWrite | isTxn | isTxnOnly }
+func (*EndTransactionRequest) flags() int     { return isWrite | isTxn | isTxnOnly | isAlone }
 func (*AdminSplitRequest) flags() int         { return isAdmin | isAlone }
 func (*AdminMergeRequest) flags() int         { return isAdmin | isAlone }
-func (*HeartbeatTxnRequest) flags() int       { return isWrite | isTxn }
+func (*HeartbeatTxnRequest) flags() int       { return isWrite | isTxn | isTxnOnly }
 func (*GCRequest) flags() int                 { return isWrite | isRange }
 func (*PushTxnRequest) flags() int            { return isWrite }
 func (*RangeLookupRequest) flags() int        { return isRead | isTxn }
+func (*RangeLookupMultiRequest) flags() int   { return isRead | isRange | isTxn }
 func (*ResolveIntentRequest) flags() int      { return isWrite }
 func (*ResolveIntentRangeRequest) flags() int { return isWrite | isRange }
 func (*NoopRequest) flags() int               { return isRead } // slightly special
 func (*MergeRequest) flags() int              { return isWrite }
 func (*TruncateLogRequest) flags() int        { return isWrite }
 func (*LeaderLeaseRequest) flags() int        { return isWrite }
+
+func (*ConditionalPutBatchRequest) flags() int {
+	return isRead | isWrite | isRange | isTxn | isTxnWrite
+}
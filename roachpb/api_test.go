@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/cockroach/util/retry"
+	"github.com/gogo/protobuf/proto"
 )
 
 type testError struct{}
@@ -141,3 +142,65 @@ func TestSetGoErrorCopy(t *testing.T) {
 		t.Fatalf("SetGoError did not create a new error")
 	}
 }
+
+// TestUnionCoversAllMethods verifies that every method other than Batch
+// has exactly one request type in RequestUnion, and that each request and
+// its reply survive a round trip through their union's wire encoding.
+// Adding a method without wiring up both unions fails here rather than at
+// runtime.
+func TestUnionCoversAllMethods(t *testing.T) {
+	seen := map[Method]string{}
+	ut := reflect.TypeOf(RequestUnion{})
+	for i := 0; i < ut.NumField(); i++ {
+		field := ut.Field(i)
+		args, ok := reflect.New(field.Type.Elem()).Interface().(Request)
+		if !ok {
+			t.Errorf("%s: %s is not a Request", field.Name, field.Type)
+			continue
+		}
+		method := args.Method()
+		if prev, ok := seen[method]; ok {
+			t.Errorf("%s: method %s is already served by %s", field.Name, method, prev)
+		}
+		seen[method] = field.Name
+
+		var ru RequestUnion
+		if !ru.SetValue(args) {
+			t.Errorf("%s: request %T not accepted by RequestUnion", field.Name, args)
+			continue
+		}
+		data, err := proto.Marshal(&ru)
+		if err != nil {
+			t.Fatalf("%s: %s", field.Name, err)
+		}
+		ru = RequestUnion{}
+		if err := proto.Unmarshal(data, &ru); err != nil {
+			t.Fatalf("%s: %s", field.Name, err)
+		}
+		if inner := ru.GetInner(); reflect.TypeOf(inner) != field.Type {
+			t.Errorf("%s: expected %s after round trip; got %T", field.Name, field.Type, inner)
+		}
+
+		reply := args.CreateReply()
+		var rsu ResponseUnion
+		if !rsu.SetValue(reply) {
+			t.Errorf("%s: reply %T not accepted by ResponseUnion", field.Name, reply)
+			continue
+		}
+		if data, err = proto.Marshal(&rsu); err != nil {
+			t.Fatalf("%s: %s", field.Name, err)
+		}
+		rsu = ResponseUnion{}
+		if err := proto.Unmarshal(data, &rsu); err != nil {
+			t.Fatalf("%s: %s", field.Name, err)
+		}
+		if inner := rsu.GetInner(); reflect.TypeOf(inner) != reflect.TypeOf(reply) {
+			t.Errorf("%s: expected %T after round trip; got %T", field.Name, reply, inner)
+		}
+	}
+	for m := Method(0); m < Batch; m++ {
+		if _, ok := seen[m]; !ok {
+			t.Errorf("method %s has no request type in RequestUnion", m)
+		}
+	}
+}
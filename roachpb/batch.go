@@ -224,7 +224,7 @@ func (ba BatchRequest) Split(canSplitET bool) [][]RequestUnion {
 		if exFlags == 0 || (!canSplitET && method == EndTransaction) {
 			return true
 		}
-		if (newFlags & isAlone) != 0 {
+		if ((exFlags | newFlags) & isAlone) != 0 {
 			return false
 		}
 		// Otherwise, the flags below must remain the same with the new
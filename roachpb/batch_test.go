@@ -34,6 +34,7 @@ func TestBatchSplit(t *testing.T) {
 	et := &EndTransactionRequest{}
 	rv := &ReverseScanRequest{}
 	np := &NoopRequest{}
+	cc := &ComputeChecksumRequest{}
 	testCases := []struct {
 		reqs       []Request
 		sizes      []int
@@ -58,6 +59,8 @@ func TestBatchSplit(t *testing.T) {
 		{[]Request{np, spl, np}, []int{3}, true},
 		{[]Request{np, rv, np}, []int{3}, true},
 		{[]Request{np, np, et}, []int{3}, true}, // et does not split off
+		// A request which must be alone is split off from reads as well.
+		{[]Request{get, cc, get, put, cc}, []int{1, 1, 1, 1, 1}, true},
 	}
 
 	for i, test := range testCases {
@@ -91,14 +91,15 @@ func (rk RKey) Next() RKey {
 }
 
 // PrefixEnd determines the end key given key as a prefix, that is the
-// key that sorts precisely behind all keys starting with prefix: "1"
-// is added to the final byte and the carry propagated. The special
-// cases of nil and KeyMin always returns KeyMax.
+// key that sorts precisely behind all keys starting with prefix: trailing
+// \xff bytes are stripped and "1" is added to the final remaining byte.
+// The special cases of nil and KeyMin always return KeyMax, as does a
+// prefix consisting solely of \xff bytes, which has no finite end.
 func (rk RKey) PrefixEnd() RKey {
-	if len(rk) == 0 {
-		return RKeyMax
+	if end := bytesPrefixEnd(rk); end != nil {
+		return RKey(end)
 	}
-	return RKey(bytesPrefixEnd(rk))
+	return RKeyMax
 }
 
 func (rk RKey) String() string {
@@ -125,20 +126,24 @@ func BytesNext(b []byte) []byte {
 	return append(append([]byte(nil), b...), 0)
 }
 
+// bytesPrefixEnd returns the smallest byte string which sorts after all
+// byte strings prefixed by b: trailing \xff bytes are dropped and the
+// last remaining byte is incremented. Nil is returned if b is empty or
+// consists solely of \xff bytes, as no such byte string exists.
 func bytesPrefixEnd(b []byte) []byte {
-	end := append([]byte(nil), b...)
-	for i := len(end) - 1; i >= 0; i-- {
-		end[i] = end[i] + 1
-		if end[i] != 0 {
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] != 0xff {
+			end := append([]byte(nil), b[:i+1]...)
+			end[i]++
 			return end
 		}
 	}
-	// This statement will only be reached if the key is already a
-	// maximal byte string (i.e. already \xff...).
-	return b
+	return nil
 }
 
-// Next returns the next key in lexicographic sort order.
+// Next returns the next key in lexicographic sort order. Note that the
+// successor of KeyMax sorts after KeyMax and is thus not a valid key;
+// callers must check against KeyMax where that matters.
 func (k Key) Next() Key {
 	return Key(BytesNext(k))
 }
@@ -150,14 +155,15 @@ func (k Key) IsPrev(m Key) bool {
 }
 
 // PrefixEnd determines the end key given key as a prefix, that is the
-// key that sorts precisely behind all keys starting with prefix: "1"
-// is added to the final byte and the carry propagated. The special
-// cases of nil and KeyMin always returns KeyMax.
+// key that sorts precisely behind all keys starting with prefix: trailing
+// \xff bytes are stripped and "1" is added to the final remaining byte.
+// The special cases of nil and KeyMin always return KeyMax, as does a
+// prefix consisting solely of \xff bytes, which has no finite end.
 func (k Key) PrefixEnd() Key {
-	if len(k) == 0 {
-		return Key(RKeyMax)
+	if end := bytesPrefixEnd(k); end != nil {
+		return Key(end)
 	}
-	return Key(bytesPrefixEnd(k))
+	return Key(RKeyMax)
 }
 
 // Equal returns whether two keys are identical.
@@ -280,6 +286,11 @@ const (
 	checksumSize        = 4
 	tagPos              = checksumSize
 	headerSize          = tagPos + 1
+	// valueExpiresFlag is set on the tag byte of values which carry an
+	// expiration. The 8-byte expiration wall time immediately follows the
+	// tag and precedes the data.
+	valueExpiresFlag = 0x80
+	expirationSize   = 8
 )
 
 func (v Value) checksum() uint32 {
@@ -364,17 +375,67 @@ func (v Value) GetTag() ValueType {
 	if len(v.RawBytes) <= tagPos {
 		return ValueType_UNKNOWN
 	}
-	return ValueType(v.RawBytes[tagPos])
+	return ValueType(v.RawBytes[tagPos] &^ valueExpiresFlag)
 }
 
 func (v *Value) setTag(t ValueType) {
 	v.RawBytes[tagPos] = byte(t)
 }
 
+func (v Value) hasExpiration() bool {
+	return len(v.RawBytes) >= headerSize+expirationSize &&
+		v.RawBytes[tagPos]&valueExpiresFlag != 0
+}
+
 func (v Value) dataBytes() []byte {
+	if v.hasExpiration() {
+		return v.RawBytes[headerSize+expirationSize:]
+	}
 	return v.RawBytes[headerSize:]
 }
 
+// SetExpiration marks the value as expiring at the given wall time (in
+// nanoseconds). It must be called after the contents of the value have
+// been set and clears the checksum. A value which has expired is treated
+// as absent by reads.
+func (v *Value) SetExpiration(wallTime int64) {
+	if len(v.RawBytes) < headerSize {
+		return
+	}
+	data := v.dataBytes()
+	b := make([]byte, headerSize, headerSize+expirationSize+len(data))
+	b[tagPos] = byte(v.GetTag()) | valueExpiresFlag
+	b = encoding.EncodeUint64(b, uint64(wallTime))
+	v.RawBytes = append(b, data...)
+}
+
+// Expiration returns the wall time (in nanoseconds) at which the value
+// expires. The boolean is false if the value does not expire.
+func (v Value) Expiration() (int64, bool) {
+	if !v.hasExpiration() {
+		return 0, false
+	}
+	_, u, err := encoding.DecodeUint64(v.RawBytes[headerSize : headerSize+expirationSize])
+	if err != nil {
+		panic(err)
+	}
+	return int64(u), true
+}
+
+// IsExpired returns true if the value carries an expiration which is at
+// or before the given timestamp.
+func (v Value) IsExpired(ts Timestamp) bool {
+	exp, ok := v.Expiration()
+	return ok && exp <= ts.WallTime
+}
+
+// IsTombstone returns true if the value carries no data. Scans which
+// include deleted keys return such values, stamped with the timestamp of
+// the deletion, for keys which are deleted or whose value has expired.
+func (v Value) IsTombstone() bool {
+	return len(v.RawBytes) == 0
+}
+
 // SetBytes sets the bytes and tag field of the receiver and clears the checksum.
 func (v *Value) SetBytes(b []byte) {
 	v.RawBytes = make([]byte, headerSize+len(b))
@@ -784,6 +845,11 @@ func (s Span) Equal(o Span) bool {
 	return bytes.Equal(s.Key, o.Key) && bytes.Equal(s.EndKey, o.EndKey)
 }
 
+// ContainsKey returns whether this span contains the specified key.
+func (s Span) ContainsKey(key Key) bool {
+	return bytes.Compare(key, s.Key) >= 0 && bytes.Compare(key, s.EndKey) < 0
+}
+
 // RSpan is a key range with an inclusive start RKey and an exclusive end RKey.
 type RSpan struct {
 	Key, EndKey RKey
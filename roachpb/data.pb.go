@@ -306,6 +306,8 @@ type ChangeReplicasTrigger struct {
 	// The new replica list with this change applied.
 	UpdatedReplicas []ReplicaDescriptor `protobuf:"bytes,3,rep,name=updated_replicas" json:"updated_replicas"`
 	NextReplicaID   ReplicaID           `protobuf:"varint,4,opt,name=next_replica_id,casttype=ReplicaID" json:"next_replica_id"`
+	// The generation of the range descriptor with this change applied.
+	Generation int64 `protobuf:"varint,5,opt,name=generation" json:"generation"`
 }
 
 func (m *ChangeReplicasTrigger) Reset()         { *m = ChangeReplicasTrigger{} }
@@ -769,6 +771,9 @@ func (m *ChangeReplicasTrigger) MarshalTo(data []byte) (int, error) {
 	data[i] = 0x20
 	i++
 	i = encodeVarintData(data, i, uint64(m.NextReplicaID))
+	data[i] = 0x28
+	i++
+	i = encodeVarintData(data, i, uint64(m.Generation))
 	return i, nil
 }
 
@@ -1248,6 +1253,7 @@ func (m *ChangeReplicasTrigger) Size() (n int) {
 		}
 	}
 	n += 1 + sovData(uint64(m.NextReplicaID))
+	n += 1 + sovData(uint64(m.Generation))
 	return n
 }
 
@@ -2270,6 +2276,25 @@ func (m *ChangeReplicasTrigger) Unmarshal(data []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Generation", wireType)
+			}
+			m.Generation = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Generation |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipData(data[iNdEx:])
@@ -72,20 +72,35 @@ func TestKeyPrefixEnd(t *testing.T) {
 		key Key
 		end Key
 	}{
+		{nil, KeyMax},
 		{Key{}, KeyMax},
 		{Key{0}, Key{0x01}},
-		{Key{0xff}, Key{0xff}},
-		{Key{0xff, 0xff}, Key{0xff, 0xff}},
+		{Key{0xff}, KeyMax},
+		{Key{0xff, 0xff}, KeyMax},
+		{Key{0xff, 0xff, 0xff}, KeyMax},
 		{KeyMax, KeyMax},
 		{Key{0xff, 0xfe}, Key{0xff, 0xff}},
 		{Key{0x00, 0x00}, Key{0x00, 0x01}},
-		{Key{0x00, 0xff}, Key{0x01, 0x00}},
-		{Key{0x00, 0xff, 0xff}, Key{0x01, 0x00, 0x00}},
+		{Key{0x00, 0xff}, Key{0x01}},
+		{Key{0x00, 0xff, 0xff}, Key{0x01}},
+		{Key{0x61, 0xff, 0x62, 0xff}, Key{0x61, 0xff, 0x63}},
 	}
 	for i, c := range testCases {
 		if !bytes.Equal(c.key.PrefixEnd(), c.end) {
 			t.Errorf("%d: unexpected prefix end bytes for %q: %q", i, c.key, c.key.PrefixEnd())
 		}
+		if !bytes.Equal(RKey(c.key).PrefixEnd(), c.end) {
+			t.Errorf("%d: unexpected RKey prefix end bytes for %q: %q", i, c.key, RKey(c.key).PrefixEnd())
+		}
+		// Unless the prefix has no finite end, every key with the prefix
+		// must sort before the prefix end.
+		if !c.end.Equal(KeyMax) {
+			for _, suffix := range []string{"", "\x00", "\xff", "\xff\xff\xff"} {
+				if k := append(append(Key(nil), c.key...), suffix...); bytes.Compare(k, c.end) >= 0 {
+					t.Errorf("%d: expected %q to sort before prefix end %q", i, k, c.end)
+				}
+			}
+		}
 	}
 }
 
@@ -150,6 +165,8 @@ func TestNextKey(t *testing.T) {
 		{Key("test key"), Key("test key\x00")},
 		{Key("\xff\xff"), Key("\xff\xff\x00")},
 		{Key("xoxo\x00"), Key("xoxo\x00\x00")},
+		{Key("a\xff"), Key("a\xff\x00")},
+		{KeyMax, Key("\xff\xff\x00")},
 	}
 	for i, c := range testCases {
 		if !c.key.Next().Equal(c.next) {
@@ -272,6 +289,31 @@ func TestValueChecksumWithBytes(t *testing.T) {
 	}
 }
 
+func TestValueExpiration(t *testing.T) {
+	k := []byte("key")
+	v := MakeValueFromString("abc")
+	if _, ok := v.Expiration(); ok {
+		t.Fatal("expected value without expiration")
+	}
+	v.SetExpiration(10)
+	v.InitChecksum(k)
+	if err := v.Verify(k); err != nil {
+		t.Fatal(err)
+	}
+	if exp, ok := v.Expiration(); !ok || exp != 10 {
+		t.Fatalf("expected expiration 10; got %d, %t", exp, ok)
+	}
+	if b, err := v.GetBytes(); err != nil || string(b) != "abc" {
+		t.Fatalf("expected bytes \"abc\"; got %q, %v", b, err)
+	}
+	if v.IsExpired(Timestamp{WallTime: 9}) {
+		t.Error("expected value to be live before its expiration")
+	}
+	if !v.IsExpired(Timestamp{WallTime: 10}) {
+		t.Error("expected value to be expired at its expiration")
+	}
+}
+
 func TestSetGetChecked(t *testing.T) {
 	v := Value{}
 
@@ -457,6 +499,51 @@ func TestTransactionUpdate(t *testing.T) {
 
 }
 
+// TestTransactionRestart verifies that a restart bumps the epoch, moves the
+// timestamps forward and never lowers the priority, while adopting the
+// upgrade priority so that a txn which lost a push can win on retry.
+func TestTransactionRestart(t *testing.T) {
+	txn := Transaction{
+		Priority:      10,
+		Timestamp:     makeTS(20, 0),
+		OrigTimestamp: makeTS(10, 0),
+	}
+
+	// A negative user priority yields that exact (positive) priority.
+	txn.Restart(-5, 0, makeTS(15, 0))
+	if txn.Epoch != 1 {
+		t.Errorf("expected epoch 1; got %d", txn.Epoch)
+	}
+	if txn.Priority != 10 {
+		t.Errorf("expected priority to stay at 10; got %d", txn.Priority)
+	}
+	if !txn.Timestamp.Equal(makeTS(20, 0)) || !txn.OrigTimestamp.Equal(txn.Timestamp) {
+		t.Errorf("expected timestamps to stay at %s; got %s, %s", makeTS(20, 0), txn.Timestamp, txn.OrigTimestamp)
+	}
+
+	// The upgrade priority, e.g. that of the txn which won a push, is adopted.
+	txn.Restart(-5, 50, makeTS(30, 0))
+	if txn.Priority != 50 {
+		t.Errorf("expected priority 50; got %d", txn.Priority)
+	}
+	if !txn.Timestamp.Equal(makeTS(30, 0)) || !txn.OrigTimestamp.Equal(txn.Timestamp) {
+		t.Errorf("expected timestamps to move to %s; got %s, %s", makeTS(30, 0), txn.Timestamp, txn.OrigTimestamp)
+	}
+
+	// A new random priority is adopted only if it's higher.
+	txn.Restart(-70, 20, makeTS(30, 0))
+	if txn.Priority != 70 {
+		t.Errorf("expected priority 70; got %d", txn.Priority)
+	}
+	txn.Restart(1, 0, makeTS(30, 0))
+	if txn.Priority < 70 {
+		t.Errorf("expected priority not to decrease below 70; got %d", txn.Priority)
+	}
+	if txn.Epoch != 4 {
+		t.Errorf("expected epoch 4; got %d", txn.Epoch)
+	}
+}
+
 func TestIsPrev(t *testing.T) {
 	for i, tc := range []struct {
 		k, m Key
@@ -222,6 +222,17 @@ func (*RangeNotFoundError) CanRetry() bool {
 	return true
 }
 
+// Error formats error.
+func (e *RangeOverloadedError) Error() string {
+	return fmt.Sprintf("range %d overloaded: %d commands pending in raft", e.RangeID, e.Pending)
+}
+
+// CanRetry indicates that the client should back off and retry once the
+// backlog has drained.
+func (*RangeOverloadedError) CanRetry() bool {
+	return true
+}
+
 // NewRangeKeyMismatchError initializes a new RangeKeyMismatchError.
 func NewRangeKeyMismatchError(start, end Key, desc *RangeDescriptor) *RangeKeyMismatchError {
 	return &RangeKeyMismatchError{
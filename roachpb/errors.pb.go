@@ -220,6 +220,8 @@ func (*OpRequiresTxnError) ProtoMessage()    {}
 type ConditionFailedError struct {
 	ActualValue *Value       `protobuf:"bytes,1,opt,name=actual_value" json:"actual_value,omitempty"`
 	Index       *ErrPosition `protobuf:"bytes,2,opt,name=index" json:"index,omitempty"`
+	// The key whose condition failed, if known.
+	Key Key `protobuf:"bytes,3,opt,name=key,casttype=Key" json:"key,omitempty"`
 }
 
 func (m *ConditionFailedError) Reset()         { *m = ConditionFailedError{} }
@@ -249,6 +251,18 @@ func (m *SendError) Reset()         { *m = SendError{} }
 func (m *SendError) String() string { return proto.CompactTextString(m) }
 func (*SendError) ProtoMessage()    {}
 
+// A RangeOverloadedError indicates that a write was shed because the
+// replica's raft backlog did not drain in time. The write should be
+// retried after a backoff.
+type RangeOverloadedError struct {
+	RangeID RangeID `protobuf:"varint,1,opt,name=range_id,casttype=RangeID" json:"range_id"`
+	Pending int32   `protobuf:"varint,2,opt,name=pending" json:"pending"`
+}
+
+func (m *RangeOverloadedError) Reset()         { *m = RangeOverloadedError{} }
+func (m *RangeOverloadedError) String() string { return proto.CompactTextString(m) }
+func (*RangeOverloadedError) ProtoMessage()    {}
+
 // ErrorDetail is a union type containing all available errors.
 type ErrorDetail struct {
 	NotLeader                     *NotLeaderError                     `protobuf:"bytes,1,opt,name=not_leader" json:"not_leader,omitempty"`
@@ -266,6 +280,7 @@ type ErrorDetail struct {
 	LeaseRejected                 *LeaseRejectedError                 `protobuf:"bytes,13,opt,name=lease_rejected" json:"lease_rejected,omitempty"`
 	NodeUnavailable               *NodeUnavailableError               `protobuf:"bytes,14,opt,name=node_unavailable" json:"node_unavailable,omitempty"`
 	Send                          *SendError                          `protobuf:"bytes,15,opt,name=send" json:"send,omitempty"`
+	RangeOverloaded               *RangeOverloadedError               `protobuf:"bytes,16,opt,name=range_overloaded" json:"range_overloaded,omitempty"`
 }
 
 func (m *ErrorDetail) Reset()         { *m = ErrorDetail{} }
@@ -318,6 +333,7 @@ func init() {
 	proto.RegisterType((*ConditionFailedError)(nil), "cockroach.roachpb.ConditionFailedError")
 	proto.RegisterType((*LeaseRejectedError)(nil), "cockroach.roachpb.LeaseRejectedError")
 	proto.RegisterType((*SendError)(nil), "cockroach.roachpb.SendError")
+	proto.RegisterType((*RangeOverloadedError)(nil), "cockroach.roachpb.RangeOverloadedError")
 	proto.RegisterType((*ErrorDetail)(nil), "cockroach.roachpb.ErrorDetail")
 	proto.RegisterType((*ErrPosition)(nil), "cockroach.roachpb.ErrPosition")
 	proto.RegisterType((*Error)(nil), "cockroach.roachpb.Error")
@@ -741,6 +757,12 @@ func (m *ConditionFailedError) MarshalTo(data []byte) (int, error) {
 		}
 		i += n16
 	}
+	if m.Key != nil {
+		data[i] = 0x1a
+		i++
+		i = encodeVarintErrors(data, i, uint64(len(m.Key)))
+		i += copy(data[i:], m.Key)
+	}
 	return i, nil
 }
 
@@ -812,6 +834,30 @@ func (m *SendError) MarshalTo(data []byte) (int, error) {
 	return i, nil
 }
 
+func (m *RangeOverloadedError) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *RangeOverloadedError) MarshalTo(data []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0x8
+	i++
+	i = encodeVarintErrors(data, i, uint64(m.RangeID))
+	data[i] = 0x10
+	i++
+	i = encodeVarintErrors(data, i, uint64(m.Pending))
+	return i, nil
+}
+
 func (m *ErrorDetail) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
@@ -977,6 +1023,18 @@ func (m *ErrorDetail) MarshalTo(data []byte) (int, error) {
 		}
 		i += n33
 	}
+	if m.RangeOverloaded != nil {
+		data[i] = 0x82
+		i++
+		data[i] = 0x1
+		i++
+		i = encodeVarintErrors(data, i, uint64(m.RangeOverloaded.Size()))
+		n34, err := m.RangeOverloaded.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n34
+	}
 	return i, nil
 }
 
@@ -1212,6 +1270,10 @@ func (m *ConditionFailedError) Size() (n int) {
 		l = m.Index.Size()
 		n += 1 + l + sovErrors(uint64(l))
 	}
+	if m.Key != nil {
+		l = len(m.Key)
+		n += 1 + l + sovErrors(uint64(l))
+	}
 	return n
 }
 
@@ -1236,6 +1298,14 @@ func (m *SendError) Size() (n int) {
 	return n
 }
 
+func (m *RangeOverloadedError) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovErrors(uint64(m.RangeID))
+	n += 1 + sovErrors(uint64(m.Pending))
+	return n
+}
+
 func (m *ErrorDetail) Size() (n int) {
 	var l int
 	_ = l
@@ -1299,6 +1369,10 @@ func (m *ErrorDetail) Size() (n int) {
 		l = m.Send.Size()
 		n += 1 + l + sovErrors(uint64(l))
 	}
+	if m.RangeOverloaded != nil {
+		l = m.RangeOverloaded.Size()
+		n += 2 + l + sovErrors(uint64(l))
+	}
 	return n
 }
 
@@ -1382,6 +1456,9 @@ func (this *ErrorDetail) GetValue() interface{} {
 	if this.Send != nil {
 		return this.Send
 	}
+	if this.RangeOverloaded != nil {
+		return this.RangeOverloaded
+	}
 	return nil
 }
 
@@ -1417,6 +1494,8 @@ func (this *ErrorDetail) SetValue(value interface{}) bool {
 		this.NodeUnavailable = vt
 	case *SendError:
 		this.Send = vt
+	case *RangeOverloadedError:
+		this.RangeOverloaded = vt
 	default:
 		return false
 	}
@@ -2745,6 +2824,34 @@ func (m *ConditionFailedError) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrors
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthErrors
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrors(data[iNdEx:])
@@ -3004,6 +3111,94 @@ func (m *SendError) Unmarshal(data []byte) error {
 	}
 	return nil
 }
+func (m *RangeOverloadedError) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrors
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RangeOverloadedError: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RangeOverloadedError: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RangeID", wireType)
+			}
+			m.RangeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrors
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.RangeID |= (RangeID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pending", wireType)
+			}
+			m.Pending = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrors
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Pending |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrors(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrors
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ErrorDetail) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
@@ -3528,6 +3723,39 @@ func (m *ErrorDetail) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RangeOverloaded", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrors
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthErrors
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RangeOverloaded == nil {
+				m.RangeOverloaded = &RangeOverloadedError{}
+			}
+			if err := m.RangeOverloaded.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrors(data[iNdEx:])
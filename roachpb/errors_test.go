@@ -0,0 +1,66 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package roachpb
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/util/retry"
+)
+
+// TestErrorDetailRoundTrip verifies that typed errors keep their type,
+// contents and retryability when they are encoded into an Error and sent
+// over the wire.
+func TestErrorDetailRoundTrip(t *testing.T) {
+	desc := &RangeDescriptor{RangeID: 1, StartKey: RKey("a"), EndKey: RKey("b")}
+	testCases := []struct {
+		err       error
+		retryable bool
+	}{
+		{&ConditionFailedError{ActualValue: &Value{RawBytes: []byte("v")}}, false},
+		{NewRangeKeyMismatchError(Key("c"), Key("d"), desc), true},
+		{&NotLeaderError{RangeID: 1, Replica: &ReplicaDescriptor{NodeID: 1, StoreID: 1}}, false},
+		{NewRangeNotFoundError(2), true},
+		{&OpRequiresTxnError{}, false},
+	}
+	for i, c := range testCases {
+		data, err := NewError(c.err).Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pErr Error
+		if err := pErr.Unmarshal(data); err != nil {
+			t.Fatal(err)
+		}
+		goErr := pErr.GoError()
+		if !reflect.DeepEqual(goErr, c.err) {
+			t.Errorf("%d: expected %#v; got %#v", i, c.err, goErr)
+		}
+		if r, ok := goErr.(retry.Retryable); (ok && r.CanRetry()) != c.retryable {
+			t.Errorf("%d: expected %T retryable=%t", i, goErr, c.retryable)
+		}
+		if pErr.Retryable != c.retryable {
+			t.Errorf("%d: expected encoded retryable=%t; got %t", i, c.retryable, pErr.Retryable)
+		}
+	}
+
+	// Untyped errors are passed on as generic errors.
+	goErr := NewError(errors.New("boom")).GoError()
+	if _, ok := goErr.(*internalError); !ok || goErr.Error() != "boom" {
+		t.Errorf("expected generic error \"boom\"; got %#v", goErr)
+	}
+}
@@ -130,6 +130,9 @@ type RaftSnapshotData struct {
 	// The latest RangeDescriptor
 	RangeDescriptor RangeDescriptor              `protobuf:"bytes,1,opt,name=range_descriptor" json:"range_descriptor"`
 	KV              []*RaftSnapshotData_KeyValue `protobuf:"bytes,2,rep,name=KV" json:"KV,omitempty"`
+	// checksum is the SHA256 of the range's key/value tuples, which is verified
+	// before the snapshot is applied.
+	Checksum []byte `protobuf:"bytes,3,opt,name=checksum" json:"checksum,omitempty"`
 }
 
 func (m *RaftSnapshotData) Reset()         { *m = RaftSnapshotData{} }
@@ -345,6 +348,12 @@ func (m *RaftSnapshotData) MarshalTo(data []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.Checksum != nil {
+		data[i] = 0x1a
+		i++
+		i = encodeVarintInternal(data, i, uint64(len(m.Checksum)))
+		i += copy(data[i:], m.Checksum)
+	}
 	return i, nil
 }
 
@@ -479,6 +488,10 @@ func (m *RaftSnapshotData) Size() (n int) {
 			n += 1 + l + sovInternal(uint64(l))
 		}
 	}
+	if m.Checksum != nil {
+		l = len(m.Checksum)
+		n += 1 + l + sovInternal(uint64(l))
+	}
 	return n
 }
 
@@ -1150,6 +1163,34 @@ func (m *RaftSnapshotData) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthInternal
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Checksum = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipInternal(data[iNdEx:])
@@ -18,6 +18,9 @@
 package roachpb
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -65,6 +68,12 @@ func (r ReplicaID) String() string {
 	return strconv.FormatInt(int64(r), 10)
 }
 
+// String implements the fmt.Stringer interface. The replica is rendered
+// as n<NodeID>/s<StoreID>/r<ReplicaID>.
+func (r ReplicaDescriptor) String() string {
+	return fmt.Sprintf("n%d/s%d/r%d", r.NodeID, r.StoreID, r.ReplicaID)
+}
+
 // IsSubset returns whether attributes list a is a subset of
 // attributes list b.
 func (a Attributes) IsSubset(b Attributes) bool {
@@ -99,9 +108,56 @@ func (a Attributes) String() string {
 // SortedString returns a sorted, de-duplicated, comma-separated list
 // of the attributes.
 func (a Attributes) SortedString() string {
+	return strings.Join(a.sortedAttrs(), ",")
+}
+
+func (a Attributes) sortedAttrs() []string {
 	attrs := a.uniqueAttrs()
 	sort.Strings(attrs)
-	return strings.Join(attrs, ",")
+	return attrs
+}
+
+// jsonAttributes is the JSON form of Attributes. It is a distinct type so
+// that encoding it does not recurse into Attributes.MarshalJSON.
+type jsonAttributes struct {
+	Attrs []string `json:"attrs,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The attributes are encoded in
+// their sorted, de-duplicated form so that equivalent attribute lists
+// always produce the same JSON.
+func (a Attributes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonAttributes{Attrs: a.sortedAttrs()})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts either the object
+// produced by MarshalJSON or a bare array of attributes, and normalizes
+// the result in the same way.
+func (a *Attributes) UnmarshalJSON(data []byte) error {
+	var ja jsonAttributes
+	if err := json.Unmarshal(data, &ja.Attrs); err != nil {
+		if err := json.Unmarshal(data, &ja); err != nil {
+			return err
+		}
+	}
+	a.Attrs = Attributes{Attrs: ja.Attrs}.sortedAttrs()
+	return nil
+}
+
+// String implements the fmt.Stringer interface. The range is rendered as
+// its key span followed by the node and store of each replica, e.g.
+// "[/Table/50,/Table/51) replicas=[n1/s1, n2/s2]".
+func (r RangeDescriptor) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "[%s,%s) replicas=[", r.StartKey, r.EndKey)
+	for i, repl := range r.Replicas {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "n%d/s%d", repl.NodeID, repl.StoreID)
+	}
+	buf.WriteString("]")
+	return buf.String()
 }
 
 // ContainsKey returns whether this RangeDescriptor contains the specified key.
@@ -165,12 +221,60 @@ func (r ReplicaDescriptor) Validate() error {
 	return nil
 }
 
+// Equal returns whether the two replica descriptors refer to the same
+// replica, that is, whether their node, store and replica IDs match.
+func (r ReplicaDescriptor) Equal(o ReplicaDescriptor) bool {
+	return r.NodeID == o.NodeID && r.StoreID == o.StoreID && r.ReplicaID == o.ReplicaID
+}
+
+// ReplicaSetDiff returns the replicas which must be added to and removed
+// from the current replica set to arrive at the desired one. Replicas
+// are compared using Equal; the order of either set is irrelevant.
+func ReplicaSetDiff(current, desired []ReplicaDescriptor) (toAdd, toRemove []ReplicaDescriptor) {
+	contains := func(set []ReplicaDescriptor, r ReplicaDescriptor) bool {
+		for _, o := range set {
+			if r.Equal(o) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, r := range desired {
+		if !contains(current, r) {
+			toAdd = append(toAdd, r)
+		}
+	}
+	for _, r := range current {
+		if !contains(desired, r) {
+			toRemove = append(toRemove, r)
+		}
+	}
+	return toAdd, toRemove
+}
+
+// Used returns the number of bytes of storage capacity that are in use.
+func (sc StoreCapacity) Used() int64 {
+	return sc.Capacity - sc.Available
+}
+
 // FractionUsed computes the fraction of storage capacity that is in use.
+// A store which reports no capacity is considered full.
 func (sc StoreCapacity) FractionUsed() float64 {
-	if sc.Capacity == 0 {
-		return 0
+	if sc.Capacity <= 0 {
+		return 1
+	}
+	return float64(sc.Used()) / float64(sc.Capacity)
+}
+
+// String implements the fmt.Stringer interface. The store is rendered with
+// the percentage of its capacity which is available and its combined node
+// and store attributes, e.g. "s1 avail=40% attrs=dc1,ssd".
+func (s StoreDescriptor) String() string {
+	var avail int64
+	if s.Capacity.Capacity > 0 {
+		avail = s.Capacity.Available * 100 / s.Capacity.Capacity
 	}
-	return float64(sc.Capacity-sc.Available) / float64(sc.Capacity)
+	return fmt.Sprintf("s%d avail=%d%% attrs=%s", s.StoreID, avail, s.CombinedAttrs())
 }
 
 // CombinedAttrs returns the full list of attributes for the store, including
@@ -39,9 +39,8 @@ type ReplicaDescriptor struct {
 	ReplicaID ReplicaID `protobuf:"varint,3,opt,name=replica_id,casttype=ReplicaID" json:"replica_id"`
 }
 
-func (m *ReplicaDescriptor) Reset()         { *m = ReplicaDescriptor{} }
-func (m *ReplicaDescriptor) String() string { return proto.CompactTextString(m) }
-func (*ReplicaDescriptor) ProtoMessage()    {}
+func (m *ReplicaDescriptor) Reset()      { *m = ReplicaDescriptor{} }
+func (*ReplicaDescriptor) ProtoMessage() {}
 
 // RangeDescriptor is the value stored in a range metadata key.
 // A range is described using an inclusive start key, a non-inclusive end key,
@@ -60,11 +59,14 @@ type RangeDescriptor struct {
 	Replicas []ReplicaDescriptor `protobuf:"bytes,4,rep,name=replicas" json:"replicas"`
 	// next_replica_id is a counter used to generate replica IDs.
 	NextReplicaID ReplicaID `protobuf:"varint,5,opt,name=next_replica_id,casttype=ReplicaID" json:"next_replica_id"`
+	// generation is incremented whenever the range's bounds or replica set
+	// change, allowing cached copies of the descriptor to be recognized as
+	// stale.
+	Generation int64 `protobuf:"varint,6,opt,name=generation" json:"generation"`
 }
 
-func (m *RangeDescriptor) Reset()         { *m = RangeDescriptor{} }
-func (m *RangeDescriptor) String() string { return proto.CompactTextString(m) }
-func (*RangeDescriptor) ProtoMessage()    {}
+func (m *RangeDescriptor) Reset()      { *m = RangeDescriptor{} }
+func (*RangeDescriptor) ProtoMessage() {}
 
 // RangeTree holds the root node of the range tree.
 type RangeTree struct {
@@ -121,9 +123,8 @@ type StoreDescriptor struct {
 	Capacity StoreCapacity  `protobuf:"bytes,4,opt,name=capacity" json:"capacity"`
 }
 
-func (m *StoreDescriptor) Reset()         { *m = StoreDescriptor{} }
-func (m *StoreDescriptor) String() string { return proto.CompactTextString(m) }
-func (*StoreDescriptor) ProtoMessage()    {}
+func (m *StoreDescriptor) Reset()      { *m = StoreDescriptor{} }
+func (*StoreDescriptor) ProtoMessage() {}
 
 func init() {
 	proto.RegisterType((*Attributes)(nil), "cockroach.roachpb.Attributes")
@@ -240,6 +241,9 @@ func (m *RangeDescriptor) MarshalTo(data []byte) (int, error) {
 	data[i] = 0x28
 	i++
 	i = encodeVarintMetadata(data, i, uint64(m.NextReplicaID))
+	data[i] = 0x30
+	i++
+	i = encodeVarintMetadata(data, i, uint64(m.Generation))
 	return i, nil
 }
 
@@ -493,6 +497,7 @@ func (m *RangeDescriptor) Size() (n int) {
 		}
 	}
 	n += 1 + sovMetadata(uint64(m.NextReplicaID))
+	n += 1 + sovMetadata(uint64(m.Generation))
 	return n
 }
 
@@ -915,6 +920,25 @@ func (m *RangeDescriptor) Unmarshal(data []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Generation", wireType)
+			}
+			m.Generation = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetadata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Generation |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetadata(data[iNdEx:])
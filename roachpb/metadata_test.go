@@ -17,6 +17,9 @@
 package roachpb
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -60,6 +63,60 @@ func TestAttributesSortedString(t *testing.T) {
 	}
 }
 
+func TestAttributesJSON(t *testing.T) {
+	testCases := []struct {
+		attrs   Attributes
+		expJSON string
+	}{
+		{Attributes{}, `{}`},
+		{Attributes{Attrs: []string{}}, `{}`},
+		{Attributes{Attrs: []string{"ssd"}}, `{"attrs":["ssd"]}`},
+		{Attributes{Attrs: []string{"ssd", "dc1", "ssd", "dc1", "ssd"}}, `{"attrs":["dc1","ssd"]}`},
+		{Attributes{Attrs: []string{"us-east", "hdd", "a"}}, `{"attrs":["a","hdd","us-east"]}`},
+	}
+	for i, test := range testCases {
+		data, err := json.Marshal(test.attrs)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if string(data) != test.expJSON {
+			t.Errorf("%d: expected %s; got %s", i, test.expJSON, data)
+		}
+		var attrs Attributes
+		if err := json.Unmarshal(data, &attrs); err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if attrs.SortedString() != test.attrs.SortedString() {
+			t.Errorf("%d: expected %s after round trip; got %s", i, test.attrs.SortedString(), attrs.SortedString())
+		}
+	}
+}
+
+func TestAttributesUnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		data     string
+		expAttrs []string
+	}{
+		{`{}`, nil},
+		{`[]`, nil},
+		{`{"attrs":["b","a","b"]}`, []string{"a", "b"}},
+		{`["ssd","dc1","ssd"]`, []string{"dc1", "ssd"}},
+	}
+	for i, test := range testCases {
+		var attrs Attributes
+		if err := json.Unmarshal([]byte(test.data), &attrs); err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if !reflect.DeepEqual(attrs.Attrs, test.expAttrs) {
+			t.Errorf("%d: expected %q; got %q", i, test.expAttrs, attrs.Attrs)
+		}
+	}
+	var attrs Attributes
+	if err := json.Unmarshal([]byte(`"ssd"`), &attrs); err == nil {
+		t.Error("expected error unmarshaling a string")
+	}
+}
+
 func TestRangeDescriptorFindReplica(t *testing.T) {
 	desc := RangeDescriptor{
 		Replicas: []ReplicaDescriptor{
@@ -82,3 +139,116 @@ func TestRangeDescriptorMissingReplica(t *testing.T) {
 		t.Fatalf("unexpected return (%d, %s) on missing replica", i, r)
 	}
 }
+
+func TestReplicaDescriptorEqual(t *testing.T) {
+	r := ReplicaDescriptor{NodeID: 1, StoreID: 2, ReplicaID: 3}
+	testCases := []struct {
+		o        ReplicaDescriptor
+		expEqual bool
+	}{
+		{ReplicaDescriptor{NodeID: 1, StoreID: 2, ReplicaID: 3}, true},
+		{ReplicaDescriptor{NodeID: 4, StoreID: 2, ReplicaID: 3}, false},
+		{ReplicaDescriptor{NodeID: 1, StoreID: 4, ReplicaID: 3}, false},
+		{ReplicaDescriptor{NodeID: 1, StoreID: 2, ReplicaID: 4}, false},
+	}
+	for i, c := range testCases {
+		if eq := r.Equal(c.o); eq != c.expEqual {
+			t.Errorf("%d: expected %s.Equal(%s) to be %t", i, r, c.o, c.expEqual)
+		}
+	}
+}
+
+func TestReplicaSetDiff(t *testing.T) {
+	r1 := ReplicaDescriptor{NodeID: 1, StoreID: 1, ReplicaID: 1}
+	r2 := ReplicaDescriptor{NodeID: 2, StoreID: 2, ReplicaID: 2}
+	r3 := ReplicaDescriptor{NodeID: 3, StoreID: 3, ReplicaID: 3}
+	r4 := ReplicaDescriptor{NodeID: 4, StoreID: 4, ReplicaID: 4}
+	set := func(rs ...ReplicaDescriptor) []ReplicaDescriptor { return rs }
+
+	testCases := []struct {
+		current, desired  []ReplicaDescriptor
+		expAdd, expRemove []ReplicaDescriptor
+	}{
+		// Identical sets, in differing order.
+		{set(r1, r2, r3), set(r3, r1, r2), nil, nil},
+		// Pure additions.
+		{set(r1), set(r1, r2, r3), set(r2, r3), nil},
+		// Pure removals.
+		{set(r1, r2, r3), set(r2), nil, set(r1, r3)},
+		// Swaps.
+		{set(r1, r2, r3), set(r1, r2, r4), set(r4), set(r3)},
+		{set(r1, r2), set(r3, r4), set(r3, r4), set(r1, r2)},
+	}
+	for i, c := range testCases {
+		toAdd, toRemove := ReplicaSetDiff(c.current, c.desired)
+		if !reflect.DeepEqual(toAdd, c.expAdd) {
+			t.Errorf("%d: expected to add %v; got %v", i, c.expAdd, toAdd)
+		}
+		if !reflect.DeepEqual(toRemove, c.expRemove) {
+			t.Errorf("%d: expected to remove %v; got %v", i, c.expRemove, toRemove)
+		}
+	}
+}
+
+func TestStoreCapacityUsed(t *testing.T) {
+	testCases := []struct {
+		capacity    StoreCapacity
+		expUsed     int64
+		expFraction float64
+	}{
+		{StoreCapacity{Capacity: 100, Available: 100}, 0, 0},
+		{StoreCapacity{Capacity: 100, Available: 75}, 25, 0.25},
+		{StoreCapacity{Capacity: 100, Available: 0}, 100, 1},
+		// A store which reports no capacity is full, rather than causing a
+		// division by zero.
+		{StoreCapacity{}, 0, 1},
+	}
+	for i, c := range testCases {
+		if used := c.capacity.Used(); used != c.expUsed {
+			t.Errorf("%d: expected %d bytes used; got %d", i, c.expUsed, used)
+		}
+		if fraction := c.capacity.FractionUsed(); fraction != c.expFraction {
+			t.Errorf("%d: expected fraction used %f; got %f", i, c.expFraction, fraction)
+		}
+	}
+}
+
+func TestDescriptorStrings(t *testing.T) {
+	repl := ReplicaDescriptor{NodeID: 1, StoreID: 2, ReplicaID: 3}
+	desc := RangeDescriptor{
+		RangeID:  5,
+		StartKey: RKey("a"),
+		EndKey:   RKey("c"),
+		Replicas: []ReplicaDescriptor{
+			{NodeID: 1, StoreID: 1, ReplicaID: 1},
+			{NodeID: 2, StoreID: 3, ReplicaID: 2},
+		},
+	}
+	store := StoreDescriptor{
+		StoreID:  4,
+		Attrs:    Attributes{Attrs: []string{"ssd"}},
+		Node:     NodeDescriptor{NodeID: 2, Attrs: Attributes{Attrs: []string{"dc1"}}},
+		Capacity: StoreCapacity{Capacity: 200, Available: 50},
+	}
+
+	testCases := []struct {
+		s        fmt.Stringer
+		expected string
+	}{
+		{repl, "n1/s2/r3"},
+		{&repl, "n1/s2/r3"},
+		{desc, `["a","c") replicas=[n1/s1, n2/s3]`},
+		{RangeDescriptor{StartKey: RKeyMin, EndKey: RKeyMax}, `["","\xff\xff") replicas=[]`},
+		{store, "s4 avail=25% attrs=dc1,ssd"},
+		{StoreDescriptor{StoreID: 1}, "s1 avail=0% attrs="},
+	}
+	for i, test := range testCases {
+		if s := test.s.String(); s != test.expected {
+			t.Errorf("%d: expected %q; got %q", i, test.expected, s)
+		}
+	}
+	// The custom formats are also used by the fmt verbs.
+	if s := fmt.Sprintf("%s", &desc); s != desc.String() {
+		t.Errorf("expected %q; got %q", desc.String(), s)
+	}
+}
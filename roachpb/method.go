@@ -102,6 +102,37 @@ const (
 	TruncateLog
 	// LeaderLease requests a leader lease for a replica.
 	LeaderLease
+	// GetMulti fetches the values for a set of keys which all fall
+	// within the span given by args.RequestHeader.Key and
+	// args.RequestHeader.EndKey.
+	GetMulti
+	// WriteBatch puts a set of key/value pairs, all of which fall within
+	// the span given by args.RequestHeader.Key and args.RequestHeader.EndKey,
+	// with a single command per range. A batch spanning several ranges is
+	// applied in a transaction.
+	WriteBatch
+	// ScanVersions returns every version of the keys in the span given
+	// by args.RequestHeader.Key and args.RequestHeader.EndKey which was
+	// written within a range of timestamps.
+	ScanVersions
+	// ContainsRange reports whether any key exists in the span given by
+	// args.RequestHeader.Key and args.RequestHeader.EndKey.
+	ContainsRange
+	// Verify checks the integrity of the data held by the range
+	// addressed by args.RequestHeader.Key and args.RequestHeader.EndKey.
+	Verify
+	// RangeLookupMulti looks up the range descriptors addressed by
+	// several range metadata keys in a single command.
+	RangeLookupMulti
+	// ComputeChecksum computes a checksum of the replicated data of the
+	// range addressed by args.RequestHeader.Key, along with the raft
+	// applied index the checksum corresponds to.
+	ComputeChecksum
+	// ConditionalPutBatch sets the values of several keys, all of which
+	// fall within the span given by args.RequestHeader.Key and
+	// args.RequestHeader.EndKey, only if every expected value matches.
+	// A batch spanning several ranges is applied in a transaction.
+	ConditionalPutBatch
 	// Batch implements batch processing of commands. This is a
 	// superset of the Batch method.
 	Batch
@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/server/status"
 	"github.com/cockroachdb/cockroach/storage"
+	"github.com/cockroachdb/cockroach/storage/engine"
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/log"
 	"github.com/julienschmidt/httprouter"
@@ -54,6 +55,7 @@ const (
 		/_status/nodes/:node_id		     - a specific node's status
 		/_status/stores                  - all stores' status
 		/_status/stores/:store_id        - a specific store's status
+		/_status/ranges/:store_id        - the ranges of a store on this node
 	*/
 
 	// statusPrefix is the root of the cluster statistics and metrics API.
@@ -90,6 +92,10 @@ const (
 	// statusStorePattern exposes status for a single store.
 	statusStorePattern = statusPrefix + "stores/:store_id"
 
+	// statusRangesPattern exposes the replicas of a store on the local
+	// node, along with their stats and operation counters.
+	statusRangesPattern = statusPrefix + "ranges/:store_id"
+
 	// healthEndpoint is a shortcut for local details, intended for use by
 	// monitoring processes to verify that the server is up.
 	healthEndpoint = "/health"
@@ -102,13 +108,14 @@ var localRE = regexp.MustCompile(`(?i)local`)
 type statusServer struct {
 	db          *client.DB
 	gossip      *gossip.Gossip
+	stores      *storage.Stores
 	router      *httprouter.Router
 	ctx         *Context
 	proxyClient *http.Client
 }
 
 // newStatusServer allocates and returns a statusServer.
-func newStatusServer(db *client.DB, gossip *gossip.Gossip, ctx *Context) *statusServer {
+func newStatusServer(db *client.DB, gossip *gossip.Gossip, stores *storage.Stores, ctx *Context) *statusServer {
 	// Create an http client with a timeout
 	tlsConfig, err := ctx.GetClientTLSConfig()
 	if err != nil {
@@ -123,6 +130,7 @@ func newStatusServer(db *client.DB, gossip *gossip.Gossip, ctx *Context) *status
 	server := &statusServer{
 		db:          db,
 		gossip:      gossip,
+		stores:      stores,
 		router:      httprouter.New(),
 		ctx:         ctx,
 		proxyClient: httpClient,
@@ -138,6 +146,7 @@ func newStatusServer(db *client.DB, gossip *gossip.Gossip, ctx *Context) *status
 	server.router.GET(statusNodePattern, server.handleNodeStatus)
 	server.router.GET(statusStoresPrefix, server.handleStoresStatus)
 	server.router.GET(statusStorePattern, server.handleStoreStatus)
+	server.router.GET(statusRangesPattern, server.handleRanges)
 	server.router.GET(healthEndpoint, server.handleDetailsLocal)
 
 	return server
@@ -592,6 +601,50 @@ func (s *statusServer) handleStoreStatus(w http.ResponseWriter, r *http.Request,
 	respondAsJSON(w, r, storeStatus)
 }
 
+// rangeStatus describes a single replica in the response of handleRanges.
+type rangeStatus struct {
+	RangeID  roachpb.RangeID             `json:"rangeID"`
+	StartKey roachpb.RKey                `json:"startKey"`
+	EndKey   roachpb.RKey                `json:"endKey"`
+	Replicas []roachpb.ReplicaDescriptor `json:"replicas"`
+	Stats    engine.MVCCStats            `json:"stats"`
+	Metrics  storage.ReplicaMetrics      `json:"metrics"`
+	Lease    storage.LeaseStatus         `json:"lease"`
+}
+
+// handleRanges handles GET requests for the ranges of a store on the
+// local node.
+func (s *statusServer) handleRanges(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.ParseInt(ps.ByName("store_id"), 10, 32)
+	if err != nil {
+		http.Error(w,
+			fmt.Sprintf("store id could not be parsed: %s", err),
+			http.StatusBadRequest)
+		return
+	}
+	store, err := s.stores.GetStore(roachpb.StoreID(id))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	ranges := []rangeStatus{}
+	store.VisitReplicas(func(rng *storage.Replica) bool {
+		desc := rng.Desc()
+		ranges = append(ranges, rangeStatus{
+			RangeID:  desc.RangeID,
+			StartKey: desc.StartKey,
+			EndKey:   desc.EndKey,
+			Replicas: desc.Replicas,
+			Stats:    rng.GetMVCCStats(),
+			Metrics:  rng.Metrics(),
+			Lease:    rng.LeaseStatus(),
+		})
+		return true
+	})
+	respondAsJSON(w, r, ranges)
+}
+
 func respondAsJSON(w http.ResponseWriter, r *http.Request, response interface{}) {
 	b, contentType, err := util.MarshalResponse(r, response, []util.EncodingType{util.JSONEncoding})
 	if err != nil {
@@ -544,6 +544,63 @@ func TestStoreStatusResponse(t *testing.T) {
 	}
 }
 
+// TestRangesStatusResponse verifies that the ranges of each store are
+// reported along with their descriptors, stats and metrics.
+func TestRangesStatusResponse(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	ts := startServer(t)
+	defer ts.Stop()
+
+	if err := ts.db.AdminSplit("m"); err != nil {
+		t.Fatal(err)
+	}
+
+	var numRanges int
+	if err := ts.node.stores.VisitStores(func(store *storage.Store) error {
+		path := fmt.Sprintf("%s%s", strings.TrimSuffix(statusRangesPattern, ":store_id"), store.Ident.StoreID)
+		body := getRequest(t, ts, path)
+
+		// Verify the shape of the JSON response.
+		var raw struct {
+			Data []map[string]interface{} `json:"d"`
+		}
+		if err := json.Unmarshal(body, &raw); err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range raw.Data {
+			for _, field := range []string{"rangeID", "startKey", "endKey", "replicas", "stats", "metrics", "lease"} {
+				if _, ok := r[field]; !ok {
+					t.Errorf("store %d: range status %v lacks field %q", store.Ident.StoreID, r, field)
+				}
+			}
+		}
+
+		var wrapper struct {
+			Data []rangeStatus `json:"d"`
+		}
+		if err := json.Unmarshal(body, &wrapper); err != nil {
+			t.Fatal(err)
+		}
+		for _, rs := range wrapper.Data {
+			rng, err := store.GetReplica(rs.RangeID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if desc := rng.Desc(); !desc.StartKey.Equal(rs.StartKey) || !desc.EndKey.Equal(rs.EndKey) ||
+				!reflect.DeepEqual(desc.Replicas, rs.Replicas) {
+				t.Errorf("range status %+v doesn't match descriptor %+v", rs, desc)
+			}
+		}
+		numRanges += len(wrapper.Data)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if numRanges < 2 {
+		t.Errorf("expected at least 2 ranges after the split; got %d", numRanges)
+	}
+}
+
 // TestMetricsRecording verifies that Node statistics are periodically recorded
 // as time series data.
 func TestMetricsRecording(t *testing.T) {
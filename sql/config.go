@@ -16,7 +16,10 @@
 
 package sql
 
-import "github.com/cockroachdb/cockroach/config"
+import (
+	"github.com/cockroachdb/cockroach/config"
+	"github.com/cockroachdb/cockroach/keys"
+)
 
 func init() {
 	// TODO(marc): we use a hook to avoid a dependency on the sql package. We
@@ -24,7 +27,10 @@ func init() {
 	config.ZoneConfigHook = GetZoneConfig
 }
 
-// GetZoneConfig returns the zone config for the object with 'id'.
+// GetZoneConfig returns the zone config for the object with 'id'. Objects
+// without a zone config of their own (or of their database) fall back to
+// the zone config of the root namespace, which is the default zone config
+// set by operators. If there is none, config.DefaultZoneConfig is returned.
 func GetZoneConfig(cfg config.SystemConfig, id uint32) (*config.ZoneConfig, error) {
 	// Look in the zones table.
 	if zoneVal := cfg.GetValue(MakeZoneKey(ID(id))); zoneVal != nil {
@@ -52,5 +58,8 @@ func GetZoneConfig(cfg config.SystemConfig, id uint32) (*config.ZoneConfig, erro
 
 	// No descriptor or not a table. This table/db could have been deleted, just
 	// return the default config.
+	if id != keys.RootNamespaceID {
+		return GetZoneConfig(cfg, keys.RootNamespaceID)
+	}
 	return config.DefaultZoneConfig, nil
 }
@@ -214,4 +214,51 @@ func TestGetZoneConfig(t *testing.T) {
 			t.Errorf("#%d: bad zone config.\nexpected: %+v\ngot: %+v", tcNum, tc.zoneCfg, zoneCfg)
 		}
 	}
+
+	// Finally, set a zone config for the root namespace. It becomes the
+	// default for everything which doesn't have a more specific zone.
+	rootCfg := config.ZoneConfig{
+		ReplicaAttrs:  []roachpb.Attributes{{[]string{"root"}}},
+		RangeMinBytes: 1 << 21,
+		RangeMaxBytes: 1 << 27,
+	}
+	buf, err := proto.Marshal(&rootCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sqlDB.Exec(`INSERT INTO system.zones VALUES ($1, $2)`, keys.RootNamespaceID, buf); err != nil {
+		t.Fatalf("problem writing zone %+v: %s", rootCfg, err)
+	}
+
+	cfg, err = forceNewConfig(t, s)
+	if err != nil {
+		t.Fatalf("failed to get latest system config: %s", err)
+	}
+
+	testCases = []struct {
+		key     roachpb.RKey
+		zoneCfg config.ZoneConfig
+	}{
+		{roachpb.RKeyMin, rootCfg},
+		{keys.MakeTablePrefix(0), rootCfg},
+		{keys.MakeTablePrefix(1), rootCfg},
+		{keys.MakeTablePrefix(keys.MaxReservedDescID), rootCfg},
+		{keys.MakeTablePrefix(db1), db1Cfg},
+		{keys.MakeTablePrefix(db2), rootCfg},
+		{keys.MakeTablePrefix(tb11), tb11Cfg},
+		{keys.MakeTablePrefix(tb12), db1Cfg},
+		{keys.MakeTablePrefix(tb21), tb21Cfg},
+		{keys.MakeTablePrefix(tb22), rootCfg},
+	}
+
+	for tcNum, tc := range testCases {
+		zoneCfg, err := cfg.GetZoneConfigForKey(tc.key)
+		if err != nil {
+			t.Fatalf("#%d: err=%s", tcNum, err)
+		}
+
+		if !reflect.DeepEqual(*zoneCfg, tc.zoneCfg) {
+			t.Errorf("#%d: bad zone config.\nexpected: %+v\ngot: %+v", tcNum, tc.zoneCfg, zoneCfg)
+		}
+	}
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/security"
 	"github.com/cockroachdb/cockroach/sql/privilege"
 )
@@ -123,6 +124,33 @@ func NewDefaultPrivilegeDescriptor() *PrivilegeDescriptor {
 	}
 }
 
+// NewOwnerPrivilegeDescriptor returns a privilege descriptor for an object
+// created by 'owner', who is granted ALL privileges on it. The root user
+// holds ALL as well, as Validate requires, so the object keeps an
+// administrator whatever later happens to the owner's privileges.
+func NewOwnerPrivilegeDescriptor(owner string) *PrivilegeDescriptor {
+	p := NewDefaultPrivilegeDescriptor()
+	p.Grant(owner, privilege.List{privilege.ALL})
+	return p
+}
+
+// InheritedPrivilegeDescriptor returns the effective privileges on a table:
+// every user holds the union of the privileges granted on the table itself
+// and those granted on its parent database. Neither argument is modified;
+// either may be nil.
+func InheritedPrivilegeDescriptor(table, database *PrivilegeDescriptor) *PrivilegeDescriptor {
+	effective := &PrivilegeDescriptor{}
+	for _, p := range []*PrivilegeDescriptor{table, database} {
+		if p == nil {
+			continue
+		}
+		for _, u := range p.Users {
+			effective.Grant(u.User, privilege.ListFromBitField(u.Privileges))
+		}
+	}
+	return effective
+}
+
 // Grant adds new privileges to this descriptor for a given list of users.
 // TODO(marc): if all privileges other than ALL are set, should we collapse
 // them into ALL?
@@ -238,6 +266,69 @@ func (p *PrivilegeDescriptor) Show() ([]UserPrivilegeString, error) {
 	return ret, nil
 }
 
+// PrivilegeChange describes how the privileges held by a user differ
+// between two versions of a PrivilegeDescriptor.
+type PrivilegeChange struct {
+	User    string
+	Added   privilege.List
+	Removed privilege.List
+}
+
+// String implements the Stringer interface, e.g. "bob: +INSERT,SELECT -DROP".
+func (pc PrivilegeChange) String() string {
+	s := pc.User + ":"
+	if len(pc.Added) > 0 {
+		s += " +" + pc.Added.SortedString()
+	}
+	if len(pc.Removed) > 0 {
+		s += " -" + pc.Removed.SortedString()
+	}
+	return s
+}
+
+// Diff returns the changes which turn the privileges of 'p' into those of
+// 'other', one entry per user whose privileges differ, sorted by username.
+// A user present in only one of the descriptors has all of its privileges
+// added or removed. A nil descriptor holds no privileges. Privileges are
+// compared as stored, so replacing ALL by an explicit list shows up as ALL
+// being removed and the listed privileges being added.
+func (p *PrivilegeDescriptor) Diff(other *PrivilegeDescriptor) []PrivilegeChange {
+	var before, after []*UserPrivileges
+	if p != nil {
+		before = p.Users
+	}
+	if other != nil {
+		after = other.Users
+	}
+
+	var changes []PrivilegeChange
+	addChange := func(user string, from, to uint32) {
+		if from == to {
+			return
+		}
+		changes = append(changes, PrivilegeChange{
+			User:    user,
+			Added:   privilege.ListFromBitField(to &^ from),
+			Removed: privilege.ListFromBitField(from &^ to),
+		})
+	}
+	// Both user lists are sorted by username; merge them.
+	for len(before) > 0 || len(after) > 0 {
+		switch {
+		case len(after) == 0 || (len(before) > 0 && before[0].User < after[0].User):
+			addChange(before[0].User, before[0].Privileges, 0)
+			before = before[1:]
+		case len(before) == 0 || after[0].User < before[0].User:
+			addChange(after[0].User, 0, after[0].Privileges)
+			after = after[1:]
+		default:
+			addChange(before[0].User, before[0].Privileges, after[0].Privileges)
+			before, after = before[1:], after[1:]
+		}
+	}
+	return changes
+}
+
 // CheckPrivilege returns true if 'user' has 'privilege' on this descriptor.
 func (p *PrivilegeDescriptor) CheckPrivilege(user string, priv privilege.Kind) bool {
 	userPriv, ok := p.findUser(user)
@@ -250,3 +341,33 @@ func (p *PrivilegeDescriptor) CheckPrivilege(user string, priv privilege.Kind) b
 	}
 	return isPrivilegeSet(userPriv.Privileges, priv)
 }
+
+// MethodPrivilege returns the privilege required to execute a KV command
+// of the given method against the data governed by a descriptor. Reads
+// require SELECT, blind writes INSERT, read-modify-writes UPDATE and
+// deletions DELETE. Methods without a SQL counterpart require ALL.
+func MethodPrivilege(method roachpb.Method) privilege.Kind {
+	switch method {
+	case roachpb.Get, roachpb.GetMulti, roachpb.Scan, roachpb.ReverseScan,
+		roachpb.ScanVersions, roachpb.ContainsRange:
+		return privilege.SELECT
+	case roachpb.Put, roachpb.WriteBatch, roachpb.Merge:
+		return privilege.INSERT
+	case roachpb.ConditionalPut, roachpb.ConditionalPutBatch, roachpb.Increment:
+		return privilege.UPDATE
+	case roachpb.Delete, roachpb.DeleteRange:
+		return privilege.DELETE
+	default:
+		return privilege.ALL
+	}
+}
+
+// CheckMethodPrivilege returns an error unless 'user' has the privilege
+// required by 'method' on this descriptor.
+func (p *PrivilegeDescriptor) CheckMethodPrivilege(user string, method roachpb.Method) error {
+	priv := MethodPrivilege(method)
+	if p.CheckPrivilege(user, priv) {
+		return nil
+	}
+	return fmt.Errorf("user %s does not have %s privilege required by %s", user, priv, method)
+}
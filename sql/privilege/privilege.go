@@ -17,6 +17,7 @@
 package privilege
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 )
@@ -57,6 +58,25 @@ var ByValue = [...]Kind{
 	ALL, CREATE, DROP, GRANT, SELECT, INSERT, DELETE, UPDATE,
 }
 
+// validMask has the bits of all defined privileges set.
+var validMask = func() uint32 {
+	var m uint32
+	for _, k := range ByValue {
+		m |= k.Mask()
+	}
+	return m
+}()
+
+// IsValid returns true if k is one of the defined privileges.
+func (k Kind) IsValid() bool {
+	for _, v := range ByValue {
+		if k == v {
+			return true
+		}
+	}
+	return false
+}
+
 // List is a list of privileges.
 type List []Kind
 
@@ -109,7 +129,8 @@ func (pl List) ToBitField() uint32 {
 
 // ListFromBitField takes a bitfield of privileges and
 // returns a list. It is ordered in increasing
-// value of privilege.Kind.
+// value of privilege.Kind. Bits which do not correspond
+// to a valid privilege are ignored.
 func ListFromBitField(m uint32) List {
 	ret := List{}
 	for _, p := range ByValue {
@@ -119,3 +140,13 @@ func ListFromBitField(m uint32) List {
 	}
 	return ret
 }
+
+// ListFromBitFieldStrict is like ListFromBitField, but returns an error
+// if any bit does not correspond to a valid privilege, as happens when the
+// bitfield is corrupt.
+func ListFromBitFieldStrict(m uint32) (List, error) {
+	if invalid := m &^ validMask; invalid != 0 {
+		return nil, fmt.Errorf("invalid privilege bits %#x in bitfield %#x", invalid, m)
+	}
+	return ListFromBitField(m), nil
+}
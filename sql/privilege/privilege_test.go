@@ -62,3 +62,41 @@ func TestPrivilegeDecode(t *testing.T) {
 		}
 	}
 }
+
+func TestKindIsValid(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	for _, k := range privilege.ByValue {
+		if !k.IsValid() {
+			t.Errorf("expected %s to be valid", k)
+		}
+	}
+	for _, k := range []privilege.Kind{0, privilege.UPDATE + 1, 31} {
+		if k.IsValid() {
+			t.Errorf("expected %s to be invalid", k)
+		}
+	}
+}
+
+func TestPrivilegeDecodeStrict(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	valid := privilege.List{privilege.GRANT, privilege.DELETE}.ToBitField()
+	pl, err := privilege.ListFromBitFieldStrict(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pl.String() != "GRANT, DELETE" {
+		t.Errorf("wrong privilege list from raw: %s", pl)
+	}
+
+	// Bit 0 and bits beyond the last privilege are not privileges. The
+	// lenient decoding drops them; the strict one reports them.
+	for _, invalid := range []uint32{1, 1 << (privilege.UPDATE + 1), 1 << 31} {
+		raw := valid | invalid
+		if pl := privilege.ListFromBitField(raw); pl.String() != "GRANT, DELETE" {
+			t.Errorf("%#x: wrong privilege list from raw: %s", raw, pl)
+		}
+		if _, err := privilege.ListFromBitFieldStrict(raw); err == nil {
+			t.Errorf("%#x: expected an error", raw)
+		}
+	}
+}
@@ -17,9 +17,11 @@
 package sql_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/security"
 	"github.com/cockroachdb/cockroach/sql"
 	"github.com/cockroachdb/cockroach/sql/privilege"
@@ -91,6 +93,87 @@ func TestPrivilege(t *testing.T) {
 	}
 }
 
+func TestOwnerPrivilegeDescriptor(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	id := sql.ID(keys.MaxReservedDescID + 1)
+
+	descriptor := sql.NewOwnerPrivilegeDescriptor("foo")
+	expected := []sql.UserPrivilegeString{{"foo", "ALL"}, {security.RootUser, "ALL"}}
+	if show, err := descriptor.Show(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(show, expected) {
+		t.Fatalf("expected %v, got %v", expected, show)
+	}
+	if err := descriptor.Validate(id); err != nil {
+		t.Fatal(err)
+	}
+
+	// An object created by root has root as its only user.
+	if show, err := sql.NewOwnerPrivilegeDescriptor(security.RootUser).Show(); err != nil {
+		t.Fatal(err)
+	} else if exp := expected[1:]; !reflect.DeepEqual(show, exp) {
+		t.Fatalf("expected %v, got %v", exp, show)
+	}
+
+	// Stripping root of ALL would leave the object without an administrator
+	// and is rejected.
+	descriptor.Revoke(security.RootUser, privilege.List{privilege.ALL})
+	if err := descriptor.Validate(id); err == nil {
+		t.Fatal("expected validation to fail after revoking ALL from root")
+	}
+}
+
+func TestInheritedPrivilegeDescriptor(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	database := sql.NewDefaultPrivilegeDescriptor()
+	database.Grant("reader", privilege.List{privilege.SELECT})
+	database.Grant("both", privilege.List{privilege.SELECT})
+	table := sql.NewDefaultPrivilegeDescriptor()
+	table.Grant("writer", privilege.List{privilege.INSERT})
+	table.Grant("both", privilege.List{privilege.INSERT, privilege.DELETE})
+
+	effective := sql.InheritedPrivilegeDescriptor(table, database)
+	testCases := []struct {
+		user     string
+		priv     privilege.Kind
+		expected bool
+	}{
+		// Inherited from the database only.
+		{"reader", privilege.SELECT, true},
+		{"reader", privilege.INSERT, false},
+		// Granted on the table only.
+		{"writer", privilege.INSERT, true},
+		{"writer", privilege.SELECT, false},
+		// Combined from both.
+		{"both", privilege.SELECT, true},
+		{"both", privilege.INSERT, true},
+		{"both", privilege.DELETE, true},
+		{"both", privilege.DROP, false},
+		{security.RootUser, privilege.DROP, true},
+		{"nobody", privilege.SELECT, false},
+	}
+	for _, tc := range testCases {
+		if ok := effective.CheckPrivilege(tc.user, tc.priv); ok != tc.expected {
+			t.Errorf("%s/%s: expected %t, got %t", tc.user, tc.priv, tc.expected, ok)
+		}
+	}
+	if err := effective.Validate(sql.ID(keys.MaxReservedDescID + 1)); err != nil {
+		t.Error(err)
+	}
+
+	// The inputs are left untouched.
+	if table.CheckPrivilege("reader", privilege.SELECT) {
+		t.Error("table descriptor was modified")
+	}
+	if database.CheckPrivilege("writer", privilege.INSERT) {
+		t.Error("database descriptor was modified")
+	}
+	// A missing database contributes nothing.
+	if sql.InheritedPrivilegeDescriptor(table, nil).CheckPrivilege("reader", privilege.SELECT) {
+		t.Error("expected no privileges to be inherited from a nil database")
+	}
+}
+
 // TestPrivilegeValidate exercises validation for non-system descriptors.
 func TestPrivilegeValidate(t *testing.T) {
 	defer leaktest.AfterTest(t)
@@ -207,3 +290,129 @@ func TestSystemPrivilegeValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestMethodPrivilege(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	testCases := []struct {
+		method roachpb.Method
+		priv   privilege.Kind
+	}{
+		{roachpb.Get, privilege.SELECT},
+		{roachpb.GetMulti, privilege.SELECT},
+		{roachpb.Scan, privilege.SELECT},
+		{roachpb.ReverseScan, privilege.SELECT},
+		{roachpb.ScanVersions, privilege.SELECT},
+		{roachpb.ContainsRange, privilege.SELECT},
+		{roachpb.Put, privilege.INSERT},
+		{roachpb.WriteBatch, privilege.INSERT},
+		{roachpb.Merge, privilege.INSERT},
+		{roachpb.ConditionalPut, privilege.UPDATE},
+		{roachpb.ConditionalPutBatch, privilege.UPDATE},
+		{roachpb.Increment, privilege.UPDATE},
+		{roachpb.Delete, privilege.DELETE},
+		{roachpb.DeleteRange, privilege.DELETE},
+		{roachpb.AdminSplit, privilege.ALL},
+		{roachpb.EndTransaction, privilege.ALL},
+	}
+	for _, tc := range testCases {
+		if priv := sql.MethodPrivilege(tc.method); priv != tc.priv {
+			t.Errorf("%s: expected %s, got %s", tc.method, tc.priv, priv)
+		}
+	}
+}
+
+func TestCheckMethodPrivilege(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	descriptor := sql.NewDefaultPrivilegeDescriptor()
+	descriptor.Grant("foo", privilege.List{privilege.SELECT, privilege.DELETE})
+
+	testCases := []struct {
+		user   string
+		method roachpb.Method
+		ok     bool
+	}{
+		{security.RootUser, roachpb.Put, true},
+		{security.RootUser, roachpb.AdminSplit, true},
+		{"foo", roachpb.Scan, true},
+		{"foo", roachpb.DeleteRange, true},
+		{"foo", roachpb.Put, false},
+		{"foo", roachpb.Increment, false},
+		{"foo", roachpb.AdminSplit, false},
+		{"bar", roachpb.Get, false},
+	}
+	for _, tc := range testCases {
+		if err := descriptor.CheckMethodPrivilege(tc.user, tc.method); (err == nil) != tc.ok {
+			t.Errorf("%s %s: expected ok=%t, got %v", tc.user, tc.method, tc.ok, err)
+		}
+	}
+}
+
+func TestPrivilegeDiff(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	// Each case applies grant/revoke operations to a copy of the base
+	// descriptor, in which root holds ALL and bob holds SELECT and DROP.
+	type op struct {
+		user  string
+		grant bool
+		privs privilege.List
+	}
+	testCases := []struct {
+		ops      []op
+		expected []string
+	}{
+		// No change.
+		{nil, nil},
+		// Pure grant to an existing user.
+		{[]op{{"bob", true, privilege.List{privilege.INSERT, privilege.UPDATE}}},
+			[]string{"bob: +INSERT,UPDATE"}},
+		// Pure revoke from an existing user.
+		{[]op{{"bob", false, privilege.List{privilege.DROP}}},
+			[]string{"bob: -DROP"}},
+		// A user added, sorting before the existing ones.
+		{[]op{{"alice", true, privilege.List{privilege.SELECT}}},
+			[]string{"alice: +SELECT"}},
+		// A user removed.
+		{[]op{{"bob", false, privilege.List{privilege.SELECT, privilege.DROP}}},
+			[]string{"bob: -DROP,SELECT"}},
+		// Several users changing at once.
+		{[]op{
+			{"bob", true, privilege.List{privilege.INSERT}},
+			{"bob", false, privilege.List{privilege.DROP}},
+			{"carl", true, privilege.List{privilege.DELETE}},
+		}, []string{"bob: +INSERT -DROP", "carl: +DELETE"}},
+	}
+	for i, tc := range testCases {
+		before := sql.NewDefaultPrivilegeDescriptor()
+		before.Grant("bob", privilege.List{privilege.SELECT, privilege.DROP})
+		after := sql.NewDefaultPrivilegeDescriptor()
+		after.Grant("bob", privilege.List{privilege.SELECT, privilege.DROP})
+		for _, o := range tc.ops {
+			if o.grant {
+				after.Grant(o.user, o.privs)
+			} else {
+				after.Revoke(o.user, o.privs)
+			}
+		}
+		var changes []string
+		for _, c := range before.Diff(after) {
+			changes = append(changes, c.String())
+		}
+		if !reflect.DeepEqual(changes, tc.expected) {
+			t.Errorf("%d: expected %q, got %q", i, tc.expected, changes)
+		}
+		// The reverse diff swaps additions and removals.
+		for j, c := range after.Diff(before) {
+			if exp := before.Diff(after)[j]; c.User != exp.User ||
+				c.Added.SortedString() != exp.Removed.SortedString() ||
+				c.Removed.SortedString() != exp.Added.SortedString() {
+				t.Errorf("%d: reverse change %s does not mirror %s", i, c, exp)
+			}
+		}
+	}
+
+	// A nil descriptor holds no privileges.
+	desc := sql.NewDefaultPrivilegeDescriptor()
+	if changes := desc.Diff(nil); len(changes) != 1 || changes[0].String() != security.RootUser+": -ALL" {
+		t.Errorf("expected root to lose ALL, got %v", changes)
+	}
+}
@@ -21,6 +21,7 @@ package storage
 import (
 	"fmt"
 	"math/rand"
+	"sort"
 	"strconv"
 
 	"github.com/cockroachdb/cockroach/config"
@@ -227,6 +228,44 @@ func (a *Allocator) ComputeAction(zone config.ZoneConfig, desc *roachpb.RangeDes
 	return AllocatorNoop, 0
 }
 
+// ComputeReplicationChanges returns the replicas which must be added to or
+// removed from the supplied range so that its replica count matches the
+// number of replicas required by the zone configuration. The existing
+// replicas are assumed to satisfy the leading attribute requirements of
+// the zone, so new replicas are allocated for the remaining ones. At most
+// one of the returned slices is non-empty.
+func (a *Allocator) ComputeReplicationChanges(zone config.ZoneConfig, desc *roachpb.RangeDescriptor) (
+	add, remove []roachpb.ReplicaDescriptor, err error) {
+	need := len(zone.ReplicaAttrs)
+	existing := append([]roachpb.ReplicaDescriptor(nil), desc.Replicas...)
+	for i := len(existing); i < need; i++ {
+		target, err := a.AllocateTarget(zone.ReplicaAttrs[i], existing, true, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		newReplica := roachpb.ReplicaDescriptor{
+			NodeID:  target.Node.NodeID,
+			StoreID: target.StoreID,
+		}
+		add = append(add, newReplica)
+		existing = append(existing, newReplica)
+	}
+	for len(existing) > need {
+		removeReplica, err := a.RemoveTarget(existing)
+		if err != nil {
+			return nil, nil, err
+		}
+		remove = append(remove, removeReplica)
+		for i := range existing {
+			if existing[i] == removeReplica {
+				existing = append(existing[:i], existing[i+1:]...)
+				break
+			}
+		}
+	}
+	return add, remove, nil
+}
+
 // AllocateTarget returns a suitable store for a new allocation with the
 // required attributes. Nodes already accommodating existing replicas are ruled
 // out as targets. If relaxConstraints is true, then the required attributes
@@ -352,6 +391,81 @@ func (a Allocator) ShouldRebalance(storeID roachpb.StoreID) bool {
 	return a.balancer.improve(storeDesc, sl, makeNodeIDSet(storeDesc.Node.NodeID)) != nil
 }
 
+// RebalanceAction is a replica move recommended by SimulateRebalance: a
+// replica of the range is added to ToStoreID and the replica on
+// FromStoreID is subsequently removed.
+type RebalanceAction struct {
+	RangeID     roachpb.RangeID
+	FromStoreID roachpb.StoreID
+	ToStoreID   roachpb.StoreID
+}
+
+// SimulateRebalance returns the rebalancing moves the allocator would make
+// for the supplied ranges if the cluster consisted of the supplied stores,
+// without performing any of them. Each replica is considered in turn in the
+// same way as ShouldRebalance and RebalanceTarget do, and every recommended
+// move is applied to a private copy of the store descriptors, so that later
+// decisions take earlier ones into account. At most one move is
+// recommended per range, mirroring the replicate queue, which makes a single
+// replica change at a time.
+func (a Allocator) SimulateRebalance(ranges []roachpb.RangeDescriptor, stores []roachpb.StoreDescriptor) []RebalanceAction {
+	if !a.options.AllowRebalance {
+		return nil
+	}
+	simStores := make(map[roachpb.StoreID]*roachpb.StoreDescriptor, len(stores))
+	var storeIDs roachpb.StoreIDSlice
+	for i := range stores {
+		desc := stores[i]
+		simStores[desc.StoreID] = &desc
+		storeIDs = append(storeIDs, desc.StoreID)
+	}
+	sort.Sort(storeIDs)
+	storeList := func(required roachpb.Attributes) StoreList {
+		sl := StoreList{}
+		for _, storeID := range storeIDs {
+			if desc := simStores[storeID]; required.IsSubset(*desc.CombinedAttrs()) {
+				sl.add(desc)
+			}
+		}
+		return sl
+	}
+
+	var actions []RebalanceAction
+	for _, rng := range ranges {
+		existingNodes := make(nodeIDSet, len(rng.Replicas))
+		for _, repl := range rng.Replicas {
+			existingNodes[repl.NodeID] = struct{}{}
+		}
+		for _, repl := range rng.Replicas {
+			from := simStores[repl.StoreID]
+			if from == nil {
+				continue
+			}
+			to := a.balancer.improve(from, storeList(*from.CombinedAttrs()), existingNodes)
+			if to == nil {
+				continue
+			}
+			actions = append(actions, RebalanceAction{
+				RangeID:     rng.RangeID,
+				FromStoreID: from.StoreID,
+				ToStoreID:   to.StoreID,
+			})
+			// The size of the range isn't known, so assume it to be the
+			// average size of the ranges on the source store.
+			var rangeBytes int64
+			if from.Capacity.RangeCount > 0 {
+				rangeBytes = from.Capacity.Used() / int64(from.Capacity.RangeCount)
+			}
+			from.Capacity.RangeCount--
+			from.Capacity.Available += rangeBytes
+			to.Capacity.RangeCount++
+			to.Capacity.Available -= rangeBytes
+			break
+		}
+	}
+	return actions
+}
+
 // computeQuorum computes the quorum value for the given number of nodes.
 func computeQuorum(nodes int) int {
 	return (nodes / 2) + 1
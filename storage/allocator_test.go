@@ -400,6 +400,65 @@ func TestAllocatorRandomAllocation(t *testing.T) {
 	}
 }
 
+// TestAllocatorZeroCapacity verifies that a store which reports no
+// capacity is treated as full: it is never chosen as an allocation target
+// and is the first choice for removing a replica.
+func TestAllocatorZeroCapacity(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	stopper, g, _, a := createTestAllocator()
+	defer stopper.Stop()
+
+	stores := []*roachpb.StoreDescriptor{
+		{
+			StoreID:  1,
+			Node:     roachpb.NodeDescriptor{NodeID: 1},
+			Capacity: roachpb.StoreCapacity{RangeCount: 1},
+		},
+		{
+			StoreID:  2,
+			Node:     roachpb.NodeDescriptor{NodeID: 2},
+			Capacity: roachpb.StoreCapacity{Capacity: 100, Available: 50, RangeCount: 10},
+		},
+		{
+			StoreID:  3,
+			Node:     roachpb.NodeDescriptor{NodeID: 3},
+			Capacity: roachpb.StoreCapacity{Capacity: 100, Available: 50, RangeCount: 10},
+		},
+		{
+			StoreID:  4,
+			Node:     roachpb.NodeDescriptor{NodeID: 4},
+			Capacity: roachpb.StoreCapacity{Capacity: 100, Available: 90, RangeCount: 10},
+		},
+	}
+	gossiputil.NewStoreGossiper(g).GossipStores(stores, t)
+
+	for i := 0; i < 10; i++ {
+		result, err := a.AllocateTarget(roachpb.Attributes{}, []roachpb.ReplicaDescriptor{}, false, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.StoreID == 1 {
+			t.Errorf("%d: expected store without capacity not to be chosen", i)
+		}
+	}
+
+	var replicas []roachpb.ReplicaDescriptor
+	for _, s := range stores {
+		replicas = append(replicas, roachpb.ReplicaDescriptor{
+			NodeID:    s.Node.NodeID,
+			StoreID:   s.StoreID,
+			ReplicaID: roachpb.ReplicaID(s.StoreID),
+		})
+	}
+	targetRepl, err := a.RemoveTarget(replicas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if targetRepl.StoreID != 1 {
+		t.Errorf("expected replica on store 1 to be removed; got %+v", targetRepl)
+	}
+}
+
 // TestAllocatorRebalance verifies that rebalance targets are chosen
 // randomly from amongst stores over the minAvailCapacityThreshold.
 func TestAllocatorRebalance(t *testing.T) {
@@ -552,6 +611,63 @@ func TestAllocatorRebalanceByCount(t *testing.T) {
 	}
 }
 
+// TestAllocatorSimulateRebalance verifies that simulating a rebalance on
+// a topology with a single overloaded store recommends moving replicas off
+// that store, and only onto stores which don't hold a replica of the range.
+func TestAllocatorSimulateRebalance(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	stopper, _, _, a := createTestAllocator()
+	defer stopper.Stop()
+
+	var stores []roachpb.StoreDescriptor
+	for i := 1; i <= 5; i++ {
+		rangeCount := int32(5)
+		if i == 1 {
+			rangeCount = 30
+		}
+		stores = append(stores, roachpb.StoreDescriptor{
+			StoreID:  roachpb.StoreID(i),
+			Node:     roachpb.NodeDescriptor{NodeID: roachpb.NodeID(i)},
+			Capacity: roachpb.StoreCapacity{Capacity: 100, Available: 100, RangeCount: rangeCount},
+		})
+	}
+	var ranges []roachpb.RangeDescriptor
+	for i := 1; i <= 10; i++ {
+		desc := roachpb.RangeDescriptor{RangeID: roachpb.RangeID(i)}
+		for _, storeID := range []int{1, 2 + i%2, 4 + i%2} {
+			desc.Replicas = append(desc.Replicas, roachpb.ReplicaDescriptor{
+				NodeID:  roachpb.NodeID(storeID),
+				StoreID: roachpb.StoreID(storeID),
+			})
+		}
+		ranges = append(ranges, desc)
+	}
+
+	actions := a.SimulateRebalance(ranges, stores)
+	if len(actions) == 0 {
+		t.Fatal("expected replicas to be moved off the overloaded store")
+	}
+	for _, action := range actions {
+		if action.FromStoreID != 1 {
+			t.Errorf("expected move off store 1; got %+v", action)
+		}
+		for _, repl := range ranges[action.RangeID-1].Replicas {
+			if repl.StoreID == action.ToStoreID {
+				t.Errorf("move onto store which already holds a replica: %+v", action)
+			}
+		}
+	}
+	// The simulation must not modify the supplied descriptors.
+	if stores[0].Capacity.RangeCount != 30 {
+		t.Errorf("expected store 1 to be unmodified; got %+v", stores[0])
+	}
+
+	a.options.AllowRebalance = false
+	if actions := a.SimulateRebalance(ranges, stores); actions != nil {
+		t.Errorf("expected no moves with rebalancing disabled; got %+v", actions)
+	}
+}
+
 // TestAllocatorRemoveTarget verifies that the replica chosen by RemoveTarget is
 // the one with the lowest capacity.
 func TestAllocatorRemoveTarget(t *testing.T) {
@@ -652,6 +768,83 @@ func TestAllocatorRemoveTarget(t *testing.T) {
 	}
 }
 
+// TestAllocatorComputeReplicationChanges verifies that the replicas to add
+// or remove bring a range's replica count in line with its zone config.
+func TestAllocatorComputeReplicationChanges(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	stopper, g, _, a := createTestAllocator()
+	defer stopper.Stop()
+	gossiputil.NewStoreGossiper(g).GossipStores(sameDCStores, t)
+
+	// Under-replicated: a single replica on store 1 of the three required
+	// by multiDisksConfig; the missing replicas need hdd and mem stores.
+	desc := &roachpb.RangeDescriptor{
+		Replicas: []roachpb.ReplicaDescriptor{{NodeID: 1, StoreID: 1}},
+	}
+	add, remove, err := a.ComputeReplicationChanges(multiDisksConfig, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(add) != 2 || len(remove) != 0 {
+		t.Fatalf("expected 2 additions and no removals; got %v and %v", add, remove)
+	}
+	if add[0].StoreID != 3 && add[0].StoreID != 4 {
+		t.Errorf("expected addition on an hdd store; got %v", add[0])
+	}
+	if add[1].StoreID != 5 {
+		t.Errorf("expected addition on the mem store; got %v", add[1])
+	}
+
+	// Correctly replicated.
+	desc.Replicas = append(desc.Replicas, add...)
+	if add, remove, err = a.ComputeReplicationChanges(multiDisksConfig, desc); err != nil {
+		t.Fatal(err)
+	} else if len(add) != 0 || len(remove) != 0 {
+		t.Errorf("expected no changes; got %v and %v", add, remove)
+	}
+
+	// Over-replicated: four replicas of which simpleZoneConfig requires
+	// one. The most heavily loaded stores are removed first.
+	stores := []*roachpb.StoreDescriptor{
+		{
+			StoreID:  1,
+			Node:     roachpb.NodeDescriptor{NodeID: 1},
+			Capacity: roachpb.StoreCapacity{Capacity: 100, Available: 100, RangeCount: 5},
+		},
+		{
+			StoreID:  2,
+			Node:     roachpb.NodeDescriptor{NodeID: 2},
+			Capacity: roachpb.StoreCapacity{Capacity: 100, Available: 90, RangeCount: 10},
+		},
+		{
+			StoreID:  3,
+			Node:     roachpb.NodeDescriptor{NodeID: 3},
+			Capacity: roachpb.StoreCapacity{Capacity: 100, Available: 70, RangeCount: 20},
+		},
+		{
+			StoreID:  4,
+			Node:     roachpb.NodeDescriptor{NodeID: 4},
+			Capacity: roachpb.StoreCapacity{Capacity: 100, Available: 60, RangeCount: 30},
+		},
+	}
+	gossiputil.NewStoreGossiper(g).GossipStores(stores, t)
+	desc.Replicas = nil
+	for _, s := range stores {
+		desc.Replicas = append(desc.Replicas, roachpb.ReplicaDescriptor{NodeID: s.Node.NodeID, StoreID: s.StoreID})
+	}
+	if add, remove, err = a.ComputeReplicationChanges(simpleZoneConfig, desc); err != nil {
+		t.Fatal(err)
+	}
+	if len(add) != 0 || len(remove) != 3 {
+		t.Fatalf("expected 3 removals and no additions; got %v and %v", add, remove)
+	}
+	for i, storeID := range []roachpb.StoreID{4, 3, 2} {
+		if remove[i].StoreID != storeID {
+			t.Errorf("%d: expected removal of store %d; got %v", i, storeID, remove[i])
+		}
+	}
+}
+
 func TestAllocatorComputeAction(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	stopper, _, sp, a := createTestAllocator()
@@ -285,6 +285,60 @@ func TestStoreRangeMergeWithData(t *testing.T) {
 	}
 }
 
+// TestStoreRangeMergeRangeLookup verifies that a RangeLookup carrying a
+// descriptor which was merged away reports the merge.
+func TestStoreRangeMergeRangeLookup(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	store, stopper := createTestStore(t)
+	defer stopper.Stop()
+
+	_, bDesc, err := createSplitRanges(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookup := func(cached *roachpb.RangeDescriptor) *roachpb.RangeLookupResponse {
+		args := &roachpb.RangeLookupRequest{
+			Span: roachpb.Span{
+				Key: keys.RangeMetaKey(roachpb.RKey("c")),
+			},
+			MaxRanges:        1,
+			CachedDescriptor: cached,
+		}
+		resp, err := client.SendWrapped(rg1(store), nil, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.(*roachpb.RangeLookupResponse)
+	}
+
+	// The cached descriptor is still current.
+	if reply := lookup(bDesc); reply.Merged {
+		t.Fatalf("unexpected merge reported for current descriptor %+v", reply.Ranges)
+	}
+
+	// Merge the b range back into the a range.
+	args := adminMergeArgs(roachpb.KeyMin)
+	if _, err := client.SendWrapped(rg1(store), nil, &args); err != nil {
+		t.Fatal(err)
+	}
+	merged := store.LookupReplica([]byte("c"), nil).Desc()
+
+	if reply := lookup(nil); reply.Merged {
+		t.Fatal("unexpected merge reported without cached descriptor")
+	}
+	if reply := lookup(merged); reply.Merged {
+		t.Fatal("unexpected merge reported for merged descriptor")
+	}
+	reply := lookup(bDesc)
+	if !reply.Merged {
+		t.Fatal("expected merge to be reported for stale descriptor")
+	}
+	if len(reply.Ranges) != 1 || !reflect.DeepEqual(reply.Ranges[0], *merged) {
+		t.Fatalf("expected %+v, got %+v", *merged, reply.Ranges)
+	}
+}
+
 // TestStoreRangeMergeLastRange verifies that merging the last range
 // fails.
 func TestStoreRangeMergeLastRange(t *testing.T) {
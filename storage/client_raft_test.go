@@ -19,6 +19,7 @@ package storage_test
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -32,11 +33,13 @@ import (
 	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/storage"
 	"github.com/cockroachdb/cockroach/storage/engine"
+	"github.com/cockroachdb/cockroach/testutils"
 	"github.com/cockroachdb/cockroach/testutils/gossiputil"
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/hlc"
 	"github.com/cockroachdb/cockroach/util/leaktest"
 	"github.com/cockroachdb/cockroach/util/log"
+	"github.com/cockroachdb/cockroach/util/retry"
 	"github.com/cockroachdb/cockroach/util/stop"
 	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
@@ -592,6 +595,67 @@ func getRangeMetadata(key roachpb.RKey, mtc *multiTestContext, t *testing.T) roa
 	return reply.Ranges[0]
 }
 
+// TestReplicaRebalance verifies that Rebalance moves a replica onto a new
+// store, and that when the new replica can't catch up the rebalance is
+// rolled back, leaving the source replica in place.
+func TestReplicaRebalance(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	sc := storage.TestStoreContext
+	sc.RebalanceRetryOptions = retry.Options{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+		Multiplier:     2,
+		MaxRetries:     10,
+	}
+	mtc := &multiTestContext{storeContext: &sc}
+	mtc.Start(t, 5)
+	defer mtc.Stop()
+
+	rangeID := roachpb.RangeID(1)
+	mtc.replicateRange(rangeID, 0, 1, 2)
+	rng, err := mtc.stores[0].GetReplica(rangeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replica := func(i int) roachpb.ReplicaDescriptor {
+		return roachpb.ReplicaDescriptor{NodeID: mtc.idents[i].NodeID, StoreID: mtc.idents[i].StoreID}
+	}
+	checkStores := func(expected ...int) {
+		var expIDs, ids []int
+		for _, i := range expected {
+			expIDs = append(expIDs, int(mtc.idents[i].StoreID))
+		}
+		for _, rd := range rng.Desc().Replicas {
+			ids = append(ids, int(rd.StoreID))
+		}
+		sort.Ints(ids)
+		if !reflect.DeepEqual(ids, expIDs) {
+			t.Fatalf("expected replicas on stores %v; got %v", expIDs, ids)
+		}
+	}
+
+	// Move the replica from the third store to the fourth.
+	if err := rng.Rebalance(replica(2), replica(3)); err != nil {
+		t.Fatal(err)
+	}
+	checkStores(0, 1, 3)
+	util.SucceedsWithin(t, replicationTimeout, func() error {
+		if mtc.stores[3].LookupReplica(roachpb.RKey("a"), nil) == nil {
+			return util.Errorf("range not found on store %d", mtc.idents[3].StoreID)
+		}
+		return nil
+	})
+
+	// A replica added to a stopped store never catches up, so the rebalance
+	// fails and the source replica is kept.
+	mtc.stopStore(4)
+	if err := rng.Rebalance(replica(1), replica(4)); !testutils.IsError(err, "failed to catch up") {
+		t.Fatalf("expected catch-up failure; got %v", err)
+	}
+	checkStores(0, 1, 3)
+}
+
 // TestStoreRangeDownReplicate verifies that the replication queue will notice
 // over-replicated ranges and remove replicas from them.
 func TestStoreRangeDownReplicate(t *testing.T) {
@@ -1139,6 +1203,95 @@ func TestRaftRemoveRace(t *testing.T) {
 	}
 }
 
+// TestReplicaIDsMonotonic verifies that a replica which is removed from
+// a store and then re-added to it receives a new replica ID each time,
+// and that replica IDs are never reused within a range.
+func TestReplicaIDsMonotonic(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	mtc := startMultiTestContext(t, 3)
+	defer mtc.Stop()
+
+	rangeID := roachpb.RangeID(1)
+	mtc.replicateRange(rangeID, 0, 1, 2)
+	rng, err := mtc.stores[0].GetReplica(rangeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := map[roachpb.ReplicaID]struct{}{}
+	for _, rep := range rng.Desc().Replicas {
+		seen[rep.ReplicaID] = struct{}{}
+	}
+	var lastID roachpb.ReplicaID
+	for i := 0; i < 5; i++ {
+		mtc.unreplicateRange(rangeID, 0, 2)
+		mtc.replicateRange(rangeID, 0, 2)
+
+		desc := rng.Desc()
+		_, rep := desc.FindReplica(mtc.stores[2].StoreID())
+		if rep == nil {
+			t.Fatalf("%d: replica not found on store 2 in %+v", i, desc)
+		}
+		if _, ok := seen[rep.ReplicaID]; ok {
+			t.Fatalf("%d: replica ID %d was reused", i, rep.ReplicaID)
+		}
+		if rep.ReplicaID <= lastID {
+			t.Fatalf("%d: replica ID %d not greater than previous %d", i, rep.ReplicaID, lastID)
+		}
+		if rep.ReplicaID >= desc.NextReplicaID {
+			t.Fatalf("%d: replica ID %d not less than next replica ID %d", i, rep.ReplicaID, desc.NextReplicaID)
+		}
+		seen[rep.ReplicaID] = struct{}{}
+		lastID = rep.ReplicaID
+	}
+}
+
+// TestRangeDescriptorGeneration verifies that a range descriptor's
+// generation is incremented by splits, merges and replica changes, both
+// in memory and in the persisted descriptor.
+func TestRangeDescriptorGeneration(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	mtc := startMultiTestContext(t, 2)
+	defer mtc.Stop()
+	store := mtc.stores[0]
+
+	checkGeneration := func(key roachpb.RKey, expGen int64) {
+		rng := store.LookupReplica(key, nil)
+		if gen := rng.Desc().Generation; gen != expGen {
+			t.Fatalf("range %s: expected generation %d; got %d", rng, expGen, gen)
+		}
+		var desc roachpb.RangeDescriptor
+		descKey := keys.RangeDescriptorKey(rng.Desc().StartKey)
+		if ok, err := engine.MVCCGetProto(store.Engine(), descKey, store.Clock().Now(), true, nil, &desc); !ok || err != nil {
+			t.Fatalf("fetching range descriptor yielded %t, %v", ok, err)
+		}
+		if desc.Generation != expGen {
+			t.Fatalf("range %s: expected persisted generation %d; got %d", rng, expGen, desc.Generation)
+		}
+	}
+
+	gen := store.LookupReplica(roachpb.RKeyMin, nil).Desc().Generation
+
+	splitArgs := adminSplitArgs(roachpb.KeyMin, []byte("b"))
+	if _, err := client.SendWrapped(rg1(store), nil, &splitArgs); err != nil {
+		t.Fatal(err)
+	}
+	gen++
+	checkGeneration(roachpb.RKey("a"), gen)
+	checkGeneration(roachpb.RKey("c"), gen)
+
+	mergeArgs := adminMergeArgs(roachpb.KeyMin)
+	if _, err := client.SendWrapped(rg1(store), nil, &mergeArgs); err != nil {
+		t.Fatal(err)
+	}
+	gen++
+	checkGeneration(roachpb.RKey("a"), gen)
+
+	mtc.replicateRange(1, 0, 1)
+	gen++
+	checkGeneration(roachpb.RKey("a"), gen)
+}
+
 // TestStoreRangeRemoveDead verifies that if a store becomes dead, the
 // ReplicateQueue will notice and remove any replicas on it.
 func TestStoreRangeRemoveDead(t *testing.T) {
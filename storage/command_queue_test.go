@@ -209,3 +209,48 @@ func TestCommandQueueSelfOverlap(t *testing.T) {
 	cq.Remove([]interface{}{k})
 	wg.Wait()
 }
+
+// TestCommandQueueReadWaitsOnOverlappingWrite verifies that a read waits
+// on an executing write exactly when their spans overlap, whether the
+// overlap is partial or one span contains the other.
+func TestCommandQueueReadWaitsOnOverlappingWrite(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	testCases := []struct {
+		readStart, readEnd string
+		wait               bool
+	}{
+		// The write spans ["b", "d").
+		{"a", "c", true},  // overlaps the start of the write
+		{"c", "e", true},  // overlaps the end of the write
+		{"a", "e", true},  // contains the write
+		{"b", "d", true},  // equals the write
+		{"bb", "c", true}, // contained in the write
+		{"c", "", true},   // point read within the write
+		{"b", "", true},   // point read at the start of the write
+		{"a", "b", false}, // ends where the write starts
+		{"d", "e", false}, // starts where the write ends
+		{"d", "", false},  // point read at the end of the write
+	}
+	for i, test := range testCases {
+		cq := NewCommandQueue()
+		wk := add(cq, roachpb.Key("b"), roachpb.Key("d"), false)
+		var readEnd roachpb.Key
+		if test.readEnd != "" {
+			readEnd = roachpb.Key(test.readEnd)
+		}
+		wg := sync.WaitGroup{}
+		getWait(cq, roachpb.Key(test.readStart), readEnd, true, &wg)
+		cmdDone := waitForCmd(&wg)
+		if test.wait {
+			if testCmdDone(cmdDone, 1*time.Millisecond) {
+				t.Errorf("%d: read [%q,%q) should wait on the write", i, test.readStart, test.readEnd)
+			}
+		} else if !testCmdDone(cmdDone, 5*time.Millisecond) {
+			t.Errorf("%d: read [%q,%q) should not wait on the write", i, test.readStart, test.readEnd)
+		}
+		cq.Remove([]interface{}{wk})
+		if !testCmdDone(cmdDone, 5*time.Millisecond) {
+			t.Errorf("%d: read should proceed once the write is removed", i)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"sync"
 
 	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/util"
 	"github.com/gogo/protobuf/proto"
 )
 
@@ -203,6 +204,33 @@ func Scan(engine Engine, start, end MVCCKey, max int64) ([]MVCCKeyValue, error)
 	return kvs, err
 }
 
+// ScanBatches pages through the key/value pairs from start (inclusive) to
+// end (non-inclusive) in order, handing them to f in batches of at most
+// batchSize pairs. Only a single batch is held in memory at a time; the
+// slice passed to f is reused for the next batch and must not be retained.
+// The scan stops at the first error returned by f.
+func ScanBatches(engine Engine, start, end MVCCKey, batchSize int, f func([]MVCCKeyValue) error) error {
+	if batchSize <= 0 {
+		return util.Errorf("invalid scan batch size %d", batchSize)
+	}
+	batch := make([]MVCCKeyValue, 0, batchSize)
+	if err := engine.Iterate(start, end, func(kv MVCCKeyValue) (bool, error) {
+		batch = append(batch, kv)
+		if len(batch) < batchSize {
+			return false, nil
+		}
+		err := f(batch)
+		batch = batch[:0]
+		return err != nil, err
+	}); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return f(batch)
+	}
+	return nil
+}
+
 // ClearRange removes a set of entries, from start (inclusive) to end
 // (exclusive). This function returns the number of entries
 // removed. Either all entries within the range will be deleted, or
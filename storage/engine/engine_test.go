@@ -27,6 +27,7 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/leaktest"
 	"github.com/cockroachdb/cockroach/util/randutil"
 	"github.com/cockroachdb/cockroach/util/stop"
@@ -494,6 +495,57 @@ func TestEngineScan2(t *testing.T) {
 	}, t)
 }
 
+func TestEngineScanBatches(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	runWithAllEngines(func(engine Engine, t *testing.T) {
+		var keys []MVCCKey
+		for i := 0; i < 100; i++ {
+			keys = append(keys, mvccKey(fmt.Sprintf("key%03d", i)))
+		}
+		insertKeys(keys, engine, t)
+
+		for _, batchSize := range []int{1, 7, 50, 100, 1000} {
+			var scanned []MVCCKey
+			var batches int
+			if err := ScanBatches(engine, keys[10], keys[90], batchSize, func(kvs []MVCCKeyValue) error {
+				if len(kvs) == 0 || len(kvs) > batchSize {
+					t.Errorf("batch size %d: got batch of %d pairs", batchSize, len(kvs))
+				}
+				for _, kv := range kvs {
+					scanned = append(scanned, kv.Key)
+				}
+				batches++
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(scanned, keys[10:90]) {
+				t.Errorf("batch size %d: expected keys %v; got %v", batchSize, keys[10:90], scanned)
+			}
+			if exp := (80 + batchSize - 1) / batchSize; batches != exp {
+				t.Errorf("batch size %d: expected %d batches; got %d", batchSize, exp, batches)
+			}
+		}
+
+		// An error from the callback stops the scan.
+		var batches int
+		expErr := util.Errorf("stop")
+		if err := ScanBatches(engine, keys[0], mvccKey(roachpb.RKeyMax), 10, func([]MVCCKeyValue) error {
+			batches++
+			return expErr
+		}); err != expErr {
+			t.Errorf("expected %v; got %v", expErr, err)
+		}
+		if batches != 1 {
+			t.Errorf("expected scan to stop after one batch; got %d", batches)
+		}
+
+		if err := ScanBatches(engine, keys[0], keys[1], 0, func([]MVCCKeyValue) error { return nil }); err == nil {
+			t.Error("expected error for zero batch size")
+		}
+	}, t)
+}
+
 func TestEngineDeleteRange(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	runWithAllEngines(func(engine Engine, t *testing.T) {
@@ -61,7 +61,10 @@ func (gc *GarbageCollector) Filter(keys []MVCCKey, values [][]byte) roachpb.Time
 			log.Errorf("unexpected MVCC metadata encountered: %q", key)
 			return roachpb.ZeroTimestamp
 		}
-		deleted := len(values[i]) == 0
+		// Values whose TTL elapsed before the GC expiration can no longer be
+		// read by anyone and are collected like deletion tombstones.
+		deleted := len(values[i]) == 0 ||
+			roachpb.Value{RawBytes: values[i]}.IsExpired(gc.expiration)
 		if i == 0 {
 			// If the first value isn't a deletion tombstone, don't consider
 			// it for GC. It should always survive if non-deleted.
@@ -50,6 +50,8 @@ func TestGarbageCollectorFilter(t *testing.T) {
 	gcB := NewGarbageCollector(makeTS(0, 0), config.GCPolicy{TTLSeconds: 2})
 	n := []byte("data")
 	d := []byte(nil)
+	e := roachpb.MakeValueFromBytes(n)
+	e.SetExpiration(2.5E9)
 	testData := []struct {
 		gc       *GarbageCollector
 		time     roachpb.Timestamp
@@ -74,6 +76,8 @@ func TestGarbageCollectorFilter(t *testing.T) {
 		{gcA, makeTS(5E9, 0), aKeys, [][]byte{n, n, n}, makeTS(1E9, 1)},
 		{gcB, makeTS(5E9, 0), bKeys, [][]byte{n, n}, makeTS(1E9, 0)},
 		{gcB, makeTS(5E9, 0), bKeys, [][]byte{d, n}, makeTS(2E9, 0)},
+		{gcB, makeTS(4E9, 0), bKeys, [][]byte{e.RawBytes, n}, makeTS(1E9, 0)},
+		{gcB, makeTS(5E9, 0), bKeys, [][]byte{e.RawBytes, n}, makeTS(2E9, 0)},
 	}
 	for i, test := range testData {
 		test.gc.expiration = test.time
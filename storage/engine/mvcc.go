@@ -539,7 +539,7 @@ func MVCCGet(engine Engine, key roachpb.Key, timestamp roachpb.Timestamp, consis
 		return nil, nil, err
 	}
 
-	value, intents, err := mvccGetInternal(iter, metaKey, timestamp, consistent, txn, buf)
+	value, intents, err := mvccGetInternal(iter, metaKey, timestamp, consistent, txn, false /* !tombstones */, buf)
 	if value == &buf.value {
 		value = &roachpb.Value{}
 		*value = buf.value
@@ -588,9 +588,12 @@ func mvccGetMetadata(iter Iterator, metaKey MVCCKey,
 // most recent non-intent value instead. In the event that an inconsistent read
 // does encounter an intent (currently there can only be one), it is returned
 // via the roachpb.Intent slice, in addition to the result.
+//
+// If tombstones is true, a deleted or expired value is returned as a value
+// without data (see roachpb.Value.IsTombstone) instead of nil.
 func mvccGetInternal(iter Iterator, metaKey MVCCKey,
 	timestamp roachpb.Timestamp, consistent bool, txn *roachpb.Transaction,
-	buf *getBuffer) (*roachpb.Value, []roachpb.Intent, error) {
+	tombstones bool, buf *getBuffer) (*roachpb.Value, []roachpb.Intent, error) {
 	if !consistent && txn != nil {
 		return nil, nil, util.Errorf("cannot allow inconsistent reads within a transaction")
 	}
@@ -714,17 +717,30 @@ func mvccGetInternal(iter Iterator, metaKey MVCCKey,
 		// already been read above, so there's nothing left to do.
 	}
 
+	value := &buf.value
 	if len(iter.unsafeValue()) == 0 {
 		// Value is deleted.
+		if tombstones {
+			*value = roachpb.Value{Timestamp: unsafeKey.Timestamp}
+			return value, ignoredIntents, nil
+		}
 		return nil, ignoredIntents, nil
 	}
 
-	value := &buf.value
 	value.RawBytes = iter.Value()
 	value.Timestamp = unsafeKey.Timestamp
 	if err := value.Verify(metaKey.Key); err != nil {
 		return nil, nil, err
 	}
+	if value.IsExpired(timestamp) {
+		// The value's TTL has elapsed as of the read timestamp; it is
+		// indistinguishable from a deletion.
+		if tombstones {
+			value.RawBytes = nil
+			return value, ignoredIntents, nil
+		}
+		return nil, ignoredIntents, nil
+	}
 	return value, ignoredIntents, nil
 }
 
@@ -945,7 +961,8 @@ func MVCCIncrement(engine Engine, ms *MVCCStats, key roachpb.Key, timestamp roac
 
 // MVCCConditionalPut sets the value for a specified key only if the
 // expected value matches. If not, the return a ConditionFailedError
-// containing the actual value.
+// containing the actual value. A value without raw bytes is written as a
+// deletion tombstone, which allows a compare-and-delete.
 //
 // The condition check reads a value from the key using the same operational
 // timestamp as we use to write a value.
@@ -978,6 +995,26 @@ func MVCCConditionalPut(engine Engine, ms *MVCCStats, key roachpb.Key, timestamp
 	return MVCCPut(engine, ms, key, timestamp, value, txn)
 }
 
+// MVCCConditionalPutTimestamp is like MVCCConditionalPut, but the
+// condition is that the existing value was written at exactly expTS
+// rather than that it holds a given value. A missing or deleted key fails
+// the condition. On failure, the returned ConditionFailedError carries the
+// existing value, whose Timestamp is the one actually found.
+func MVCCConditionalPutTimestamp(engine Engine, ms *MVCCStats, key roachpb.Key, timestamp roachpb.Timestamp, value roachpb.Value,
+	expTS roachpb.Timestamp, txn *roachpb.Transaction) error {
+	existVal, _, err := MVCCGet(engine, key, timestamp, true /* consistent */, txn)
+	if err != nil {
+		return err
+	}
+	if existVal == nil || !existVal.Timestamp.Equal(expTS) {
+		return &roachpb.ConditionFailedError{
+			ActualValue: existVal,
+		}
+	}
+
+	return MVCCPut(engine, ms, key, timestamp, value, txn)
+}
+
 // MVCCMerge implements a merge operation. Merge adds integer values,
 // concatenates undifferentiated byte slice values, and efficiently
 // combines time series observations if the roachpb.Value tag value
@@ -1003,24 +1040,52 @@ func MVCCMerge(engine Engine, ms *MVCCStats, key roachpb.Key, value roachpb.Valu
 }
 
 // MVCCDeleteRange deletes the range of key/value pairs specified by
-// start and end keys. Specify max=0 for unbounded deletes.
-func MVCCDeleteRange(engine Engine, ms *MVCCStats, key, endKey roachpb.Key, max int64, timestamp roachpb.Timestamp, txn *roachpb.Transaction) (int64, error) {
+// start and end keys. Specify max=0 for unbounded deletes. Returns the
+// number of deleted keys and, if returnKeys is true, the deleted keys
+// themselves.
+func MVCCDeleteRange(engine Engine, ms *MVCCStats, key, endKey roachpb.Key, max int64, timestamp roachpb.Timestamp, txn *roachpb.Transaction, returnKeys bool) (int64, []roachpb.Key, error) {
+	return MVCCDeleteRangeIf(engine, ms, key, endKey, max, timestamp, txn, returnKeys, nil)
+}
+
+// MVCCDeleteRangeIf is like MVCCDeleteRange, but only deletes keys whose
+// current value satisfies match; the others are left in place and do not
+// count towards max. A nil match deletes every key.
+func MVCCDeleteRangeIf(engine Engine, ms *MVCCStats, key, endKey roachpb.Key, max int64, timestamp roachpb.Timestamp, txn *roachpb.Transaction, returnKeys bool, match func(roachpb.Value) bool) (int64, []roachpb.Key, error) {
+	scanMax := max
+	if match != nil {
+		// Non-matching keys don't count towards max, so we can't limit the
+		// scan.
+		scanMax = 0
+	}
 	// In order to detect the potential write intent by another
 	// concurrent transaction with a newer timestamp, we need
 	// to use the max timestamp for scan.
-	kvs, _, err := MVCCScan(engine, key, endKey, max, roachpb.MaxTimestamp, true /* consistent */, txn)
+	kvs, _, err := MVCCScan(engine, key, endKey, scanMax, roachpb.MaxTimestamp, true /* consistent */, txn)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
 	num := int64(0)
+	var keys []roachpb.Key
+	if returnKeys {
+		keys = make([]roachpb.Key, 0, len(kvs))
+	}
 	for _, kv := range kvs {
+		if max != 0 && num >= max {
+			break
+		}
+		if match != nil && !match(kv.Value) {
+			continue
+		}
 		if err := MVCCDelete(engine, ms, kv.Key, timestamp, txn); err != nil {
-			return num, err
+			return num, keys, err
+		}
+		if returnKeys {
+			keys = append(keys, kv.Key)
 		}
 		num++
 	}
-	return num, nil
+	return num, keys, nil
 }
 
 func getScanMeta(iter Iterator, encEndKey MVCCKey, meta *MVCCMetadata) (MVCCKey, error) {
@@ -1125,6 +1190,20 @@ func MVCCReverseScan(engine Engine, key, endKey roachpb.Key, max int64, timestam
 // reverse is flag set the iterator will be moved in reverse order.
 func MVCCIterate(engine Engine, startKey, endKey roachpb.Key, timestamp roachpb.Timestamp,
 	consistent bool, txn *roachpb.Transaction, reverse bool, f func(roachpb.KeyValue) (bool, error)) ([]roachpb.Intent, error) {
+	return mvccIterateInternal(engine, startKey, endKey, timestamp, consistent, txn, reverse, false /* !tombstones */, f)
+}
+
+// MVCCIterateWithTombstones is like MVCCIterate, but additionally invokes f
+// for keys whose most recent version as of the timestamp is a deletion
+// tombstone or has expired. For those keys, the value passed to f has no
+// data; see roachpb.Value.IsTombstone.
+func MVCCIterateWithTombstones(engine Engine, startKey, endKey roachpb.Key, timestamp roachpb.Timestamp,
+	consistent bool, txn *roachpb.Transaction, reverse bool, f func(roachpb.KeyValue) (bool, error)) ([]roachpb.Intent, error) {
+	return mvccIterateInternal(engine, startKey, endKey, timestamp, consistent, txn, reverse, true /* tombstones */, f)
+}
+
+func mvccIterateInternal(engine Engine, startKey, endKey roachpb.Key, timestamp roachpb.Timestamp,
+	consistent bool, txn *roachpb.Transaction, reverse, tombstones bool, f func(roachpb.KeyValue) (bool, error)) ([]roachpb.Intent, error) {
 	if !consistent && txn != nil {
 		return nil, util.Errorf("cannot allow inconsistent reads within a transaction")
 	}
@@ -1196,7 +1275,7 @@ func MVCCIterate(engine Engine, startKey, endKey roachpb.Key, timestamp roachpb.
 			break
 		}
 
-		value, newIntents, err := mvccGetInternal(iter, metaKey, timestamp, consistent, txn, buf)
+		value, newIntents, err := mvccGetInternal(iter, metaKey, timestamp, consistent, txn, tombstones, buf)
 		intents = append(intents, newIntents...)
 		if value != nil {
 			done, err := f(roachpb.KeyValue{Key: metaKey.Key, Value: *value})
@@ -1254,6 +1333,105 @@ func MVCCIterate(engine Engine, startKey, endKey roachpb.Key, timestamp roachpb.
 	return intents, wiErr
 }
 
+// MVCCScanVersions returns all versions of the keys in [key, endKey)
+// written at timestamps within [minTimestamp, maxTimestamp]. The result
+// is grouped by key in ascending order; the versions of each key are
+// ordered from oldest to newest. Deletions are returned as values
+// without data (see roachpb.Value.IsTombstone). Inline values carry no
+// history and are not returned.
+//
+// Uncommitted intents are never returned as versions. An intent may only
+// commit at its own timestamp or a later one, so intents above
+// maxTimestamp are ignored, as they are by MVCCGet. Any other intent may
+// yet commit into the window, even if its timestamp is below it: if
+// consistent is true, it results in a WriteIntentError; otherwise it is
+// skipped and returned via the roachpb.Intent slice.
+func MVCCScanVersions(engine Engine, key, endKey roachpb.Key, minTimestamp, maxTimestamp roachpb.Timestamp,
+	consistent bool) ([]roachpb.KeyValue, []roachpb.Intent, error) {
+	if len(endKey) == 0 {
+		return nil, nil, emptyKeyError()
+	}
+	if maxTimestamp.Less(minTimestamp) {
+		return nil, nil, util.Errorf("max timestamp %s is less than min timestamp %s", maxTimestamp, minTimestamp)
+	}
+
+	iter := engine.NewIterator(false)
+	defer iter.Close()
+
+	var rows []roachpb.KeyValue
+	var intents []roachpb.Intent
+	var wiErr *roachpb.WriteIntentError
+	// keyStart is the index into rows of the first version of the current
+	// key. Versions are stored newest first, so each key's versions are
+	// reversed once the iterator moves past it.
+	keyStart := 0
+	var meta MVCCMetadata
+	var curKey roachpb.Key
+	for iter.Seek(MakeMVCCMetadataKey(key)); iter.Valid(); iter.Next() {
+		unsafeKey := iter.unsafeKey()
+		if unsafeKey.Key.Compare(endKey) >= 0 {
+			break
+		}
+		if !unsafeKey.IsValue() {
+			reverseKeyValues(rows[keyStart:])
+			keyStart = len(rows)
+			curKey = iter.Key().Key
+			if err := iter.ValueProto(&meta); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		if !unsafeKey.Key.Equal(curKey) {
+			// A version without a metadata key; it can't be an intent.
+			reverseKeyValues(rows[keyStart:])
+			keyStart = len(rows)
+			curKey = iter.Key().Key
+			meta.Reset()
+		}
+		ts := unsafeKey.Timestamp
+		if meta.Txn != nil && ts.Equal(meta.Timestamp) {
+			if maxTimestamp.Less(ts) {
+				continue
+			}
+			intent := roachpb.Intent{Span: roachpb.Span{Key: curKey}, Txn: *meta.Txn}
+			if !consistent {
+				intents = append(intents, intent)
+			} else if wiErr == nil {
+				wiErr = &roachpb.WriteIntentError{Intents: []roachpb.Intent{intent}}
+			} else {
+				wiErr.Intents = append(wiErr.Intents, intent)
+			}
+			continue
+		}
+		if ts.Less(minTimestamp) || maxTimestamp.Less(ts) {
+			continue
+		}
+		value := roachpb.Value{Timestamp: ts}
+		if len(iter.unsafeValue()) > 0 {
+			value.RawBytes = iter.Value()
+			if err := value.Verify(curKey); err != nil {
+				return nil, nil, err
+			}
+		}
+		rows = append(rows, roachpb.KeyValue{Key: curKey, Value: value})
+	}
+	if err := iter.Error(); err != nil {
+		return nil, nil, err
+	}
+	reverseKeyValues(rows[keyStart:])
+	if wiErr != nil {
+		return nil, nil, wiErr
+	}
+	return rows, intents, nil
+}
+
+// reverseKeyValues reverses the order of kvs in place.
+func reverseKeyValues(kvs []roachpb.KeyValue) {
+	for i, j := 0, len(kvs)-1; i < j; i, j = i+1, j-1 {
+		kvs[i], kvs[j] = kvs[j], kvs[i]
+	}
+}
+
 // MVCCResolveWriteIntent either commits or aborts (rolls back) an
 // extant write intent for a given txn according to commit parameter.
 // ResolveWriteIntent will skip write intents of other txns.
@@ -1553,6 +1731,17 @@ func isValidEncodedSplitKey(key MVCCKey) bool {
 // The split key will never be chosen from the key ranges listed in
 // illegalSplitKeySpans.
 func MVCCFindSplitKey(engine Engine, rangeID roachpb.RangeID, key, endKey roachpb.RKey) (roachpb.Key, error) {
+	return MVCCFindWeightedSplitKey(engine, rangeID, key, endKey, nil)
+}
+
+// MVCCFindWeightedSplitKey is like MVCCFindSplitKey, but additionally
+// balances the load on the two subranges. loadBefore returns the fraction
+// of the load which falls on keys before the supplied key; the split key
+// is chosen such that the average of the fractions of bytes and load
+// before it is as close to one half as possible. If loadBefore is nil,
+// only the bytes are considered.
+func MVCCFindWeightedSplitKey(engine Engine, rangeID roachpb.RangeID, key, endKey roachpb.RKey,
+	loadBefore func(roachpb.Key) float64) (roachpb.Key, error) {
 	if key.Less(roachpb.RKey(keys.LocalMax)) {
 		key = keys.Addr(keys.LocalMax)
 	}
@@ -1566,10 +1755,9 @@ func MVCCFindSplitKey(engine Engine, rangeID roachpb.RangeID, key, endKey roachp
 	}
 	rangeSize := ms.KeyBytes + ms.ValBytes
 
-	targetSize := rangeSize / 2
 	sizeSoFar := int64(0)
 	bestSplitKey := encStartKey
-	bestSplitDiff := int64(math.MaxInt64)
+	bestSplitDiff := math.Inf(1)
 	var lastKey roachpb.Key
 
 	if err := engine.Iterate(encStartKey, encEndKey, func(kv MVCCKeyValue) (bool, error) {
@@ -1577,10 +1765,14 @@ func MVCCFindSplitKey(engine Engine, rangeID roachpb.RangeID, key, endKey roachp
 		valid := isValidEncodedSplitKey(kv.Key)
 
 		// Determine if this key would make a better split than last "best" key.
-		diff := targetSize - sizeSoFar
-		if diff < 0 {
-			diff = -diff
+		var fraction float64
+		if rangeSize > 0 {
+			fraction = float64(sizeSoFar) / float64(rangeSize)
+		}
+		if loadBefore != nil {
+			fraction = (fraction + loadBefore(kv.Key.Key)) / 2
 		}
+		diff := math.Abs(0.5 - fraction)
 		if valid && diff < bestSplitDiff {
 			bestSplitKey = kv.Key
 			bestSplitDiff = diff
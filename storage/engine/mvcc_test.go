@@ -987,6 +987,190 @@ func TestMVCCScanMaxNum(t *testing.T) {
 	}
 }
 
+func TestMVCCIterateWithTombstones(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	stopper := stop.NewStopper()
+	defer stopper.Stop()
+	engine := createTestEngine(stopper)
+
+	if err := MVCCPut(engine, nil, testKey1, makeTS(1, 0), value1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := MVCCPut(engine, nil, testKey2, makeTS(1, 0), value2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := MVCCDelete(engine, nil, testKey2, makeTS(2, 0), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := MVCCPut(engine, nil, testKey3, makeTS(1, 0), value3, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	iterate := func(ts roachpb.Timestamp) []roachpb.KeyValue {
+		var kvs []roachpb.KeyValue
+		if _, err := MVCCIterateWithTombstones(engine, testKey1, testKey4, ts, true, nil, false,
+			func(kv roachpb.KeyValue) (bool, error) {
+				kvs = append(kvs, kv)
+				return false, nil
+			}); err != nil {
+			t.Fatal(err)
+		}
+		return kvs
+	}
+
+	// Before the deletion, all keys are live.
+	kvs := iterate(makeTS(1, 0))
+	if len(kvs) != 3 || kvs[1].Value.IsTombstone() ||
+		!bytes.Equal(kvs[1].Value.RawBytes, value2.RawBytes) {
+		t.Fatalf("unexpected scan results before deletion: %v", kvs)
+	}
+
+	// After it, the deleted key is returned as a tombstone.
+	kvs = iterate(makeTS(3, 0))
+	if len(kvs) != 3 {
+		t.Fatalf("expected 3 rows; got %v", kvs)
+	}
+	if kvs[0].Value.IsTombstone() || !bytes.Equal(kvs[0].Value.RawBytes, value1.RawBytes) {
+		t.Errorf("expected live value for %s; got %v", testKey1, kvs[0])
+	}
+	if !bytes.Equal(kvs[1].Key, testKey2) || !kvs[1].Value.IsTombstone() ||
+		!kvs[1].Value.Timestamp.Equal(makeTS(2, 0)) {
+		t.Errorf("expected tombstone at %s for %s; got %v", makeTS(2, 0), testKey2, kvs[1])
+	}
+	if kvs[2].Value.IsTombstone() || !bytes.Equal(kvs[2].Value.RawBytes, value3.RawBytes) {
+		t.Errorf("expected live value for %s; got %v", testKey3, kvs[2])
+	}
+
+	// A regular scan omits the deleted key.
+	if kvs, _, err := MVCCScan(engine, testKey1, testKey4, 0, makeTS(3, 0), true, nil); err != nil {
+		t.Fatal(err)
+	} else if len(kvs) != 2 {
+		t.Errorf("expected 2 live rows; got %v", kvs)
+	}
+}
+
+func TestMVCCScanVersions(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	stopper := stop.NewStopper()
+	defer stopper.Stop()
+	engine := createTestEngine(stopper)
+
+	// testKey1 is written three times and then deleted; testKey2 has a
+	// single committed version followed by an intent; testKey3 has a
+	// single version outside of the scanned window.
+	for i, v := range []roachpb.Value{value1, value2, value3} {
+		if err := MVCCPut(engine, nil, testKey1, makeTS(int64(i+1), 0), v, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := MVCCDelete(engine, nil, testKey1, makeTS(4, 0), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := MVCCPut(engine, nil, testKey2, makeTS(2, 0), value2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := MVCCPut(engine, nil, testKey2, makeTS(5, 0), value4, makeTxn(txn1, makeTS(5, 0))); err != nil {
+		t.Fatal(err)
+	}
+	if err := MVCCPut(engine, nil, testKey3, makeTS(7, 0), value3, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	type version struct {
+		key   roachpb.Key
+		ts    roachpb.Timestamp
+		value *roachpb.Value // nil for tombstones
+	}
+	// The intent on testKey2 is at timestamp 5. It is ignored by scans
+	// whose window ends below it and reported by all others, including
+	// those whose window starts above it.
+	testCases := []struct {
+		min, max  roachpb.Timestamp
+		expIntent bool
+		expected  []version
+	}{
+		{makeTS(0, 1), makeTS(4, 0), false, []version{
+			{testKey1, makeTS(1, 0), &value1},
+			{testKey1, makeTS(2, 0), &value2},
+			{testKey1, makeTS(3, 0), &value3},
+			{testKey1, makeTS(4, 0), nil},
+			{testKey2, makeTS(2, 0), &value2},
+		}},
+		// Both bounds are inclusive.
+		{makeTS(2, 0), makeTS(3, 0), false, []version{
+			{testKey1, makeTS(2, 0), &value2},
+			{testKey1, makeTS(3, 0), &value3},
+			{testKey2, makeTS(2, 0), &value2},
+		}},
+		{makeTS(4, 0), makeTS(4, 0), false, []version{
+			{testKey1, makeTS(4, 0), nil},
+		}},
+		{makeTS(6, 0), makeTS(10, 0), true, []version{
+			{testKey3, makeTS(7, 0), &value3},
+		}},
+	}
+	for i, test := range testCases {
+		kvs, intents, err := MVCCScanVersions(engine, testKey1, testKey4, test.min, test.max, false)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if !test.expIntent {
+			if len(intents) != 0 {
+				t.Errorf("%d: expected no intents; got %v", i, intents)
+			}
+		} else if len(intents) != 1 || !intents[0].Key.Equal(testKey2) {
+			t.Errorf("%d: expected intent on %s; got %v", i, testKey2, intents)
+		}
+		if len(kvs) != len(test.expected) {
+			t.Fatalf("%d: expected %d versions; got %v", i, len(test.expected), kvs)
+		}
+		for j, exp := range test.expected {
+			kv := kvs[j]
+			if !kv.Key.Equal(exp.key) || !kv.Value.Timestamp.Equal(exp.ts) {
+				t.Errorf("%d.%d: expected %s@%s; got %s@%s", i, j, exp.key, exp.ts, kv.Key, kv.Value.Timestamp)
+			}
+			if exp.value == nil {
+				if !kv.Value.IsTombstone() {
+					t.Errorf("%d.%d: expected tombstone; got %v", i, j, kv.Value)
+				}
+			} else if !bytes.Equal(kv.Value.RawBytes, exp.value.RawBytes) {
+				t.Errorf("%d.%d: expected %v; got %v", i, j, exp.value, kv.Value)
+			}
+		}
+	}
+
+	// A consistent scan fails on the intent unless the window ends below
+	// it, even if the window starts above it.
+	for _, min := range []roachpb.Timestamp{makeTS(0, 1), makeTS(6, 0)} {
+		if _, _, err := MVCCScanVersions(engine, testKey1, testKey4, min, makeTS(10, 0), true); err == nil {
+			t.Fatalf("%s: expected write intent error", min)
+		} else if wiErr, ok := err.(*roachpb.WriteIntentError); !ok ||
+			len(wiErr.Intents) != 1 || !wiErr.Intents[0].Key.Equal(testKey2) {
+			t.Fatalf("%s: unexpected error: %v", min, err)
+		}
+	}
+	kvs, _, err := MVCCScanVersions(engine, testKey1, testKey4, makeTS(0, 1), makeTS(4, 0), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kvs) != 5 {
+		t.Errorf("expected 5 versions; got %v", kvs)
+	}
+	// Without the intent, the consistent scan succeeds.
+	kvs, _, err = MVCCScanVersions(engine, testKey1, testKey2, makeTS(0, 1), makeTS(10, 0), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kvs) != 4 {
+		t.Errorf("expected 4 versions of %s; got %v", testKey1, kvs)
+	}
+
+	// Inverted bounds are rejected.
+	if _, _, err := MVCCScanVersions(engine, testKey1, testKey4, makeTS(2, 0), makeTS(1, 0), true); err == nil {
+		t.Error("expected error for inverted timestamp bounds")
+	}
+}
+
 func TestMVCCScanWithKeyPrefix(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	stopper := stop.NewStopper()
@@ -1146,7 +1330,7 @@ func TestMVCCDeleteRange(t *testing.T) {
 	err = MVCCPut(engine, nil, testKey3, makeTS(1, 0), value3, nil)
 	err = MVCCPut(engine, nil, testKey4, makeTS(1, 0), value4, nil)
 
-	num, err := MVCCDeleteRange(engine, nil, testKey2, testKey4, 0, makeTS(2, 0), nil)
+	num, _, err := MVCCDeleteRange(engine, nil, testKey2, testKey4, 0, makeTS(2, 0), nil, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1162,7 +1346,7 @@ func TestMVCCDeleteRange(t *testing.T) {
 		t.Fatal("the value should not be empty")
 	}
 
-	num, err = MVCCDeleteRange(engine, nil, testKey4, keyMax, 0, makeTS(2, 0), nil)
+	num, _, err = MVCCDeleteRange(engine, nil, testKey4, keyMax, 0, makeTS(2, 0), nil, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1176,7 +1360,7 @@ func TestMVCCDeleteRange(t *testing.T) {
 		t.Fatal("the value should not be empty")
 	}
 
-	num, err = MVCCDeleteRange(engine, nil, keyMin, testKey2, 0, makeTS(2, 0), nil)
+	num, _, err = MVCCDeleteRange(engine, nil, keyMin, testKey2, 0, makeTS(2, 0), nil, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1189,6 +1373,50 @@ func TestMVCCDeleteRange(t *testing.T) {
 	}
 }
 
+// TestMVCCDeleteRangeReturnKeys verifies that the deleted keys are
+// returned only when requested and that the limit is respected.
+func TestMVCCDeleteRangeReturnKeys(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	stopper := stop.NewStopper()
+	defer stopper.Stop()
+	engine := createTestEngine(stopper)
+
+	for i, k := range []roachpb.Key{testKey1, testKey2, testKey3, testKey4} {
+		v := roachpb.MakeValueFromString(fmt.Sprintf("v%d", i))
+		if err := MVCCPut(engine, nil, k, makeTS(1, 0), v, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Without returnKeys, only the count is returned.
+	num, keys, err := MVCCDeleteRange(engine, nil, testKey1, testKey3, 0, makeTS(2, 0), nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num != 2 || keys != nil {
+		t.Fatalf("expected 2 deletions and no keys; got %d, %v", num, keys)
+	}
+
+	// With returnKeys, the deleted keys are returned in order, up to max.
+	if err := MVCCPut(engine, nil, testKey1, makeTS(3, 0), value1, nil); err != nil {
+		t.Fatal(err)
+	}
+	num, keys, err = MVCCDeleteRange(engine, nil, keyMin, keyMax, 2, makeTS(4, 0), nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expKeys := []roachpb.Key{testKey1, testKey3}; num != 2 || !reflect.DeepEqual(keys, expKeys) {
+		t.Fatalf("expected %d deletions of %v; got %d, %v", len(expKeys), expKeys, num, keys)
+	}
+	num, keys, err = MVCCDeleteRange(engine, nil, keyMin, keyMax, 0, makeTS(4, 0), nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expKeys := []roachpb.Key{testKey4}; num != 1 || !reflect.DeepEqual(keys, expKeys) {
+		t.Fatalf("expected %d deletions of %v; got %d, %v", len(expKeys), expKeys, num, keys)
+	}
+}
+
 func TestMVCCDeleteRangeFailed(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	stopper := stop.NewStopper()
@@ -1200,12 +1428,12 @@ func TestMVCCDeleteRangeFailed(t *testing.T) {
 	err = MVCCPut(engine, nil, testKey3, makeTS(1, 0), value3, txn1)
 	err = MVCCPut(engine, nil, testKey4, makeTS(1, 0), value4, nil)
 
-	_, err = MVCCDeleteRange(engine, nil, testKey2, testKey4, 0, makeTS(1, 0), nil)
+	_, _, err = MVCCDeleteRange(engine, nil, testKey2, testKey4, 0, makeTS(1, 0), nil, false)
 	if err == nil {
 		t.Fatal("expected error on uncommitted write intent")
 	}
 
-	_, err = MVCCDeleteRange(engine, nil, testKey2, testKey4, 0, makeTS(1, 0), txn1)
+	_, _, err = MVCCDeleteRange(engine, nil, testKey2, testKey4, 0, makeTS(1, 0), txn1, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1222,7 +1450,7 @@ func TestMVCCDeleteRangeConcurrentTxn(t *testing.T) {
 	err = MVCCPut(engine, nil, testKey3, makeTS(2, 0), value3, txn2)
 	err = MVCCPut(engine, nil, testKey4, makeTS(1, 0), value4, nil)
 
-	_, err = MVCCDeleteRange(engine, nil, testKey2, testKey4, 0, makeTS(1, 0), txn1)
+	_, _, err = MVCCDeleteRange(engine, nil, testKey2, testKey4, 0, makeTS(1, 0), txn1, false)
 	if err == nil {
 		t.Fatal("expected error on uncommitted write intent")
 	}
@@ -1396,6 +1624,161 @@ func TestMVCCResolveTxn(t *testing.T) {
 	}
 }
 
+// TestMVCCConditionalPutDelete verifies that a conditional put without
+// a value deletes the key if, and only if, the expected value matches.
+func TestMVCCConditionalPutDelete(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	stopper := stop.NewStopper()
+	defer stopper.Stop()
+	engine := createTestEngine(stopper)
+
+	if err := MVCCPut(engine, nil, testKey1, makeTS(1, 0), value1, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A mismatched expectation fails and leaves the value in place.
+	err := MVCCConditionalPut(engine, nil, testKey1, makeTS(2, 0), roachpb.Value{}, &value2, nil)
+	if cErr, ok := err.(*roachpb.ConditionFailedError); !ok {
+		t.Fatalf("expected ConditionFailedError, got %v", err)
+	} else if cErr.ActualValue == nil || !bytes.Equal(cErr.ActualValue.RawBytes, value1.RawBytes) {
+		t.Fatalf("expected actual value %v, got %v", value1, cErr.ActualValue)
+	}
+	if val, _, err := MVCCGet(engine, testKey1, makeTS(2, 0), true, nil); err != nil || val == nil {
+		t.Fatalf("expected value to remain, got %v, %v", val, err)
+	}
+
+	// A matching expectation deletes the key.
+	if err := MVCCConditionalPut(engine, nil, testKey1, makeTS(3, 0), roachpb.Value{}, &value1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if val, _, err := MVCCGet(engine, testKey1, makeTS(3, 0), true, nil); err != nil || val != nil {
+		t.Fatalf("expected key to be deleted, got %v, %v", val, err)
+	}
+
+	// Deleting an absent key fails if a value is expected...
+	err = MVCCConditionalPut(engine, nil, testKey2, makeTS(4, 0), roachpb.Value{}, &value1, nil)
+	if cErr, ok := err.(*roachpb.ConditionFailedError); !ok {
+		t.Fatalf("expected ConditionFailedError, got %v", err)
+	} else if cErr.ActualValue != nil {
+		t.Fatalf("expected missing actual value, got %v", cErr.ActualValue)
+	}
+	// ...and succeeds if the key is expected to be absent.
+	if err := MVCCConditionalPut(engine, nil, testKey2, makeTS(4, 0), roachpb.Value{}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if val, _, err := MVCCGet(engine, testKey2, makeTS(4, 0), true, nil); err != nil || val != nil {
+		t.Fatalf("expected key to be absent, got %v, %v", val, err)
+	}
+}
+
+// TestMVCCConditionalPutTimestamp verifies that a conditional put on the
+// existing value's timestamp succeeds only on an exact match, reporting the
+// actual value and timestamp otherwise, and never matches a missing key.
+func TestMVCCConditionalPutTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	stopper := stop.NewStopper()
+	defer stopper.Stop()
+	engine := createTestEngine(stopper)
+
+	if err := MVCCPut(engine, nil, testKey1, makeTS(1, 0), value1, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A mismatched timestamp fails and reports the value actually written.
+	err := MVCCConditionalPutTimestamp(engine, nil, testKey1, makeTS(2, 0), value2, makeTS(1, 1), nil)
+	if cErr, ok := err.(*roachpb.ConditionFailedError); !ok {
+		t.Fatalf("expected ConditionFailedError, got %v", err)
+	} else if cErr.ActualValue == nil || !bytes.Equal(cErr.ActualValue.RawBytes, value1.RawBytes) ||
+		!cErr.ActualValue.Timestamp.Equal(makeTS(1, 0)) {
+		t.Fatalf("expected actual value %v at %s, got %v", value1, makeTS(1, 0), cErr.ActualValue)
+	}
+
+	// A matching timestamp writes the new value.
+	if err := MVCCConditionalPutTimestamp(engine, nil, testKey1, makeTS(2, 0), value2, makeTS(1, 0), nil); err != nil {
+		t.Fatal(err)
+	}
+	if val, _, err := MVCCGet(engine, testKey1, makeTS(2, 0), true, nil); err != nil {
+		t.Fatal(err)
+	} else if val == nil || !bytes.Equal(val.RawBytes, value2.RawBytes) {
+		t.Fatalf("expected %v, got %v", value2, val)
+	}
+
+	// The old timestamp no longer matches once the key was rewritten.
+	err = MVCCConditionalPutTimestamp(engine, nil, testKey1, makeTS(3, 0), value3, makeTS(1, 0), nil)
+	if cErr, ok := err.(*roachpb.ConditionFailedError); !ok {
+		t.Fatalf("expected ConditionFailedError, got %v", err)
+	} else if cErr.ActualValue == nil || !cErr.ActualValue.Timestamp.Equal(makeTS(2, 0)) {
+		t.Fatalf("expected actual value at %s, got %v", makeTS(2, 0), cErr.ActualValue)
+	}
+
+	// A missing key never matches, not even the zero timestamp.
+	for _, ts := range []roachpb.Timestamp{roachpb.ZeroTimestamp, makeTS(1, 0)} {
+		err = MVCCConditionalPutTimestamp(engine, nil, testKey2, makeTS(3, 0), value3, ts, nil)
+		if cErr, ok := err.(*roachpb.ConditionFailedError); !ok {
+			t.Fatalf("expected ConditionFailedError, got %v", err)
+		} else if cErr.ActualValue != nil {
+			t.Fatalf("expected missing actual value, got %v", cErr.ActualValue)
+		}
+	}
+}
+
+// TestMVCCConditionalPutWithTxn verifies that a transactional conditional
+// put which succeeds writes an intent, and that one which fails leaves the
+// key untouched.
+func TestMVCCConditionalPutWithTxn(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	stopper := stop.NewStopper()
+	defer stopper.Stop()
+	engine := createTestEngine(stopper)
+
+	for _, key := range []roachpb.Key{testKey1, testKey2} {
+		if err := MVCCPut(engine, nil, key, makeTS(1, 0), value1, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	txn := *txn1
+	txn.Timestamp = makeTS(2, 0)
+
+	// The comparison succeeds against the committed value...
+	if err := MVCCConditionalPut(engine, nil, testKey1, txn.Timestamp, value2, &value1, &txn); err != nil {
+		t.Fatal(err)
+	}
+	// ...and the new value is an intent of the transaction.
+	if _, _, err := MVCCGet(engine, testKey1, makeTS(3, 0), true, nil); err == nil {
+		t.Fatal("expected write intent error")
+	} else if _, ok := err.(*roachpb.WriteIntentError); !ok {
+		t.Fatalf("expected write intent error; got %s", err)
+	}
+	val, intents, err := MVCCGet(engine, testKey1, makeTS(3, 0), false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(val.RawBytes, value1.RawBytes) {
+		t.Errorf("expected committed value %q; got %q", value1.RawBytes, val.RawBytes)
+	}
+	if len(intents) != 1 || !bytes.Equal(intents[0].Txn.ID, txn.ID) {
+		t.Errorf("expected a single intent of the transaction; got %+v", intents)
+	}
+	if val, _, err := MVCCGet(engine, testKey1, makeTS(3, 0), true, &txn); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(val.RawBytes, value2.RawBytes) {
+		t.Errorf("expected transaction to read its intent %q; got %q", value2.RawBytes, val.RawBytes)
+	}
+
+	// A mismatch returns the actual value and writes no intent.
+	err = MVCCConditionalPut(engine, nil, testKey2, txn.Timestamp, value3, &value2, &txn)
+	if cfErr, ok := err.(*roachpb.ConditionFailedError); !ok {
+		t.Fatalf("expected condition failed error; got %v", err)
+	} else if cfErr.ActualValue == nil || !bytes.Equal(cfErr.ActualValue.RawBytes, value1.RawBytes) {
+		t.Errorf("expected actual value %q; got %v", value1.RawBytes, cfErr.ActualValue)
+	}
+	if val, _, err := MVCCGet(engine, testKey2, makeTS(3, 0), true, nil); err != nil {
+		t.Fatalf("expected no intent; got %s", err)
+	} else if !bytes.Equal(val.RawBytes, value1.RawBytes) {
+		t.Errorf("expected value %q; got %q", value1.RawBytes, val.RawBytes)
+	}
+}
+
 // TestMVCCConditionalPutOldTimestamp tests a case where a conditional
 // put with an older timestamp happens after a put with a newer timestamp.
 //
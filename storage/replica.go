@@ -42,6 +42,7 @@ import (
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/encoding"
 	"github.com/cockroachdb/cockroach/util/log"
+	"github.com/cockroachdb/cockroach/util/retry"
 	"github.com/cockroachdb/cockroach/util/tracer"
 	"github.com/gogo/protobuf/proto"
 )
@@ -106,16 +107,21 @@ const (
 // index equal to the value of the final Method. Unused indexes
 // default to false.
 var tsCacheMethods = [...]bool{
-	roachpb.Get:                true,
-	roachpb.Put:                true,
-	roachpb.ConditionalPut:     true,
-	roachpb.Increment:          true,
-	roachpb.Scan:               true,
-	roachpb.ReverseScan:        true,
-	roachpb.Delete:             true,
-	roachpb.DeleteRange:        true,
-	roachpb.ResolveIntent:      true,
-	roachpb.ResolveIntentRange: true,
+	roachpb.Get:                 true,
+	roachpb.GetMulti:            true,
+	roachpb.Put:                 true,
+	roachpb.WriteBatch:          true,
+	roachpb.ConditionalPut:      true,
+	roachpb.ConditionalPutBatch: true,
+	roachpb.Increment:           true,
+	roachpb.Scan:                true,
+	roachpb.ScanVersions:        true,
+	roachpb.ContainsRange:       true,
+	roachpb.ReverseScan:         true,
+	roachpb.Delete:              true,
+	roachpb.DeleteRange:         true,
+	roachpb.ResolveIntent:       true,
+	roachpb.ResolveIntentRange:  true,
 }
 
 // usesTimestampCache returns true if the request affects or is
@@ -128,16 +134,38 @@ func usesTimestampCache(r roachpb.Request) bool {
 	return tsCacheMethods[m]
 }
 
+// isLeaseAcquisition returns true if the request asks for the leader
+// lease on behalf of the replica proposing it. Such requests are the only
+// ones which may be applied without the proposer holding the lease.
+func isLeaseAcquisition(r roachpb.Request) bool {
+	args, ok := r.(*roachpb.LeaderLeaseRequest)
+	return ok && !args.Transfer
+}
+
 // A pendingCmd holds a done channel for a command sent to Raft. Once
 // committed to the Raft log, the command is executed and the result returned
 // via the done channel.
 type pendingCmd struct {
-	ctx  context.Context
-	done chan roachpb.ResponseWithError // Used to signal waiting RPC handler
+	idKey   cmdIDKey
+	ctx     context.Context
+	done    chan roachpb.ResponseWithError // Used to signal waiting RPC handler
+	applied chan struct{}                  // Closed once the command has been applied or rejected
+	err     error                          // Why raft rejected the command; set before the command is retired
 }
 
 type cmdIDKey string
 
+// A retiredCmd remembers a locally proposed command which is no longer
+// pending, so that tests can still wait for it to be applied.
+type retiredCmd struct {
+	idKey   cmdIDKey
+	applied <-chan struct{}
+	err     error
+}
+
+// maxRetiredCmds is the number of retired commands a replica remembers.
+const maxRetiredCmds = 128
+
 // A Replica is a contiguous keyspace with writes managed via an
 // instance of the Raft consensus algorithm. Many ranges may exist
 // in a store and they are unlikely to be contiguous. Ranges are
@@ -154,10 +182,12 @@ type Replica struct {
 	lastIndex uint64
 	// Last index applied to the state machine. Updated atomically.
 	appliedIndex uint64
-	systemDBHash []byte         // sha1 hash of the system config @ last gossip
-	lease        unsafe.Pointer // Information for leader lease, updated atomically
-	llMu         sync.Mutex     // Synchronizes readers' requests for leader lease
-	sequence     *SequenceCache // Provides txn replay protection
+	systemDBHash []byte          // sha1 hash of the system config @ last gossip
+	lease        unsafe.Pointer  // Information for leader lease, updated atomically
+	llMu         sync.Mutex      // Synchronizes readers' requests for leader lease
+	sequence     *SequenceCache  // Provides txn replay protection
+	metrics      *replicaMetrics // Per-method operation counters
+	sampler      keySampler      // Keys of recent requests and writes
 
 	// proposeRaftCommandFn can be set to mock out the propose operation.
 	proposeRaftCommandFn func(cmdIDKey, roachpb.RaftCommand) <-chan error
@@ -172,6 +202,15 @@ type Replica struct {
 	tsCache      *TimestampCache // Most recent timestamps for keys / key ranges
 	pendingSeq   uint64          // atomic sequence counter for cmdIDKey generation
 	pendingCmds  map[cmdIDKey]*pendingCmd
+	retiredCmds  [maxRetiredCmds]retiredCmd // Ring of the most recently applied or rejected commands
+	retiredNext  int                        // Index in retiredCmds of the next command to retire
+
+	// systemDBKVs caches the contents of the SystemDB span for gossip,
+	// with systemDBKVsHash as their hash. Both are reset when a write to
+	// the span is applied or the range's bounds change; a nil hash means
+	// the span has to be rescanned.
+	systemDBKVs     []roachpb.KeyValue
+	systemDBKVsHash []byte
 
 	// pendingReplica houses a replica that is not yet in the range
 	// descriptor, since we must be able to look up a replica's
@@ -196,6 +235,7 @@ func NewReplica(desc *roachpb.RangeDescriptor, store *Store) (*Replica, error) {
 		cmdQ:        NewCommandQueue(),
 		tsCache:     NewTimestampCache(store.Clock()),
 		sequence:    NewSequenceCache(desc.RangeID),
+		metrics:     &replicaMetrics{},
 		pendingCmds: map[cmdIDKey]*pendingCmd{},
 	}
 	r.pendingReplica.Cond = sync.NewCond(r)
@@ -272,6 +312,29 @@ func (r *Replica) context() context.Context {
 	return context.WithValue(r.store.Context(nil), log.RangeID, r.Desc().RangeID)
 }
 
+// logPrefix returns the "[rN/sM]" tag which identifies the replica by range
+// and store in its log messages.
+func (r *Replica) logPrefix() string {
+	return fmt.Sprintf("[r%d/s%d] ", r.Desc().RangeID, r.store.StoreID())
+}
+
+// infof logs to the INFO log, prefixed with the replica's identity.
+func (r *Replica) infof(format string, args ...interface{}) {
+	log.InfocDepth(r.context(), 1, r.logPrefix()+format, args...)
+}
+
+// warningf logs to the WARNING and INFO logs, prefixed with the replica's
+// identity.
+func (r *Replica) warningf(format string, args ...interface{}) {
+	log.WarningcDepth(r.context(), 1, r.logPrefix()+format, args...)
+}
+
+// errorf logs to the ERROR, WARNING and INFO logs, prefixed with the
+// replica's identity.
+func (r *Replica) errorf(format string, args ...interface{}) {
+	log.ErrorcDepth(r.context(), 1, r.logPrefix()+format, args...)
+}
+
 // GetMaxBytes atomically gets the range maximum byte limit.
 func (r *Replica) GetMaxBytes() int64 {
 	return atomic.LoadInt64(&r.maxBytes)
@@ -283,6 +346,20 @@ func (r *Replica) SetMaxBytes(maxBytes int64) {
 	atomic.StoreInt64(&r.maxBytes, maxBytes)
 }
 
+// ApplyConfigChange refreshes the state the replica derives from the zone
+// config covering its range, which is the size at which the range splits.
+// Other zone settings, such as the GC TTL and the number of replicas, are
+// looked up by the queues on each pass and need no caching here. Calling
+// ApplyConfigChange repeatedly with the same config has no further effect.
+func (r *Replica) ApplyConfigChange(cfg *config.SystemConfig) error {
+	zone, err := cfg.GetZoneConfigForKey(r.Desc().StartKey)
+	if err != nil {
+		return util.Errorf("failed to lookup zone config for Range %s: %s", r, err)
+	}
+	r.SetMaxBytes(zone.RangeMaxBytes)
+	return nil
+}
+
 // IsFirstRange returns true if this is the first range.
 func (r *Replica) IsFirstRange() bool {
 	return bytes.Equal(r.Desc().StartKey, roachpb.RKeyMin)
@@ -301,6 +378,31 @@ func (r *Replica) getLease() *roachpb.Lease {
 	return (*roachpb.Lease)(atomic.LoadPointer(&r.lease))
 }
 
+// LeaseStatus describes the leader lease of a range as last applied by one
+// of its replicas.
+type LeaseStatus struct {
+	// Holder is the replica holding the lease. It is empty if no lease
+	// was ever granted.
+	Holder     roachpb.ReplicaDescriptor `json:"holder"`
+	Start      roachpb.Timestamp         `json:"start"`
+	Expiration roachpb.Timestamp         `json:"expiration"`
+	// Valid is true if the lease covers the current time of the store's
+	// clock.
+	Valid bool `json:"valid"`
+}
+
+// LeaseStatus returns the status of the range's leader lease as known to
+// this replica.
+func (r *Replica) LeaseStatus() LeaseStatus {
+	lease := r.getLease()
+	return LeaseStatus{
+		Holder:     lease.Replica,
+		Start:      lease.Start,
+		Expiration: lease.Expiration,
+		Valid:      lease.Replica.ReplicaID != 0 && lease.Covers(r.store.Clock().Now()),
+	}
+}
+
 // newNotLeaderError returns a NotLeaderError initialized with the
 // replica for the holder (if any) of the given lease.
 func (r *Replica) newNotLeaderError(l *roachpb.Lease, originStoreID roachpb.StoreID) error {
@@ -330,7 +432,7 @@ func (r *Replica) requestLeaderLease(timestamp roachpb.Timestamp) error {
 	if replica == nil {
 		return roachpb.NewRangeNotFoundError(desc.RangeID)
 	}
-	args := &roachpb.LeaderLeaseRequest{
+	return r.proposeLeaderLease(&roachpb.LeaderLeaseRequest{
 		Span: roachpb.Span{
 			Key: desc.StartKey.AsRawKey(),
 		},
@@ -339,9 +441,55 @@ func (r *Replica) requestLeaderLease(timestamp roachpb.Timestamp) error {
 			Expiration: expiration,
 			Replica:    *replica,
 		},
+	}, duration)
+}
+
+// TransferLeaderLease transfers the leader lease, which this replica must
+// hold, to the given replica of the range without waiting for it to
+// expire. Once the transfer has been applied, commands sent to this
+// replica are redirected to the target.
+func (r *Replica) TransferLeaderLease(target roachpb.ReplicaDescriptor) error {
+	r.llMu.Lock()
+	defer r.llMu.Unlock()
+
+	desc := r.Desc()
+	_, replica := desc.FindReplica(target.StoreID)
+	if replica == nil || replica.NodeID != target.NodeID {
+		return util.Errorf("cannot transfer leader lease of range %d to %v, which is not one of its replicas",
+			desc.RangeID, target)
 	}
+	timestamp := r.store.Clock().Now()
+	if lease := r.getLease(); !lease.OwnedBy(r.store.StoreID()) || !lease.Covers(timestamp) {
+		return r.newNotLeaderError(lease, r.store.StoreID())
+	}
+	if replica.StoreID == r.store.StoreID() {
+		return nil
+	}
+
+	duration := DefaultLeaderLeaseDuration
+	return r.proposeLeaderLease(&roachpb.LeaderLeaseRequest{
+		Span: roachpb.Span{
+			Key: desc.StartKey.AsRawKey(),
+		},
+		Lease: roachpb.Lease{
+			Start:      timestamp,
+			Expiration: timestamp.Add(int64(duration), 0),
+			Replica:    *replica,
+		},
+		Transfer: true,
+	}, duration)
+}
+
+// proposeLeaderLease proposes the given lease request and waits for it to
+// be applied. The request is abandoned after the given duration.
+func (r *Replica) proposeLeaderLease(args *roachpb.LeaderLeaseRequest, duration time.Duration) error {
 	ba := roachpb.BatchRequest{}
-	ba.RangeID = desc.RangeID
+	ba.RangeID = r.Desc().RangeID
+	if args.Transfer {
+		// The current holder's lease is verified at this timestamp when the
+		// transfer is applied.
+		ba.Timestamp = args.Lease.Start
+	}
 	ba.Add(args)
 
 	// The raft command becomes moot after its expiration, so give it a
@@ -422,8 +570,7 @@ func (r *Replica) redirectOnOrAcquireLeaderLease(trace *tracer.Trace, timestamp
 			// The lease was rejected even though it was not obtained by another
 			// replica.
 			if log.V(1) {
-				log.Warningf("Lease for range %s rejected at timestamp %v: %s",
-					r, timestamp, err)
+				r.warningf("lease rejected at timestamp %v: %s", timestamp, err)
 			}
 			lease = nil
 		}
@@ -445,6 +592,49 @@ func (r *Replica) Desc() *roachpb.RangeDescriptor {
 	return (*roachpb.RangeDescriptor)(atomic.LoadPointer(&r.desc))
 }
 
+// Metrics returns a snapshot of the replica's operation counters.
+func (r *Replica) Metrics() ReplicaMetrics {
+	m := r.metrics.snapshot()
+	m.PendingRaftCommands = int64(r.pendingCmdCount())
+	return m
+}
+
+// pendingCmdCount returns the number of commands proposed to raft by this
+// replica which have not yet been applied.
+func (r *Replica) pendingCmdCount() int {
+	r.RLock()
+	defer r.RUnlock()
+	return len(r.pendingCmds)
+}
+
+// checkRaftOverload sheds load when the replica's raft backlog has reached
+// StoreContext.MaxPendingRaftCommands. A write then waits up to
+// RaftOverloadGracePeriod for the backlog to drain before giving up with a
+// retryable RangeOverloadedError, so that clients back off instead of
+// queueing indefinitely.
+func (r *Replica) checkRaftOverload(ctx context.Context) error {
+	max := r.store.ctx.MaxPendingRaftCommands
+	if max <= 0 {
+		return nil
+	}
+	deadline := time.Now().Add(r.store.ctx.RaftOverloadGracePeriod)
+	var pending int
+	for retryer := retry.Start(retry.Options{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+		Multiplier:     2,
+		Closer:         ctx.Done(),
+	}); retryer.Next(); {
+		if pending = r.pendingCmdCount(); pending < max {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			break
+		}
+	}
+	return &roachpb.RangeOverloadedError{RangeID: r.Desc().RangeID, Pending: int32(pending)}
+}
+
 // setDesc atomically sets the range's descriptor. This method calls
 // processRangeDescriptorUpdate() to make the range manager handle the
 // descriptor update.
@@ -460,7 +650,11 @@ func (r *Replica) setDesc(desc *roachpb.RangeDescriptor) error {
 // setDescWithoutProcessUpdate updates the range descriptor without calling
 // processRangeDescriptorUpdate.
 func (r *Replica) setDescWithoutProcessUpdate(desc *roachpb.RangeDescriptor) {
-	atomic.StorePointer(&r.desc, unsafe.Pointer(desc))
+	old := (*roachpb.RangeDescriptor)(atomic.SwapPointer(&r.desc, unsafe.Pointer(desc)))
+	if old != nil && (!old.StartKey.Equal(desc.StartKey) || !old.EndKey.Equal(desc.EndKey)) {
+		// The sampled keys describe the previous key span.
+		r.sampler.reset()
+	}
 }
 
 // getCachedTruncatedState atomically returns the range's cached truncated
@@ -523,6 +717,13 @@ func containsKeyRange(desc roachpb.RangeDescriptor, start, end roachpb.Key) bool
 	return desc.ContainsKeyRange(keys.Addr(start), keys.Addr(end))
 }
 
+// ComputeReplicationAction returns the replicas which must be added to or
+// removed from this range to match the replication factor of the supplied
+// zone configuration. See Allocator.ComputeReplicationChanges.
+func (r *Replica) ComputeReplicationAction(zone *config.ZoneConfig) (add, remove []roachpb.ReplicaDescriptor, err error) {
+	return r.store.allocator.ComputeReplicationChanges(*zone, r.Desc())
+}
+
 // GetGCMetadata reads the latest GC metadata for this range.
 func (r *Replica) GetGCMetadata() (*roachpb.GCMetadata, error) {
 	key := keys.RangeGCMetadataKey(r.Desc().RangeID)
@@ -563,6 +764,12 @@ func (r *Replica) Send(ctx context.Context, ba roachpb.BatchRequest) (*roachpb.B
 	var br *roachpb.BatchResponse
 	var err error
 
+	// A replica which has not yet received its initial snapshot has no data
+	// and doesn't know its key range. Let the client try another replica.
+	if !r.isInitialized() {
+		return nil, roachpb.NewError(roachpb.NewRangeNotFoundError(r.Desc().RangeID))
+	}
+
 	if err := r.checkBatchRequest(ba); err != nil {
 		return nil, roachpb.NewError(err)
 	}
@@ -629,6 +836,18 @@ func (r *Replica) checkBatchRequest(ba roachpb.BatchRequest) error {
 	} else if ba.ReadConsistency == roachpb.INCONSISTENT {
 		return util.Errorf("inconsistent mode is only available to reads")
 	}
+	if len(ba.Requests) > 1 {
+		if _, ok := ba.GetArg(roachpb.ComputeChecksum); ok {
+			return util.Errorf("%s must be alone in a batch", roachpb.ComputeChecksum)
+		}
+	}
+	if ba.Txn == nil {
+		for _, union := range ba.Requests {
+			if args := union.GetInner(); roachpb.RequiresTxn(args) {
+				return util.Errorf("no transaction specified to %s", args.Method())
+			}
+		}
+	}
 
 	return nil
 }
@@ -810,6 +1029,12 @@ func (r *Replica) addWriteCmd(ctx context.Context, ba roachpb.BatchRequest, wg *
 
 	trace := tracer.FromCtx(ctx)
 
+	// Shed the write before it enters the command queue, where it would
+	// block overlapping commands while waiting for the backlog to drain.
+	if err := r.checkRaftOverload(ctx); err != nil {
+		return nil, err
+	}
+
 	// Add the write to the command queue to gate subsequent overlapping
 	// commands until this command completes. Note that this must be
 	// done before getting the max timestamp for the key(s), as
@@ -836,6 +1061,7 @@ func (r *Replica) addWriteCmd(ctx context.Context, ba roachpb.BatchRequest, wg *
 	//
 	// Find the maximum timestamp required to satisfy all requests in
 	// the batch and then apply that to all requests.
+	origTS := ba.Timestamp
 	r.Lock()
 	for _, union := range ba.Requests {
 		args := union.GetInner()
@@ -868,19 +1094,47 @@ func (r *Replica) addWriteCmd(ctx context.Context, ba roachpb.BatchRequest, wg *
 
 	r.Unlock()
 
-	defer trace.Epoch("raft")()
-
-	errChan, pendingCmd := r.proposeRaftCommand(ctx, ba)
+	if !ba.Timestamp.Equal(origTS) {
+		trace.Event(fmt.Sprintf("timestamp pushed from %s to %s", origTS, ba.Timestamp))
+	}
 
-	signal()
+	defer trace.Epoch("raft")()
 
-	// First wait for raft to commit or abort the command.
+	// A proposal which raft dropped was never appended to the log, so the
+	// command can safely be proposed again. All other errors, including
+	// those from applying the command, are final and are returned as is.
 	var br *roachpb.BatchResponse
 	var err error
-	if err = <-errChan; err == nil {
-		// Next if the command was committed, wait for the range to apply it.
-		respWithErr := <-pendingCmd.done
-		br, err = respWithErr.Reply, respWithErr.Err
+	opts := r.store.ctx.RaftProposalRetryOptions
+	opts.Closer = ctx.Done()
+	for retryer := retry.Start(opts); retryer.Next(); {
+		errChan, pendingCmd := r.proposeRaftCommand(ctx, ba)
+
+		signal()
+
+		// First wait for raft to commit or abort the command.
+		if err = <-errChan; err == nil {
+			// Next if the command was committed, wait for the range to apply it.
+			respWithErr := <-pendingCmd.done
+			br, err = respWithErr.Reply, respWithErr.Err
+			break
+		}
+		// The rejected proposal will never be applied; stop tracking it so
+		// that it doesn't count towards the raft backlog.
+		r.Lock()
+		_, pending := r.pendingCmds[pendingCmd.idKey]
+		if pending {
+			pendingCmd.err = err
+			r.retirePendingCmdLocked(pendingCmd)
+		}
+		r.Unlock()
+		if pending {
+			close(pendingCmd.applied)
+		}
+		if err != multiraft.ErrProposalDropped {
+			break
+		}
+		trace.Event(fmt.Sprintf("retrying proposal: %s", err))
 	}
 
 	r.endCmds(cmdKeys, ba, err)
@@ -893,8 +1147,9 @@ func (r *Replica) addWriteCmd(ctx context.Context, ba roachpb.BatchRequest, wg *
 // pending command struct for receiving.
 func (r *Replica) proposeRaftCommand(ctx context.Context, ba roachpb.BatchRequest) (<-chan error, *pendingCmd) {
 	pendingCmd := &pendingCmd{
-		ctx:  ctx,
-		done: make(chan roachpb.ResponseWithError, 1),
+		ctx:     ctx,
+		done:    make(chan roachpb.ResponseWithError, 1),
+		applied: make(chan struct{}),
 	}
 	desc := r.Desc()
 	_, replica := desc.FindReplica(r.store.StoreID())
@@ -914,6 +1169,7 @@ func (r *Replica) proposeRaftCommand(ctx context.Context, ba roachpb.BatchReques
 		buf = encoding.EncodeUint64(buf, uint64(rand.Int63()))[:multiraft.CommandIDLen]
 	}
 	idKey := cmdIDKey(buf)
+	pendingCmd.idKey = idKey
 
 	r.Lock()
 	r.pendingCmds[idKey] = pendingCmd
@@ -929,6 +1185,15 @@ func (r *Replica) proposeRaftCommand(ctx context.Context, ba roachpb.BatchReques
 	return errChan, pendingCmd
 }
 
+// retirePendingCmdLocked stops tracking cmd as pending, remembering it
+// among the replica's most recently retired commands. The replica lock
+// must be held.
+func (r *Replica) retirePendingCmdLocked(cmd *pendingCmd) {
+	delete(r.pendingCmds, cmd.idKey)
+	r.retiredCmds[r.retiredNext] = retiredCmd{idKey: cmd.idKey, applied: cmd.applied, err: cmd.err}
+	r.retiredNext = (r.retiredNext + 1) % maxRetiredCmds
+}
+
 // processRaftCommand processes a raft command by unpacking the command
 // struct to get args and reply and then applying the command to the
 // state machine via applyRaftCommand(). The error result is sent on
@@ -940,7 +1205,9 @@ func (r *Replica) processRaftCommand(idKey cmdIDKey, index uint64, raftCmd roach
 
 	r.Lock()
 	cmd := r.pendingCmds[idKey]
-	delete(r.pendingCmds, idKey)
+	if cmd != nil {
+		r.retirePendingCmdLocked(cmd)
+	}
 	r.Unlock()
 
 	var ctx context.Context
@@ -963,8 +1230,9 @@ func (r *Replica) processRaftCommand(idKey cmdIDKey, index uint64, raftCmd roach
 
 	if cmd != nil {
 		cmd.done <- roachpb.ResponseWithError{Reply: br, Err: err}
+		close(cmd.applied)
 	} else if err != nil && log.V(1) {
-		log.Errorc(r.context(), "error executing raft command: %s", err)
+		r.errorf("error executing raft command: %s", err)
 	}
 
 	return err
@@ -1052,18 +1320,20 @@ func (r *Replica) applyRaftCommandInBatch(ctx context.Context, index uint64, ori
 
 		// TODO(tschottdorf): shouldn't be in the loop. Currently is because
 		// we haven't cleaned up the timestamp handling fully.
-		if lease := r.getLease(); args.Method() != roachpb.LeaderLease &&
+		if lease := r.getLease(); !isLeaseAcquisition(args) &&
 			(!lease.OwnedBy(originReplica.StoreID) || !lease.Covers(ba.Timestamp)) {
 			// Verify the leader lease is held, unless this command is trying to
-			// obtain it. Any other Raft command has had the leader lease held
-			// by the replica at proposal time, but this may no longer be the case.
-			// Corruption aside, the most likely reason is a leadership change (the
-			// most recent leader assumes responsibility for all past timestamps as
-			// well). In that case, it's not valid to go ahead with the execution:
-			// Writes must be aware of the last time the mutated key was read, and
-			// since reads are served locally by the lease holder without going
-			// through Raft, a read which was not taken into account may have been
-			// served. Hence, we must retry at the current leader.
+			// obtain it. Any other Raft command (including a lease transfer,
+			// which must be proposed by the current holder) has had the leader
+			// lease held by the replica at proposal time, but this may no longer
+			// be the case. Corruption aside, the most likely reason is a
+			// leadership change (the most recent leader assumes responsibility
+			// for all past timestamps as well). In that case, it's not valid to
+			// go ahead with the execution: Writes must be aware of the last time
+			// the mutated key was read, and since reads are served locally by the
+			// lease holder without going through Raft, a read which was not taken
+			// into account may have been served. Hence, we must retry at the
+			// current leader.
 			//
 			// It's crucial that we don't update the sequence cache for the
 			// error returned below since the request is going to be retried
@@ -1080,6 +1350,12 @@ func (r *Replica) applyRaftCommandInBatch(ctx context.Context, index uint64, ori
 	// readOnlyCmdMu via a batch.Defer).
 	br, intents, err := r.executeBatch(btch, ms, ba)
 
+	// Drop the cached SystemDB span once the batch commits. Deferred last,
+	// this runs before any system config gossip deferred by the commands.
+	if err == nil && invalidatesSystemDBCache(ba) {
+		btch.Defer(r.invalidateSystemDBCache)
+	}
+
 	// Regardless of error, add result to the sequence cache if this is
 	// a write method. This must be done as part of the execution of
 	// raft commands so that every replica maintains the same responses
@@ -1137,7 +1413,7 @@ func (r *Replica) checkSequenceCache(b engine.Engine, txn roachpb.Transaction) e
 		// We hit the cache, so let the transaction restart.
 		// This is also the path taken by roachpb.SequencePoisonRestart.
 		if log.V(1) {
-			log.Infof("found sequence cache entry for %s@%d", txn.Short(), txn.Sequence)
+			r.infof("found sequence cache entry for %s@%d", txn.Short(), txn.Sequence)
 		}
 		retryErr := roachpb.NewTransactionRetryError(&txn)
 		retryErr.Txn.Timestamp.Forward(entry.Timestamp)
@@ -1281,7 +1557,7 @@ func (r *Replica) getLeaseForGossip(ctx context.Context) (bool, error) {
 				err = nil
 			default:
 				// Any other error is worth being logged visibly.
-				log.Warningc(ctx, "could not acquire lease for range gossip: %s", e)
+				r.warningf("could not acquire lease for range gossip: %s", e)
 			}
 		}
 	}) {
@@ -1303,31 +1579,31 @@ func (r *Replica) maybeGossipFirstRange() error {
 
 	// Gossip the cluster ID from all replicas of the first range.
 	if log.V(1) {
-		log.Infoc(ctx, "gossiping cluster id %s from store %d, range %d", r.store.ClusterID(),
-			r.store.StoreID(), r.Desc().RangeID)
+		r.infof("gossiping cluster id %s", r.store.ClusterID())
 	}
 	if err := r.store.Gossip().AddInfo(gossip.KeyClusterID, []byte(r.store.ClusterID()), clusterIDGossipTTL); err != nil {
-		log.Errorc(ctx, "failed to gossip cluster ID: %s", err)
+		r.errorf("failed to gossip cluster ID: %s", err)
 	}
 	if ok, err := r.getLeaseForGossip(ctx); !ok || err != nil {
 		return err
 	}
 	if log.V(1) {
-		log.Infoc(ctx, "gossiping sentinel from store %d, range %d", r.store.StoreID(), desc.RangeID)
+		r.infof("gossiping sentinel")
 	}
 	if err := r.store.Gossip().AddInfo(gossip.KeySentinel, []byte(r.store.ClusterID()), clusterIDGossipTTL); err != nil {
-		log.Errorc(ctx, "failed to gossip sentinel: %s", err)
+		r.errorf("failed to gossip sentinel: %s", err)
 	}
 	if log.V(1) {
-		log.Infoc(ctx, "gossiping first range from store %d, range %d", r.store.StoreID(), desc.RangeID)
+		r.infof("gossiping first range")
 	}
 	if err := r.store.Gossip().AddInfoProto(gossip.KeyFirstRangeDescriptor, desc, configGossipTTL); err != nil {
-		log.Errorc(ctx, "failed to gossip first range metadata: %s", err)
+		r.errorf("failed to gossip first range metadata: %s", err)
 	}
 	return nil
 }
 
-// maybeGossipSystemConfig scans the entire SystemDB span and gossips it.
+// maybeGossipSystemConfig gossips the contents of the SystemDB span,
+// rescanning it only if it may have changed since the previous scan.
 // The first call is on NewReplica. Further calls come from the trigger
 // on an EndTransactionRequest.
 //
@@ -1352,11 +1628,10 @@ func (r *Replica) maybeGossipSystemConfigLocked() {
 		return
 	}
 
-	ctx := r.context()
 	// TODO(marc): check for bad split in the middle of the SystemDB span.
-	kvs, hash, err := r.loadSystemDBSpan()
+	kvs, hash, err := r.loadSystemDBSpanCachedLocked()
 	if err != nil {
-		log.Errorc(ctx, "could not load SystemDB span: %s", err)
+		r.errorf("could not load SystemDB span: %s", err)
 		return
 	}
 	if bytes.Equal(r.systemDBHash, hash) {
@@ -1364,12 +1639,12 @@ func (r *Replica) maybeGossipSystemConfigLocked() {
 	}
 
 	if log.V(1) {
-		log.Infoc(ctx, "gossiping system config from store %d, range %d", r.store.StoreID(), r.Desc().RangeID)
+		r.infof("gossiping system config")
 	}
 
 	cfg := &config.SystemConfig{Values: kvs}
 	if err := r.store.Gossip().AddInfoProto(gossip.KeySystemConfig, cfg, 0); err != nil {
-		log.Errorc(ctx, "failed to gossip system config: %s", err)
+		r.errorf("failed to gossip system config: %s", err)
 		return
 	}
 
@@ -1377,6 +1652,67 @@ func (r *Replica) maybeGossipSystemConfigLocked() {
 	r.systemDBHash = hash
 }
 
+// loadSystemDBSpanCachedLocked returns the contents of the SystemDB span
+// and their hash, scanning the span only if no write to it has been
+// applied since the previous scan.
+func (r *Replica) loadSystemDBSpanCachedLocked() ([]roachpb.KeyValue, []byte, error) {
+	if r.systemDBKVsHash != nil {
+		return r.systemDBKVs, r.systemDBKVsHash, nil
+	}
+	kvs, hash, err := r.loadSystemDBSpan()
+	if err != nil {
+		return nil, nil, err
+	}
+	r.systemDBKVs, r.systemDBKVsHash = kvs, hash
+	return kvs, hash, nil
+}
+
+// invalidateSystemDBCache discards the cached SystemDB span contents.
+func (r *Replica) invalidateSystemDBCache() {
+	r.Lock()
+	defer r.Unlock()
+	r.systemDBKVs, r.systemDBKVsHash = nil, nil
+}
+
+// invalidatesSystemDBCache returns whether the batch contains a write
+// which may touch the SystemDB span, either directly or by resolving
+// intents as part of an EndTransaction, or a split or merge which changes
+// the range's bounds.
+func invalidatesSystemDBCache(ba roachpb.BatchRequest) bool {
+	for _, union := range ba.Requests {
+		args := union.GetInner()
+		if roachpb.IsReadOnly(args) {
+			continue
+		}
+		if overlapsSystemDB(*args.Header()) {
+			return true
+		}
+		if et, ok := args.(*roachpb.EndTransactionRequest); ok {
+			if ct := et.InternalCommitTrigger; ct != nil &&
+				(ct.GetSplitTrigger() != nil || ct.GetMergeTrigger() != nil) {
+				return true
+			}
+			for _, span := range et.IntentSpans {
+				if overlapsSystemDB(span) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// overlapsSystemDB returns whether the span, which addresses a single key
+// if EndKey is empty, overlaps the SystemDB span.
+func overlapsSystemDB(span roachpb.Span) bool {
+	if len(span.EndKey) == 0 {
+		return bytes.Compare(span.Key, keys.SystemDBSpan.Key) >= 0 &&
+			bytes.Compare(span.Key, keys.SystemDBSpan.EndKey) < 0
+	}
+	return bytes.Compare(span.Key, keys.SystemDBSpan.EndKey) < 0 &&
+		bytes.Compare(span.EndKey, keys.SystemDBSpan.Key) > 0
+}
+
 func (r *Replica) handleSkippedIntents(intents []intentsWithArg) {
 	if len(intents) == 0 {
 		return
@@ -1396,11 +1732,11 @@ func (r *Replica) handleSkippedIntents(intents []intentsWithArg) {
 				Intents: item.intents,
 			}, r, args, h, roachpb.PUSH_TOUCH)
 			if wiErr, ok := err.(*roachpb.WriteIntentError); !ok || wiErr == nil || !wiErr.Resolved {
-				log.Warningc(ctx, "failed to push during intent resolution: %s", err)
+				r.warningf("failed to push during intent resolution: %s", err)
 				return
 			}
 			if err := r.resolveIntents(ctx, resolveIntents, true /* wait */, true /* poison */); err != nil {
-				log.Warningc(ctx, "failed to resolve intents: %s", err)
+				r.warningf("failed to resolve intents: %s", err)
 				return
 			}
 			// We successfully resolved the intents, so we're able to GC from
@@ -1429,7 +1765,7 @@ func (r *Replica) handleSkippedIntents(intents []intentsWithArg) {
 
 				ba.Add(&gcArgs)
 				if _, pErr := r.addWriteCmd(ctx, ba, nil /* nil */); pErr != nil {
-					log.Warningf("could not GC completed transaction: %s", pErr)
+					r.warningf("could not GC completed transaction: %s", pErr)
 				}
 			}
 		})
@@ -1502,7 +1838,7 @@ func newReplicaCorruptionError(err ...error) *replicaCorruptionError {
 // range, store, node or cluster with corresponding actions taken.
 func (r *Replica) maybeSetCorrupt(err error) error {
 	if cErr, ok := err.(*replicaCorruptionError); ok && cErr != nil {
-		log.Errorc(r.context(), "stalling replica due to: %s", cErr.error)
+		r.errorf("stalling replica due to: %s", cErr.error)
 		cErr.processed = true
 		return cErr
 	}
@@ -1574,7 +1910,7 @@ func (r *Replica) resolveIntents(ctx context.Context, intents []roachpb.Intent,
 		wg.Add(1)
 		if wait || !r.store.Stopper().RunAsyncTask(func() {
 			if err := action(); err != nil {
-				log.Warningf("unable to resolve local intents; %s", err)
+				r.warningf("unable to resolve local intents; %s", err)
 			}
 		}) {
 			// Still run the task when draining. Our caller already has a task and
@@ -1597,7 +1933,7 @@ func (r *Replica) resolveIntents(ctx context.Context, intents []roachpb.Intent,
 		}
 		if wait || !r.store.Stopper().RunAsyncTask(func() {
 			if err := action; err != nil {
-				log.Warningf("unable to resolve external intents: %s", err)
+				r.warningf("unable to resolve external intents: %s", err)
 			}
 		}) {
 			// As with local intents, try async to not keep the caller waiting, but
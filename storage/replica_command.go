@@ -21,8 +21,10 @@ package storage
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync/atomic"
 	"time"
 	"unsafe"
@@ -33,6 +35,8 @@ import (
 	"github.com/cockroachdb/cockroach/storage/engine"
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/log"
+	"github.com/cockroachdb/cockroach/util/retry"
+	"github.com/coreos/etcd/raft"
 	"github.com/gogo/protobuf/proto"
 )
 
@@ -60,6 +64,7 @@ func (r *Replica) executeCmd(batch engine.Engine, ms *engine.MVCCStats, h roachp
 		}
 	}
 
+	start := time.Now()
 	var reply roachpb.Response
 	var intents []roachpb.Intent
 	var err error
@@ -68,14 +73,26 @@ func (r *Replica) executeCmd(batch engine.Engine, ms *engine.MVCCStats, h roachp
 		var resp roachpb.GetResponse
 		resp, intents, err = r.Get(batch, h, *tArgs)
 		reply = &resp
+	case *roachpb.GetMultiRequest:
+		var resp roachpb.GetMultiResponse
+		resp, intents, err = r.GetMulti(batch, h, *tArgs)
+		reply = &resp
 	case *roachpb.PutRequest:
 		var resp roachpb.PutResponse
 		resp, err = r.Put(batch, ms, h, *tArgs)
 		reply = &resp
+	case *roachpb.WriteBatchRequest:
+		var resp roachpb.WriteBatchResponse
+		resp, err = r.WriteBatch(batch, ms, h, *tArgs)
+		reply = &resp
 	case *roachpb.ConditionalPutRequest:
 		var resp roachpb.ConditionalPutResponse
 		resp, err = r.ConditionalPut(batch, ms, h, *tArgs)
 		reply = &resp
+	case *roachpb.ConditionalPutBatchRequest:
+		var resp roachpb.ConditionalPutBatchResponse
+		resp, err = r.ConditionalPutBatch(batch, ms, h, *tArgs)
+		reply = &resp
 	case *roachpb.IncrementRequest:
 		var resp roachpb.IncrementResponse
 		resp, err = r.Increment(batch, ms, h, *tArgs)
@@ -88,6 +105,18 @@ func (r *Replica) executeCmd(batch engine.Engine, ms *engine.MVCCStats, h roachp
 		var resp roachpb.DeleteRangeResponse
 		resp, err = r.DeleteRange(batch, ms, h, *tArgs)
 		reply = &resp
+	case *roachpb.ContainsRangeRequest:
+		var resp roachpb.ContainsRangeResponse
+		resp, intents, err = r.ContainsRange(batch, h, *tArgs)
+		reply = &resp
+	case *roachpb.VerifyRequest:
+		var resp roachpb.VerifyResponse
+		resp, err = r.Verify(batch, h, *tArgs)
+		reply = &resp
+	case *roachpb.ScanVersionsRequest:
+		var resp roachpb.ScanVersionsResponse
+		resp, intents, err = r.ScanVersions(batch, h, *tArgs)
+		reply = &resp
 	case *roachpb.ScanRequest:
 		var resp roachpb.ScanResponse
 		resp, intents, err = r.Scan(batch, h, *tArgs)
@@ -108,6 +137,14 @@ func (r *Replica) executeCmd(batch engine.Engine, ms *engine.MVCCStats, h roachp
 		var resp roachpb.RangeLookupResponse
 		resp, intents, err = r.RangeLookup(batch, h, *tArgs)
 		reply = &resp
+	case *roachpb.RangeLookupMultiRequest:
+		var resp roachpb.RangeLookupMultiResponse
+		resp, intents, err = r.RangeLookupMulti(batch, h, *tArgs)
+		reply = &resp
+	case *roachpb.ComputeChecksumRequest:
+		var resp roachpb.ComputeChecksumResponse
+		resp, err = r.ComputeChecksum(batch, h, *tArgs)
+		reply = &resp
 	case *roachpb.HeartbeatTxnRequest:
 		var resp roachpb.HeartbeatTxnResponse
 		resp, err = r.HeartbeatTxn(batch, ms, h, *tArgs)
@@ -143,6 +180,9 @@ func (r *Replica) executeCmd(batch engine.Engine, ms *engine.MVCCStats, h roachp
 	default:
 		err = util.Errorf("unrecognized command %s", args.Method())
 	}
+	r.metrics.record(args.Method(), time.Since(start), err)
+	r.sampler.record(args.Header().Key,
+		err == nil && roachpb.IsTransactionWrite(args) && !roachpb.IsRange(args))
 
 	if log.V(2) {
 		log.Infof("executed %s command %+v: %+v, err=%s", args.Method(), args, reply, err)
@@ -186,22 +226,104 @@ func (r *Replica) Get(batch engine.Engine, h roachpb.Header, args roachpb.GetReq
 	return reply, intents, err
 }
 
-// Put sets the value for a specified key.
+// GetMulti returns the values for the specified keys, in the order in
+// which they were requested. Keys outside of the request span, which
+// are served by other ranges, are skipped and yield empty values.
+func (r *Replica) GetMulti(batch engine.Engine, h roachpb.Header, args roachpb.GetMultiRequest) (roachpb.GetMultiResponse, []roachpb.Intent, error) {
+	var reply roachpb.GetMultiResponse
+	var intents []roachpb.Intent
+
+	reply.Values = make([]roachpb.Value, len(args.Keys))
+	for i, key := range args.Keys {
+		if !args.Span.ContainsKey(key) {
+			continue
+		}
+		val, keyIntents, err := engine.MVCCGet(batch, key, h.Timestamp, h.ReadConsistency == roachpb.CONSISTENT, h.Txn)
+		intents = append(intents, keyIntents...)
+		if err != nil {
+			return reply, intents, err
+		}
+		if val != nil {
+			reply.Values[i] = *val
+		}
+	}
+	return reply, intents, nil
+}
+
+// Put sets the value for a specified key. A non-zero TTL causes the value
+// to expire that long after the write timestamp.
 func (r *Replica) Put(batch engine.Engine, ms *engine.MVCCStats, h roachpb.Header, args roachpb.PutRequest) (roachpb.PutResponse, error) {
 	var reply roachpb.PutResponse
 
+	if args.TTL > 0 {
+		// The expiration is part of the checksummed contents, so the checksum
+		// supplied by the client must be recomputed.
+		args.Value.SetExpiration(h.Timestamp.WallTime + int64(args.TTL))
+		args.Value.InitChecksum(args.Key)
+	}
 	return reply, engine.MVCCPut(batch, ms, args.Key, h.Timestamp, args.Value, h.Txn)
 }
 
+// WriteBatch puts the supplied key/value pairs. The pairs are written to
+// the command's batch as a single unit, so they are proposed and applied
+// as one Raft command. Pairs outside of the request span, which the
+// DistSender truncates to this range, are served by other ranges and
+// skipped; the DistSender rejects pairs outside of the original span.
+func (r *Replica) WriteBatch(batch engine.Engine, ms *engine.MVCCStats, h roachpb.Header, args roachpb.WriteBatchRequest) (roachpb.WriteBatchResponse, error) {
+	var reply roachpb.WriteBatchResponse
+
+	var delta engine.MVCCStats
+	for _, kv := range args.Pairs {
+		if !args.Span.ContainsKey(kv.Key) {
+			continue
+		}
+		if err := engine.MVCCPut(batch, &delta, kv.Key, h.Timestamp, kv.Value, h.Txn); err != nil {
+			return reply, err
+		}
+	}
+	ms.Add(&delta)
+	return reply, nil
+}
+
 // ConditionalPut sets the value for a specified key only if
-// the expected value matches. If not, the return value contains
-// the actual value.
+// the expected value matches, or, when args.ExpTimestamp is set, only if
+// the existing value was written at that timestamp. If not, the return
+// value contains the actual value.
 func (r *Replica) ConditionalPut(batch engine.Engine, ms *engine.MVCCStats, h roachpb.Header, args roachpb.ConditionalPutRequest) (roachpb.ConditionalPutResponse, error) {
 	var reply roachpb.ConditionalPutResponse
 
+	if args.ExpTimestamp != nil {
+		return reply, engine.MVCCConditionalPutTimestamp(batch, ms, args.Key, h.Timestamp, args.Value, *args.ExpTimestamp, h.Txn)
+	}
 	return reply, engine.MVCCConditionalPut(batch, ms, args.Key, h.Timestamp, args.Value, args.ExpValue, h.Txn)
 }
 
+// ConditionalPutBatch applies the supplied conditional puts as a single
+// unit. If any condition fails, the returned ConditionFailedError names
+// the offending key; the failed command's batch is then discarded by
+// applyRaftCommandInBatch, so none of the puts take effect. Puts outside
+// of the request span, which the DistSender truncates to this range, are
+// served by other ranges and skipped; the DistSender rejects puts outside
+// of the original span.
+func (r *Replica) ConditionalPutBatch(batch engine.Engine, ms *engine.MVCCStats, h roachpb.Header, args roachpb.ConditionalPutBatchRequest) (roachpb.ConditionalPutBatchResponse, error) {
+	var reply roachpb.ConditionalPutBatchResponse
+
+	var delta engine.MVCCStats
+	for _, put := range args.Puts {
+		if !args.Span.ContainsKey(put.Key) {
+			continue
+		}
+		if _, err := r.ConditionalPut(batch, &delta, h, put); err != nil {
+			if cErr, ok := err.(*roachpb.ConditionFailedError); ok {
+				cErr.Key = put.Key
+			}
+			return reply, err
+		}
+	}
+	ms.Add(&delta)
+	return reply, nil
+}
+
 // Increment increments the value (interpreted as varint64 encoded) and
 // returns the newly incremented value (encoded as varint64). If no value
 // exists for the key, zero is incremented.
@@ -221,21 +343,303 @@ func (r *Replica) Delete(batch engine.Engine, ms *engine.MVCCStats, h roachpb.He
 }
 
 // DeleteRange deletes the range of key/value pairs specified by
-// start and end keys.
+// start and end keys. If a predicate is supplied, only keys whose
+// current value matches it are deleted and counted.
 func (r *Replica) DeleteRange(batch engine.Engine, ms *engine.MVCCStats, h roachpb.Header, args roachpb.DeleteRangeRequest) (roachpb.DeleteRangeResponse, error) {
 	var reply roachpb.DeleteRangeResponse
 
-	numDel, err := engine.MVCCDeleteRange(batch, ms, args.Key, args.EndKey, args.MaxEntriesToDelete, h.Timestamp, h.Txn)
+	var match func(roachpb.Value) bool
+	if args.Predicate != nil {
+		match = args.Predicate.Matches
+	}
+	numDel, keys, err := engine.MVCCDeleteRangeIf(batch, ms, args.Key, args.EndKey, args.MaxEntriesToDelete, h.Timestamp, h.Txn, args.ReturnKeys, match)
 	reply.NumDeleted = numDel
+	reply.Keys = keys
 	return reply, err
 }
 
 // Scan scans the key range specified by start key through end key in ascending
-// order up to some maximum number of results.
+// order up to some maximum number of results. If a prefix or suffix is
+// specified, only rows with matching keys are returned. If a since
+// timestamp is specified, only keys written or deleted after it are
+// returned. A scan which exceeds the store's time budget returns the
+// rows found so far along with a resume key.
 func (r *Replica) Scan(batch engine.Engine, h roachpb.Header, args roachpb.ScanRequest) (roachpb.ScanResponse, []roachpb.Intent, error) {
 	var reply roachpb.ScanResponse
 
-	rows, intents, err := engine.MVCCScan(batch, args.Key, args.EndKey, args.MaxResults, h.Timestamp, h.ReadConsistency == roachpb.CONSISTENT, h.Txn)
+	resumeKey, reason, intents, err := r.scanStream(batch, h, args, r.store.ctx.ScanTimeBudget, func(kv roachpb.KeyValue) error {
+		reply.Rows = append(reply.Rows, kv)
+		return nil
+	})
+	if err != nil {
+		return roachpb.ScanResponse{}, nil, err
+	}
+	reply.ResumeKey = resumeKey
+	reply.ResumeReason = reason
+	reply.TimedOut = reason == roachpb.TIME_BUDGET
+	return reply, intents, nil
+}
+
+// ScanStream is like Scan, but instead of accumulating the rows it invokes
+// f for each of them as the engine iterator advances, so that large scans
+// don't hold the entire result set in memory. If a limit was reached, the
+// key following the last row is returned, from which the scan may be
+// resumed. An error returned by f ends the scan and is passed on to the
+// caller.
+func (r *Replica) ScanStream(batch engine.Engine, h roachpb.Header, args roachpb.ScanRequest,
+	f func(roachpb.KeyValue) error) (roachpb.Key, []roachpb.Intent, error) {
+	resumeKey, _, intents, err := r.scanStream(batch, h, args, 0, f)
+	return resumeKey, intents, err
+}
+
+// scanStream implements ScanStream and additionally reports why the scan
+// stopped. A scan stops as soon as a limit is reached, without looking
+// further for rows, so the resume key may lead to an empty scan. If budget
+// is positive and the scan runs for longer than that, it stops before the
+// next key it visits and returns that key as the resume key. A scan which exhausts its span up to the end of the
+// range (other than the last range) reports RANGE_BOUNDARY, since the span
+// may continue on the next range.
+func (r *Replica) scanStream(batch engine.Engine, h roachpb.Header, args roachpb.ScanRequest, budget time.Duration,
+	f func(roachpb.KeyValue) error) (resumeKey roachpb.Key, reason roachpb.ScanResumeReason, intents []roachpb.Intent, err error) {
+	key, endKey := args.Key, args.EndKey
+	if len(args.Prefix) > 0 {
+		// Keys sharing a prefix are contiguous, so the prefix simply narrows
+		// the span to be scanned.
+		if bytes.Compare(key, args.Prefix) < 0 {
+			key = args.Prefix
+		}
+		if prefixEnd := args.Prefix.PrefixEnd(); bytes.Compare(prefixEnd, endKey) < 0 {
+			endKey = prefixEnd
+		}
+		if bytes.Compare(key, endKey) >= 0 {
+			return nil, roachpb.COMPLETE, nil, nil
+		}
+	}
+
+	// A changes-only scan reports deletions as well; keys which were not
+	// written since the cutoff are still visited, but filtered out below.
+	changesOnly := !args.Since.Equal(roachpb.ZeroTimestamp)
+	iterate := engine.MVCCIterate
+	if args.ReturnTombstones || changesOnly {
+		iterate = engine.MVCCIterateWithTombstones
+	}
+	var deadline int64
+	if budget > 0 {
+		deadline = r.store.Clock().PhysicalNow() + budget.Nanoseconds()
+	}
+	var numVisited, numRows, numBytes int64
+	intents, err = iterate(batch, key, endKey, h.Timestamp, h.ReadConsistency == roachpb.CONSISTENT, h.Txn, false, /* !reverse */
+		func(kv roachpb.KeyValue) (bool, error) {
+			// The budget is checked for every key, including those filtered
+			// out below, so that selective scans time out as well. The first
+			// key is always visited so that a resumed scan makes progress.
+			if deadline != 0 && numVisited > 0 && r.store.Clock().PhysicalNow() >= deadline {
+				resumeKey, reason = kv.Key, roachpb.TIME_BUDGET
+				return true, nil
+			}
+			numVisited++
+			if !bytes.HasSuffix(kv.Key, args.Suffix) {
+				return false, nil
+			}
+			if changesOnly && !args.Since.Less(kv.Value.Timestamp) {
+				return false, nil
+			}
+			if err := f(kv); err != nil {
+				return true, err
+			}
+			numRows++
+			numBytes += int64(len(kv.Value.RawBytes))
+			// Whichever of the limits is reached first ends the scan.
+			switch {
+			case args.MaxResults != 0 && args.MaxResults == numRows:
+				reason = roachpb.MAX_RESULTS
+			case args.MaxBytes != 0 && numBytes >= args.MaxBytes:
+				reason = roachpb.MAX_BYTES
+			default:
+				return false, nil
+			}
+			resumeKey = kv.Key.Next()
+			return true, nil
+		})
+	if err != nil {
+		return nil, roachpb.COMPLETE, nil, err
+	}
+	if resumeKey == nil {
+		if rangeEnd := r.Desc().EndKey; bytes.Equal(endKey, rangeEnd) && !rangeEnd.Equal(roachpb.RKeyMax) {
+			reason = roachpb.RANGE_BOUNDARY
+		}
+	}
+	return resumeKey, reason, intents, nil
+}
+
+// ContainsRange reports whether any key exists in the request span. The
+// iteration stops at the first live key and no rows are returned.
+func (r *Replica) ContainsRange(batch engine.Engine, h roachpb.Header, args roachpb.ContainsRangeRequest) (roachpb.ContainsRangeResponse, []roachpb.Intent, error) {
+	var reply roachpb.ContainsRangeResponse
+
+	intents, err := engine.MVCCIterate(batch, args.Key, args.EndKey, h.Timestamp,
+		h.ReadConsistency == roachpb.CONSISTENT, h.Txn, false, /* !reverse */
+		func(roachpb.KeyValue) (bool, error) {
+			reply.Exists = true
+			return true, nil
+		})
+	return reply, intents, err
+}
+
+// Verify checks the integrity of all data held by the range: every key
+// must sort after its predecessor, every value must match its checksum and
+// the maintained MVCC stats must agree with a recount of the data. The
+// problems found are returned as discrepancies rather than as an error.
+// Stats which depend on the time of computation (ages, last update) and
+// system-local stats are not compared.
+//
+// The data is examined and the maintained stats are read from a single
+// engine snapshot rather than the supplied engine, since concurrent writes
+// would otherwise show up as spurious discrepancies.
+func (r *Replica) Verify(batch engine.Engine, h roachpb.Header, args roachpb.VerifyRequest) (roachpb.VerifyResponse, error) {
+	var reply roachpb.VerifyResponse
+	desc := r.Desc()
+	snap := r.store.NewSnapshot()
+	defer snap.Close()
+
+	iter := newReplicaDataIterator(desc, snap)
+	defer iter.Close()
+	var prev engine.MVCCKey
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if prev.Key != nil && !prev.Less(key) {
+			reply.Discrepancies = append(reply.Discrepancies,
+				fmt.Sprintf("key %s out of order after %s", key, prev))
+		}
+		prev = key
+
+		value := roachpb.Value{RawBytes: iter.Value()}
+		if !key.IsValue() {
+			var meta engine.MVCCMetadata
+			if err := iter.ValueProto(&meta); err != nil {
+				reply.Discrepancies = append(reply.Discrepancies,
+					fmt.Sprintf("key %s: unable to decode metadata: %s", key, err))
+				continue
+			}
+			if meta.RawBytes == nil {
+				continue
+			}
+			value.RawBytes = meta.RawBytes
+		}
+		if err := value.Verify(key.Key); err != nil {
+			reply.Discrepancies = append(reply.Discrepancies, err.Error())
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return reply, err
+	}
+
+	var ms engine.MVCCStats
+	if err := engine.MVCCGetRangeStats(snap, desc.RangeID, &ms); err != nil {
+		return reply, err
+	}
+	computed, err := r.computeStats(desc, snap, ms.LastUpdateNanos)
+	if err != nil {
+		return reply, err
+	}
+	for _, stat := range []struct {
+		name                string
+		maintained, counted int64
+	}{
+		{"live bytes", ms.LiveBytes, computed.LiveBytes},
+		{"key bytes", ms.KeyBytes, computed.KeyBytes},
+		{"val bytes", ms.ValBytes, computed.ValBytes},
+		{"intent bytes", ms.IntentBytes, computed.IntentBytes},
+		{"live count", ms.LiveCount, computed.LiveCount},
+		{"key count", ms.KeyCount, computed.KeyCount},
+		{"val count", ms.ValCount, computed.ValCount},
+		{"intent count", ms.IntentCount, computed.IntentCount},
+	} {
+		if stat.maintained != stat.counted {
+			reply.Discrepancies = append(reply.Discrepancies,
+				fmt.Sprintf("%s: maintained %d, counted %d", stat.name, stat.maintained, stat.counted))
+		}
+	}
+	return reply, nil
+}
+
+// ComputeChecksum returns a checksum of the range's replicated data along
+// with the raft applied index it was computed at. The request must be
+// alone in its batch, so it is never evaluated as part of a write batch
+// during raft application.
+//
+// The applied index and the data are read from a single engine snapshot,
+// since raft commands applied concurrently would otherwise make the
+// checksum disagree with the index. Like any read, the command is served
+// by the holder of the leader lease and reports the checksum of that
+// replica only; the checksums of followers are not collected.
+func (r *Replica) ComputeChecksum(batch engine.Engine, h roachpb.Header, args roachpb.ComputeChecksumRequest) (roachpb.ComputeChecksumResponse, error) {
+	var reply roachpb.ComputeChecksumResponse
+	snap := r.store.NewSnapshot()
+	defer snap.Close()
+	var err error
+	reply.Checksum, reply.AppliedIndex, err = r.checksum(snap)
+	return reply, err
+}
+
+// checksum computes the SHA256 of the replica's data in the supplied
+// engine and returns it along with the applied index. Raft log entries and
+// the raft state kept by each replica individually (hard state, last
+// index, applied index and tombstone) are left out, since they legitimately
+// differ between replicas holding identical data.
+func (r *Replica) checksum(eng engine.Engine) ([]byte, uint64, error) {
+	appliedIndex, err := r.loadAppliedIndex(eng)
+	if err != nil {
+		return nil, 0, err
+	}
+	desc := r.Desc()
+	logPrefix := keys.RaftLogPrefix(desc.RangeID)
+	skip := []roachpb.Key{
+		keys.RaftHardStateKey(desc.RangeID),
+		keys.RaftLastIndexKey(desc.RangeID),
+		keys.RaftAppliedIndexKey(desc.RangeID),
+		keys.RaftTombstoneKey(desc.RangeID),
+	}
+
+	sha := sha256.New()
+	var buf []byte
+	iter := newReplicaDataIterator(desc, eng)
+	defer iter.Close()
+outer:
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if bytes.HasPrefix(key.Key, logPrefix) {
+			continue
+		}
+		for _, k := range skip {
+			if key.Key.Equal(k) {
+				continue outer
+			}
+		}
+		buf = appendChecksumKV(buf[:0], key.Key, key.Timestamp, iter.Value())
+		// Writes to a hash never fail.
+		_, _ = sha.Write(buf)
+	}
+	return sha.Sum(nil), appliedIndex, nil
+}
+
+// ScanVersions returns the history of the keys in the request span: every
+// version, including deletions, written at a timestamp between
+// args.MinTimestamp and args.MaxTimestamp. A zero MaxTimestamp defaults
+// to the read timestamp, which MaxTimestamp may not exceed; otherwise the
+// timestamp cache couldn't prevent new versions from being written into
+// the window after it has been read.
+func (r *Replica) ScanVersions(batch engine.Engine, h roachpb.Header, args roachpb.ScanVersionsRequest) (roachpb.ScanVersionsResponse, []roachpb.Intent, error) {
+	var reply roachpb.ScanVersionsResponse
+
+	maxTS := args.MaxTimestamp
+	if maxTS.Equal(roachpb.ZeroTimestamp) {
+		maxTS = h.Timestamp
+	} else if h.Timestamp.Less(maxTS) {
+		return reply, nil, util.Errorf("max timestamp %s exceeds read timestamp %s", maxTS, h.Timestamp)
+	}
+	rows, intents, err := engine.MVCCScanVersions(batch, args.Key, args.EndKey, args.MinTimestamp, maxTS,
+		h.ReadConsistency == roachpb.CONSISTENT)
 	reply.Rows = rows
 	return reply, intents, err
 }
@@ -253,7 +657,7 @@ func (r *Replica) ReverseScan(batch engine.Engine, h roachpb.Header, args roachp
 
 func verifyTransaction(h roachpb.Header, args roachpb.Request) error {
 	if h.Txn == nil {
-		return util.Errorf("no transaction specified to HeartbeatTxn")
+		return util.Errorf("no transaction specified to %s", args.Method())
 	}
 	if !bytes.Equal(args.Header().Key, h.Txn.Key) {
 		return util.Errorf("request key %s should match txn key %s", args.Header().Key, h.Txn.Key)
@@ -584,7 +988,11 @@ func intersectSpan(span roachpb.Span, desc roachpb.RangeDescriptor) (middle *roa
 // the range to the respective meta prefix.
 //
 // Lookups for range metadata keys usually want to read inconsistently, but
-// some callers need a consistent result; both are supported.
+// some callers need a consistent result; both are supported. The header's
+// ReadConsistency selects the mode: an INCONSISTENT lookup bypasses the
+// leader lease check and the command queue, so it may return a stale
+// descriptor; callers must be prepared to retry once the addressed range
+// rejects their request with a RangeKeyMismatchError.
 //
 // This method has an important optimization in the inconsistent case: instead
 // of just returning the request RangeDescriptor, it also returns a slice of
@@ -774,6 +1182,46 @@ func (r *Replica) RangeLookup(batch engine.Engine, h roachpb.Header, args roachp
 	}
 
 	reply.Ranges = rds
+	if cached := args.CachedDescriptor; cached != nil {
+		for i := range rds {
+			if rds[i].RangeID != cached.RangeID && rds[i].ContainsKeyRange(cached.StartKey, cached.EndKey) {
+				reply.Merged = true
+				break
+			}
+		}
+	}
+	return reply, intents, nil
+}
+
+// RangeLookupMulti looks up the range descriptors addressed by each of the
+// requested range metadata keys, applying the same validation as
+// RangeLookup to every key. Each descriptor is returned only once, no
+// matter how many keys address it. Keys outside of the request span,
+// which are served by other ranges, are skipped.
+func (r *Replica) RangeLookupMulti(batch engine.Engine, h roachpb.Header, args roachpb.RangeLookupMultiRequest) (roachpb.RangeLookupMultiResponse, []roachpb.Intent, error) {
+	var reply roachpb.RangeLookupMultiResponse
+	var intents []roachpb.Intent
+
+	seen := map[roachpb.RangeID]struct{}{}
+	for _, key := range args.Keys {
+		if bytes.Compare(key, args.Key) < 0 || bytes.Compare(key, args.EndKey) >= 0 {
+			continue
+		}
+		lookupReply, keyIntents, err := r.RangeLookup(batch, h, roachpb.RangeLookupRequest{
+			Span:      roachpb.Span{Key: key},
+			MaxRanges: 1,
+		})
+		intents = append(intents, keyIntents...)
+		if err != nil {
+			return reply, intents, err
+		}
+		for _, desc := range lookupReply.Ranges {
+			if _, ok := seen[desc.RangeID]; !ok {
+				seen[desc.RangeID] = struct{}{}
+				reply.Ranges = append(reply.Ranges, desc)
+			}
+		}
+	}
 	return reply, intents, nil
 }
 
@@ -1182,7 +1630,10 @@ func (r *Replica) LeaderLease(batch engine.Engine, ms *engine.MVCCStats, h roach
 		}
 		// Note that the lease expiration can be shortened by the holder.
 		// This could be used to effect a faster lease handoff.
-	} else if effectiveStart.Less(prevLease.Expiration) {
+	} else if effectiveStart.Less(prevLease.Expiration) && !args.Transfer {
+		// A transfer, proposed by the previous holder, may cut its lease
+		// short. The new holder's timestamp cache still accounts for the
+		// full previous lease (see below).
 		rErr.Message = "requested lease overlaps previous lease"
 		return reply, rErr
 	}
@@ -1215,6 +1666,65 @@ func (r *Replica) LeaderLease(batch engine.Engine, ms *engine.MVCCStats, h roach
 	return reply, nil
 }
 
+// SuggestSplitKey returns a key at which to split the range so that both
+// the data and the recent request load are divided as evenly as possible
+// between the two halves. Without enough sampled requests to estimate the
+// load distribution, the key splits the range's data in half. The split
+// queue uses it to split ranges which have grown too large.
+func (r *Replica) SuggestSplitKey() (roachpb.Key, error) {
+	snap := r.store.NewSnapshot()
+	defer snap.Close()
+	desc := r.Desc()
+	var loadKeys []roachpb.Key
+	for _, key := range r.sampler.loadSample() {
+		if addr := keys.Addr(key); desc.ContainsKey(addr) {
+			loadKeys = append(loadKeys, addr.AsRawKey())
+		}
+	}
+	if len(loadKeys) < minKeySamples {
+		return engine.MVCCFindSplitKey(snap, desc.RangeID, desc.StartKey, desc.EndKey)
+	}
+	sort.Sort(keySlice(loadKeys))
+	loadBefore := func(key roachpb.Key) float64 {
+		i := sort.Search(len(loadKeys), func(i int) bool {
+			return bytes.Compare(loadKeys[i], key) >= 0
+		})
+		return float64(i) / float64(len(loadKeys))
+	}
+	return engine.MVCCFindWeightedSplitKey(snap, desc.RangeID, desc.StartKey, desc.EndKey, loadBefore)
+}
+
+// ApproximateMedianKey returns an estimate of the median key of the data
+// in the range, computed from the sample of written keys without reading
+// the engine. Deleted and overwritten keys remain in the sample, so the
+// estimate is only as good as the write pattern is uniform. The sample is
+// reset whenever the range's data is replaced wholesale, by a split,
+// merge or snapshot; until enough keys have been written since, it falls
+// back to the key that splits the range's data in half by size.
+func (r *Replica) ApproximateMedianKey() (roachpb.Key, error) {
+	desc := r.Desc()
+	var sampled []roachpb.Key
+	for _, key := range r.sampler.writeSample() {
+		if addr := keys.Addr(key); desc.ContainsKey(addr) && engine.IsValidSplitKey(addr.AsRawKey()) {
+			sampled = append(sampled, addr.AsRawKey())
+		}
+	}
+	if len(sampled) < minKeySamples {
+		snap := r.store.NewSnapshot()
+		defer snap.Close()
+		return engine.MVCCFindSplitKey(snap, desc.RangeID, desc.StartKey, desc.EndKey)
+	}
+	sort.Sort(keySlice(sampled))
+	return sampled[len(sampled)/2], nil
+}
+
+// keySlice implements sort.Interface.
+type keySlice []roachpb.Key
+
+func (s keySlice) Len() int           { return len(s) }
+func (s keySlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s keySlice) Less(i, j int) bool { return bytes.Compare(s[i], s[j]) < 0 }
+
 // AdminSplit divides the range into into two ranges, using either
 // args.SplitKey (if provided) or an internally computed key that aims to
 // roughly equipartition the range by size. The split is done inside of
@@ -1273,9 +1783,12 @@ func (r *Replica) AdminSplit(args roachpb.AdminSplitRequest, desc *roachpb.Range
 		return reply, util.Errorf("unable to allocate new range descriptor: %s", err)
 	}
 
-	// Init updated version of existing range descriptor.
+	// Init updated version of existing range descriptor. Both halves of the
+	// split share the new generation.
 	updatedDesc := *desc
 	updatedDesc.EndKey = splitKey
+	updatedDesc.Generation++
+	newDesc.Generation = updatedDesc.Generation
 
 	log.Infof("initiating a split of %s at key %s", r, splitKey)
 
@@ -1493,7 +2006,12 @@ func (r *Replica) AdminMerge(args roachpb.AdminMergeRequest, origLeftDesc *roach
 			return reply, util.Errorf("ranges not collocated")
 		}
 
-		updatedLeftDesc.EndKey = rightRng.Desc().EndKey
+		rightDesc := rightRng.Desc()
+		updatedLeftDesc.EndKey = rightDesc.EndKey
+		if rightDesc.Generation > updatedLeftDesc.Generation {
+			updatedLeftDesc.Generation = rightDesc.Generation
+		}
+		updatedLeftDesc.Generation++
 		log.Infof("initiating a merge of %s into %s", rightRng, r)
 	}
 
@@ -1591,7 +2109,7 @@ func (r *Replica) mergeTrigger(batch engine.Engine, merge *roachpb.MergeTrigger)
 
 	// Remove the subsumed range's metadata.
 	localRangeKeyPrefix := keys.MakeRangeIDPrefix(merge.SubsumedRangeID)
-	if _, err := engine.MVCCDeleteRange(batch, nil, localRangeKeyPrefix, localRangeKeyPrefix.PrefixEnd(), 0, roachpb.ZeroTimestamp, nil); err != nil {
+	if _, _, err := engine.MVCCDeleteRange(batch, nil, localRangeKeyPrefix, localRangeKeyPrefix.PrefixEnd(), 0, roachpb.ZeroTimestamp, nil, false); err != nil {
 		return util.Errorf("cannot remove range metadata %s", err)
 	}
 
@@ -1627,6 +2145,7 @@ func (r *Replica) changeReplicasTrigger(batch engine.Engine, change *roachpb.Cha
 	cpy := *r.Desc()
 	cpy.Replicas = change.UpdatedReplicas
 	cpy.NextReplicaID = change.NextReplicaID
+	cpy.Generation = change.Generation
 	if err := r.setDesc(&cpy); err != nil {
 		return err
 	}
@@ -1662,6 +2181,7 @@ func (r *Replica) ChangeReplicas(changeType roachpb.ReplicaChangeType, replica r
 	// Validate the request and prepare the new descriptor.
 	updatedDesc := *desc
 	updatedDesc.Replicas = append([]roachpb.ReplicaDescriptor(nil), desc.Replicas...)
+	updatedDesc.Generation++
 	found := -1       // tracks NodeID && StoreID
 	nodeUsed := false // tracks NodeID only
 	for i, existingRep := range desc.Replicas {
@@ -1677,6 +2197,7 @@ func (r *Replica) ChangeReplicas(changeType roachpb.ReplicaChangeType, replica r
 		// If the replica exists on the remote node, no matter in which store,
 		// abort the replica add.
 		if nodeUsed {
+			r.Unlock()
 			return util.Errorf("adding replica %v which is already present in range %d",
 				replica, desc.RangeID)
 		}
@@ -1691,9 +2212,16 @@ func (r *Replica) ChangeReplicas(changeType roachpb.ReplicaChangeType, replica r
 		// If that exact node-store combination does not have the replica,
 		// abort the removal.
 		if found == -1 {
+			r.Unlock()
 			return util.Errorf("removing replica %v which is not present in range %d",
 				replica, desc.RangeID)
 		}
+		// A range without replicas could never be recovered.
+		if len(desc.Replicas) == 1 {
+			r.Unlock()
+			return util.Errorf("removing replica %v which is the last replica of range %d",
+				replica, desc.RangeID)
+		}
 		updatedDesc.Replicas[found] = updatedDesc.Replicas[len(updatedDesc.Replicas)-1]
 		updatedDesc.Replicas = updatedDesc.Replicas[:len(updatedDesc.Replicas)-1]
 	}
@@ -1725,6 +2253,7 @@ func (r *Replica) ChangeReplicas(changeType roachpb.ReplicaChangeType, replica r
 					Replica:         replica,
 					UpdatedReplicas: updatedDesc.Replicas,
 					NextReplicaID:   updatedDesc.NextReplicaID,
+					Generation:      updatedDesc.Generation,
 				},
 			},
 		})
@@ -1737,6 +2266,58 @@ func (r *Replica) ChangeReplicas(changeType roachpb.ReplicaChangeType, replica r
 	return nil
 }
 
+// Rebalance moves the range's replica on from.StoreID to the store
+// described by to. The new replica is added first and the old one is only
+// removed once raft reports the new replica as caught up with the leader,
+// so the range never drops below its quorum during the move; raft sends
+// the new replica a snapshot as needed. If the new replica doesn't catch
+// up within the store's RebalanceRetryOptions, it is removed again and
+// the source replica is left in place. Rebalance must be invoked on the
+// raft leader, which is the only replica tracking follower progress.
+func (r *Replica) Rebalance(from, to roachpb.ReplicaDescriptor) error {
+	desc := r.Desc()
+	if status := r.store.RaftStatus(desc.RangeID); status == nil || status.RaftState != raft.StateLeader {
+		return util.Errorf("cannot rebalance range %d from a replica which is not the raft leader", desc.RangeID)
+	}
+	if _, rd := desc.FindReplica(from.StoreID); rd == nil {
+		return util.Errorf("cannot rebalance range %d away from store %d which holds no replica of it",
+			desc.RangeID, from.StoreID)
+	}
+	if err := r.ChangeReplicas(roachpb.ADD_REPLICA, to, desc); err != nil {
+		return err
+	}
+	_, rd := r.Desc().FindReplica(to.StoreID)
+	if rd == nil {
+		return util.Errorf("replica on store %d missing from range %d after being added", to.StoreID, desc.RangeID)
+	}
+	added := *rd
+	if err := r.waitForReplicaCatchUp(added.ReplicaID); err != nil {
+		if rbErr := r.ChangeReplicas(roachpb.REMOVE_REPLICA, added, r.Desc()); rbErr != nil {
+			return util.Errorf("%s; removing the added replica failed: %s", err, rbErr)
+		}
+		return err
+	}
+	return r.ChangeReplicas(roachpb.REMOVE_REPLICA, from, r.Desc())
+}
+
+// waitForReplicaCatchUp waits until the raft leader's progress for the
+// given replica has reached the leader's applied index.
+func (r *Replica) waitForReplicaCatchUp(replicaID roachpb.ReplicaID) error {
+	rangeID := r.Desc().RangeID
+	opts := r.store.ctx.RebalanceRetryOptions
+	opts.Closer = r.store.stopper.ShouldStop()
+	for retryer := retry.Start(opts); retryer.Next(); {
+		status := r.store.RaftStatus(rangeID)
+		if status == nil {
+			return util.Errorf("the raft group doesn't exist for range %d", rangeID)
+		}
+		if progress, ok := status.Progress[uint64(replicaID)]; ok && progress.Match >= status.Applied {
+			return nil
+		}
+	}
+	return util.Errorf("replica %d of range %d failed to catch up", replicaID, rangeID)
+}
+
 func (r *Replica) clearPendingChangeReplicas() {
 	r.Lock()
 	r.pendingReplica.value = roachpb.ReplicaDescriptor{}
@@ -0,0 +1,205 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/roachpb"
+)
+
+// numMethods is the number of distinct roachpb methods tracked by
+// ReplicaMetrics.
+const numMethods = int(roachpb.Batch) + 1
+
+// LatencyBuckets are the inclusive upper bounds of the buckets of the
+// latency histogram in ReplicaMetrics. Commands slower than the last
+// bound are counted in an additional overflow bucket.
+var LatencyBuckets = [...]time.Duration{
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+}
+
+// ReplicaMetrics is a point-in-time copy of the operation counters
+// maintained by a replica. Counts and errors are indexed by
+// roachpb.Method; Latency is indexed by the bucket in LatencyBuckets,
+// with the final entry holding commands exceeding the last bound.
+type ReplicaMetrics struct {
+	Counts  [numMethods]int64
+	Errors  [numMethods]int64
+	Latency [len(LatencyBuckets) + 1]int64
+	// PendingRaftCommands is the number of commands proposed to raft
+	// which had not yet been applied when the snapshot was taken.
+	PendingRaftCommands int64
+}
+
+// Count returns the number of commands executed for the given method.
+func (m ReplicaMetrics) Count(method roachpb.Method) int64 {
+	return m.Counts[method]
+}
+
+// ErrorCount returns the number of commands for the given method which
+// returned an error.
+func (m ReplicaMetrics) ErrorCount(method roachpb.Method) int64 {
+	return m.Errors[method]
+}
+
+// Add accumulates the counters from o into m. It is used to aggregate
+// the metrics of several replicas.
+func (m *ReplicaMetrics) Add(o ReplicaMetrics) {
+	for i := range m.Counts {
+		m.Counts[i] += o.Counts[i]
+		m.Errors[i] += o.Errors[i]
+	}
+	m.PendingRaftCommands += o.PendingRaftCommands
+	for i := range m.Latency {
+		m.Latency[i] += o.Latency[i]
+	}
+}
+
+// replicaMetrics holds the live counters of a replica. All fields are
+// updated atomically so that recording a command never takes a lock.
+type replicaMetrics struct {
+	counts  [numMethods]int64
+	errors  [numMethods]int64
+	latency [len(LatencyBuckets) + 1]int64
+}
+
+// record accounts for a single command of the given method which took
+// duration d to execute and returned err.
+func (m *replicaMetrics) record(method roachpb.Method, d time.Duration, err error) {
+	if int(method) < 0 || int(method) >= numMethods {
+		return
+	}
+	atomic.AddInt64(&m.counts[method], 1)
+	if err != nil {
+		atomic.AddInt64(&m.errors[method], 1)
+	}
+	bucket := len(LatencyBuckets)
+	for i, bound := range LatencyBuckets {
+		if d <= bound {
+			bucket = i
+			break
+		}
+	}
+	atomic.AddInt64(&m.latency[bucket], 1)
+}
+
+// snapshot returns a copy of the current counter values.
+func (m *replicaMetrics) snapshot() ReplicaMetrics {
+	var s ReplicaMetrics
+	for i := range m.counts {
+		s.Counts[i] = atomic.LoadInt64(&m.counts[i])
+		s.Errors[i] = atomic.LoadInt64(&m.errors[i])
+	}
+	for i := range m.latency {
+		s.Latency[i] = atomic.LoadInt64(&m.latency[i])
+	}
+	return s
+}
+
+// loadSampleSize is the number of recent request keys retained by
+// keySampler.
+const loadSampleSize = 128
+
+// loadSampleInterval is the number of requests per recent request key
+// stored by keySampler.
+const loadSampleInterval = 8
+
+// writeSampleSize is the number of written keys retained by keySampler.
+const writeSampleSize = 256
+
+// minKeySamples is the minimum number of sampled keys within a range for
+// the sample to be trusted to estimate the distribution of load or data
+// over the range's key space.
+const minKeySamples = 16
+
+// keySampler samples the keys of the commands executed by a replica. It
+// retains the keys of a fraction of the most recent requests, which
+// approximate the distribution of load over the range's key space, and a
+// uniform random sample of the keys written, which approximates the
+// distribution of the range's data. Unlike the recent requests, old
+// writes are as likely to be retained as new ones. Both samples describe
+// a single key span and are reset when the replica's span changes.
+type keySampler struct {
+	calls int64 // accessed atomically
+	sync.Mutex
+	recent  [loadSampleSize]roachpb.Key
+	next    int // index in recent at which the next key is stored
+	n       int // number of keys stored in recent
+	written []roachpb.Key
+	seen    int64 // number of written keys offered to the sample
+}
+
+// record samples key, the key of an executed command. Every
+// loadSampleInterval-th key is stored as a recent request key, replacing
+// the oldest one once the sample is full. If write is true, the key is
+// also offered to the sample of written keys; once that sample is full,
+// the key replaces a random sampled key with probability
+// writeSampleSize/seen (reservoir sampling). Calls which sample nothing
+// return without locking or copying.
+func (s *keySampler) record(key roachpb.Key, write bool) {
+	recent := atomic.AddInt64(&s.calls, 1)%loadSampleInterval == 0
+	if !recent && !write {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	if recent {
+		s.recent[s.next] = append(roachpb.Key(nil), key...)
+		s.next = (s.next + 1) % loadSampleSize
+		if s.n < loadSampleSize {
+			s.n++
+		}
+	}
+	if write {
+		s.seen++
+		if len(s.written) < writeSampleSize {
+			s.written = append(s.written, append(roachpb.Key(nil), key...))
+		} else if i := rand.Int63n(s.seen); i < writeSampleSize {
+			s.written[i] = append(roachpb.Key(nil), key...)
+		}
+	}
+}
+
+// reset discards all sampled keys.
+func (s *keySampler) reset() {
+	s.Lock()
+	defer s.Unlock()
+	s.recent = [loadSampleSize]roachpb.Key{}
+	s.next, s.n = 0, 0
+	s.written, s.seen = nil, 0
+}
+
+// loadSample returns the stored recent request keys in no particular
+// order.
+func (s *keySampler) loadSample() []roachpb.Key {
+	s.Lock()
+	defer s.Unlock()
+	return append([]roachpb.Key(nil), s.recent[:s.n]...)
+}
+
+// writeSample returns the sampled written keys in no particular order.
+func (s *keySampler) writeSample() []roachpb.Key {
+	s.Lock()
+	defer s.Unlock()
+	return append([]roachpb.Key(nil), s.written...)
+}
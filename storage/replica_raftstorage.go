@@ -17,6 +17,7 @@
 package storage
 
 import (
+	"crypto/sha256"
 	"sync/atomic"
 	"unsafe"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/roachpb"
 	"github.com/cockroachdb/cockroach/storage/engine"
 	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/encoding"
 	"github.com/cockroachdb/cockroach/util/log"
 	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
@@ -345,6 +347,7 @@ func (r *Replica) Snapshot() (raftpb.Snapshot, error) {
 				Timestamp: key.Timestamp,
 			})
 	}
+	snapData.Checksum = snapshotChecksum(snapData.KV)
 
 	data, err := proto.Marshal(&snapData)
 	if err != nil {
@@ -372,6 +375,32 @@ func (r *Replica) Snapshot() (raftpb.Snapshot, error) {
 	}, nil
 }
 
+// snapshotChecksum returns the SHA256 of the supplied snapshot tuples. Keys
+// and values are length-prefixed so that the boundaries between them are
+// covered by the checksum as well.
+func snapshotChecksum(kvs []*roachpb.RaftSnapshotData_KeyValue) []byte {
+	sha := sha256.New()
+	var buf []byte
+	for _, kv := range kvs {
+		buf = appendChecksumKV(buf[:0], kv.Key, kv.Timestamp, kv.Value)
+		// Writes to a hash never fail.
+		_, _ = sha.Write(buf)
+	}
+	return sha.Sum(nil)
+}
+
+// appendChecksumKV appends the checksummed encoding of a single key/value
+// pair to buf. Key and value are length-prefixed so that adjacent pairs
+// cannot be confused for one another.
+func appendChecksumKV(buf []byte, key roachpb.Key, ts roachpb.Timestamp, value []byte) []byte {
+	buf = encoding.EncodeUvarint(buf, uint64(len(key)))
+	buf = append(buf, key...)
+	buf = encoding.EncodeUint64(buf, uint64(ts.WallTime))
+	buf = encoding.EncodeUint32(buf, uint32(ts.Logical))
+	buf = encoding.EncodeUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
 // Append implements the multiraft.WriteableGroupStorage interface.
 func (r *Replica) Append(entries []raftpb.Entry) error {
 	if len(entries) == 0 {
@@ -433,14 +462,7 @@ func (r *Replica) updateRangeInfo() error {
 		return nil
 	}
 
-	// Find zone config for this range.
-	zone, err := cfg.GetZoneConfigForKey(r.Desc().StartKey)
-	if err != nil {
-		return util.Errorf("failed to lookup zone config for Range %s: %s", r, err)
-	}
-
-	r.SetMaxBytes(zone.RangeMaxBytes)
-	return nil
+	return r.ApplyConfigChange(cfg)
 }
 
 // ApplySnapshot implements the multiraft.WriteableGroupStorage interface.
@@ -450,7 +472,6 @@ func (r *Replica) ApplySnapshot(snap raftpb.Snapshot) error {
 	if err != nil {
 		return err
 	}
-
 	rangeID := r.Desc().RangeID
 
 	// First, save the HardState.  The HardState must not be changed
@@ -531,6 +552,8 @@ func (r *Replica) ApplySnapshot(snap raftpb.Snapshot) error {
 
 	// Update the range stats.
 	r.stats.Replace(newStats)
+	r.invalidateSystemDBCache()
+	r.sampler.reset()
 
 	// As outlined above, last and applied index are the same after applying
 	// the snapshot.
@@ -20,10 +20,15 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math"
+	"os"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -42,9 +47,14 @@ import (
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/hlc"
 	"github.com/cockroachdb/cockroach/util/leaktest"
+	"github.com/cockroachdb/cockroach/util/log"
+	"github.com/cockroachdb/cockroach/util/randutil"
+	"github.com/cockroachdb/cockroach/util/retry"
 	"github.com/cockroachdb/cockroach/util/stop"
+	"github.com/cockroachdb/cockroach/util/tracer"
 	"github.com/cockroachdb/cockroach/util/uuid"
 	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
 	"github.com/gogo/protobuf/proto"
 )
 
@@ -430,6 +440,79 @@ func TestRangeRangeBoundsChecking(t *testing.T) {
 	}
 }
 
+// TestRangeWriteBoundsChecking verifies that a write sent directly to a
+// replica which doesn't contain its key is rejected with a
+// RangeKeyMismatchError carrying the replica's descriptor, and that the
+// key is not written.
+func TestRangeWriteBoundsChecking(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	splitTestRange(tc.store, roachpb.RKey("a"), roachpb.RKey("a"), t)
+	h := roachpb.Header{Timestamp: tc.clock.Now()}
+
+	pArgs := putArgs(roachpb.Key("0"), []byte("value"))
+	if _, err := client.SendWrappedWith(tc.rng, tc.rng.context(), h, &pArgs); err != nil {
+		t.Fatalf("expected in-range put to succeed: %s", err)
+	}
+
+	pArgs = putArgs(roachpb.Key("b"), []byte("value"))
+	_, err := client.SendWrappedWith(tc.rng, tc.rng.context(), h, &pArgs)
+	mismatchErr, ok := err.(*roachpb.RangeKeyMismatchError)
+	if !ok {
+		t.Fatalf("expected range key mismatch error; got %v", err)
+	}
+	if desc := tc.rng.Desc(); !reflect.DeepEqual(mismatchErr.Range, desc) {
+		t.Errorf("expected error to carry descriptor %+v; got %+v", desc, mismatchErr.Range)
+	}
+	if val, _, err := engine.MVCCGet(tc.engine, roachpb.Key("b"), tc.clock.Now(), true, nil); err != nil || val != nil {
+		t.Errorf("expected no value to be written; got %v (err: %v)", val, err)
+	}
+}
+
+// TestRangeDeleteRangeBoundsChecking verifies that a DeleteRange within the
+// range deletes its span, while one extending into the next range is
+// rejected as a whole with a RangeKeyMismatchError, leaving it to the
+// DistSender to split the span across ranges.
+func TestRangeDeleteRangeBoundsChecking(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	splitTestRange(tc.store, roachpb.RKeyMin, roachpb.RKey("m"), t)
+	for _, k := range []string{"a", "b", "c", "d"} {
+		pArgs := putArgs(roachpb.Key(k), []byte("value"))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dArgs := roachpb.DeleteRangeRequest{Span: roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("c")}}
+	reply, err := client.SendWrapped(tc.rng, tc.rng.context(), &dArgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num := reply.(*roachpb.DeleteRangeResponse).NumDeleted; num != 2 {
+		t.Errorf("expected 2 keys deleted; got %d", num)
+	}
+
+	dArgs = roachpb.DeleteRangeRequest{Span: roachpb.Span{Key: roachpb.Key("c"), EndKey: roachpb.Key("z")}}
+	_, err = client.SendWrapped(tc.rng, tc.rng.context(), &dArgs)
+	if mismatchErr, ok := err.(*roachpb.RangeKeyMismatchError); !ok {
+		t.Fatalf("expected range key mismatch error; got %v", err)
+	} else if desc := tc.rng.Desc(); !reflect.DeepEqual(mismatchErr.Range, desc) {
+		t.Errorf("expected error to carry descriptor %+v; got %+v", desc, mismatchErr.Range)
+	}
+	for _, k := range []string{"c", "d"} {
+		if val, _, err := engine.MVCCGet(tc.engine, roachpb.Key(k), tc.clock.Now(), true, nil); err != nil || val == nil {
+			t.Errorf("expected %q to survive the rejected delete; got %v (err: %v)", k, val, err)
+		}
+	}
+}
+
 // hasLease returns whether the most recent leader lease was held by the given
 // range replica and whether it's expired for the given timestamp.
 func hasLease(rng *Replica, timestamp roachpb.Timestamp) (bool, bool) {
@@ -504,6 +587,33 @@ func TestRangeLeaderLease(t *testing.T) {
 	}
 }
 
+// TestRangeLeaseStatus verifies the lease status reported for a valid
+// lease, an expired lease and a range which never had a lease.
+func TestRangeLeaseStatus(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	status := tc.rng.LeaseStatus()
+	if !status.Valid || status.Holder.StoreID != tc.store.StoreID() {
+		t.Errorf("expected a valid lease held by store %d; got %+v", tc.store.StoreID(), status)
+	}
+	if lease := tc.rng.getLease(); !status.Start.Equal(lease.Start) || !status.Expiration.Equal(lease.Expiration) {
+		t.Errorf("expected lease interval [%s, %s); got %+v", lease.Start, lease.Expiration, status)
+	}
+
+	tc.manualClock.Set(status.Expiration.WallTime + 1)
+	if expired := tc.rng.LeaseStatus(); expired.Valid || expired.Holder != status.Holder {
+		t.Errorf("expected an expired lease held by %s; got %+v", status.Holder, expired)
+	}
+
+	newRng := splitTestRange(tc.store, roachpb.RKeyMin, roachpb.RKey("a"), t)
+	if none := newRng.LeaseStatus(); none != (LeaseStatus{}) {
+		t.Errorf("expected no lease; got %+v", none)
+	}
+}
+
 func TestRangeNotLeaderError(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
@@ -563,6 +673,54 @@ func TestRangeNotLeaderError(t *testing.T) {
 	}
 }
 
+// TestRangeTransferLeaderLease verifies that the holder of the leader
+// lease can hand it to another replica of the range before it expires,
+// after which commands are redirected to the new holder.
+func TestRangeTransferLeaderLease(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	secondReplica := roachpb.ReplicaDescriptor{
+		NodeID:    2,
+		StoreID:   2,
+		ReplicaID: 2,
+	}
+	rngDesc := tc.rng.Desc()
+	rngDesc.Replicas = append(rngDesc.Replicas, secondReplica)
+	tc.rng.setDescWithoutProcessUpdate(rngDesc)
+
+	if held, _ := hasLease(tc.rng, tc.clock.Now()); !held {
+		t.Fatal("expected lease on range start")
+	}
+
+	// Transfers to stores which don't hold a replica are rejected.
+	if err := tc.rng.TransferLeaderLease(roachpb.ReplicaDescriptor{NodeID: 3, StoreID: 3}); !testutils.IsError(err, "not one of its replicas") {
+		t.Fatalf("unexpected error transferring to non-member: %v", err)
+	}
+
+	if err := tc.rng.TransferLeaderLease(secondReplica); err != nil {
+		t.Fatal(err)
+	}
+	if held, expired := hasLease(tc.rng, tc.clock.Now()); held || expired {
+		t.Fatalf("expected lease to be held by %v", secondReplica)
+	}
+
+	gArgs := getArgs(roachpb.Key("a"))
+	_, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &gArgs)
+	if lErr, ok := err.(*roachpb.NotLeaderError); !ok {
+		t.Fatalf("expected NotLeaderError; got %v", err)
+	} else if lErr.Leader == nil || lErr.Leader.StoreID != secondReplica.StoreID {
+		t.Fatalf("expected redirect to %v; got %v", secondReplica, lErr.Leader)
+	}
+
+	// Having given up the lease, the replica can't transfer it again.
+	if err := tc.rng.TransferLeaderLease(secondReplica); err == nil {
+		t.Fatal("expected error transferring a lease which isn't held")
+	}
+}
+
 // TestRangeGossipConfigsOnLease verifies that config info is gossiped
 // upon acquisition of the leader lease.
 func TestRangeGossipConfigsOnLease(t *testing.T) {
@@ -827,6 +985,70 @@ func TestRangeNoGossipConfig(t *testing.T) {
 	}
 }
 
+// TestRangeGossipConfigCache verifies that the SystemDB span is rescanned
+// for gossip only after a write to it has been applied, and not on every
+// gossip attempt.
+func TestRangeGossipConfigCache(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	gossiped := func() map[string]struct{} {
+		var cfg config.SystemConfig
+		if err := tc.gossip.GetInfoProto(gossip.KeySystemConfig, &cfg); err != nil {
+			t.Fatal(err)
+		}
+		m := map[string]struct{}{}
+		for _, kv := range cfg.Values {
+			m[string(kv.Key)] = struct{}{}
+		}
+		return m
+	}
+	// forceGossip clears the hash of the last gossiped config so that the
+	// replica gossips again even if its contents are unchanged.
+	forceGossip := func() {
+		tc.rng.Lock()
+		tc.rng.systemDBHash = nil
+		tc.rng.Unlock()
+		tc.rng.maybeGossipSystemConfig()
+	}
+
+	// Acquire the leader lease, since only the lease holder gossips, and
+	// populate the cache.
+	pArgs := putArgs(roachpb.Key("a"), []byte("value"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+	forceGossip()
+
+	// Writing directly to the engine bypasses raft, so the cached span
+	// contents are not invalidated and the key is not gossiped.
+	key1 := roachpb.Key(keys.MakeTablePrefix(keys.MaxSystemDescID))
+	if err := engine.MVCCPut(tc.engine, nil, key1, tc.clock.Now(), roachpb.MakeValueFromString("foo"), nil); err != nil {
+		t.Fatal(err)
+	}
+	forceGossip()
+	if _, ok := gossiped()[string(key1)]; ok {
+		t.Fatalf("expected %s to be served from the cache and not gossiped", key1)
+	}
+
+	// An applied write to the span invalidates the cache; both keys are
+	// picked up by the rescan.
+	key2 := key1.Next()
+	pArgs = putArgs(key2, []byte("bar"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+	forceGossip()
+	cfg := gossiped()
+	for _, key := range []roachpb.Key{key1, key2} {
+		if _, ok := cfg[string(key)]; !ok {
+			t.Errorf("expected %s to be gossiped after the cache was invalidated", key)
+		}
+	}
+}
+
 // TestRangeNoGossipFromNonLeader verifies that a non-leader replica
 // does not gossip configurations.
 func TestRangeNoGossipFromNonLeader(t *testing.T) {
@@ -1314,1988 +1536,3479 @@ func TestRangeUseTSCache(t *testing.T) {
 	}
 }
 
-// TestRangeNoTSCacheInconsistent verifies that the timestamp cache
-// is not affected by inconsistent reads.
-func TestRangeNoTSCacheInconsistent(t *testing.T) {
+// TestRangeTSCacheClockSkew verifies that a write whose timestamp lies
+// below that of a prior read of the same key, as happens when the
+// coordinators of the two commands have skewed clocks, has its timestamp
+// pushed past the read.
+func TestRangeTSCacheClockSkew(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
-	// Set clock to time 1s and do the read.
-	t0 := 1 * time.Second
-	tc.manualClock.Set(t0.Nanoseconds())
-	args := getArgs([]byte("a"))
-	ts := tc.clock.Now()
 
-	_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-		Timestamp:       ts,
-		ReadConsistency: roachpb.INCONSISTENT,
-	}, &args)
+	now := 10 * time.Second
+	tc.manualClock.Set(now.Nanoseconds())
+	for i, skew := range []time.Duration{time.Nanosecond, time.Millisecond, 250 * time.Millisecond} {
+		key := roachpb.Key(fmt.Sprintf("skew-%d", i))
+		// The reader's clock runs ahead of the local clock, the writer's
+		// behind it.
+		readTS := roachpb.ZeroTimestamp.Add(now.Nanoseconds()+skew.Nanoseconds(), 0)
+		writeTS := roachpb.ZeroTimestamp.Add(now.Nanoseconds()-skew.Nanoseconds(), 0)
 
-	if err != nil {
-		t.Error(err)
+		gArgs := getArgs(key)
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Timestamp: readTS}, &gArgs); err != nil {
+			t.Fatal(err)
+		}
+		pArgs := putArgs(key, []byte("value"))
+		reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Timestamp: writeTS}, &pArgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ts := reply.Header().Timestamp; !readTS.Less(ts) {
+			t.Errorf("%d: skew %s: expected write timestamp to be pushed past read at %s; got %s", i, skew, readTS, ts)
+		}
+
+		// A read at the original write timestamp must not see the write.
+		gArgs = getArgs(key)
+		gReply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Timestamp: writeTS}, &gArgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := gReply.(*roachpb.GetResponse).Value; v != nil {
+			t.Errorf("%d: skew %s: expected no value at %s; got %s", i, skew, writeTS, v)
+		}
 	}
-	pArgs := putArgs([]byte("a"), []byte("value"))
+}
 
-	reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Timestamp: roachpb.ZeroTimestamp.Add(0, 1)}, &pArgs)
-	if err != nil {
+// TestRangeTracePushedTimestamp verifies that a write whose timestamp
+// is pushed forward by the timestamp cache records the push in its trace.
+func TestRangeTracePushedTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+	tc.manualClock.Set((1 * time.Second).Nanoseconds())
+	gArgs := getArgs([]byte("a"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &gArgs); err != nil {
 		t.Fatal(err)
 	}
-	pReply := reply.(*roachpb.PutResponse)
-	if pReply.Timestamp.WallTime == tc.clock.Timestamp().WallTime {
-		t.Errorf("expected write timestamp not to upgrade to 1s; got %s", pReply.Timestamp)
+
+	// Write with a timestamp preceding the read.
+	ba := roachpb.BatchRequest{}
+	ba.Timestamp = roachpb.ZeroTimestamp.Add(1, 0)
+	pArgs := putArgs([]byte("a"), []byte("value"))
+	ba.Add(&pArgs)
+	trace := tracer.NewTracer(nil, "test").NewTrace(tracer.Node, ba)
+	if _, pErr := tc.Sender().Send(tracer.ToCtx(tc.rng.context(), trace), ba); pErr != nil {
+		t.Fatal(pErr)
+	}
+	var found bool
+	for _, item := range trace.Content {
+		if strings.HasPrefix(item.Name, "timestamp pushed from") {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("expected timestamp push event in trace:\n%s", trace)
 	}
 }
 
-// TestRangeNoTSCacheUpdateOnFailure verifies that read and write
-// commands do not update the timestamp cache if they result in
-// failure.
-func TestRangeNoTSCacheUpdateOnFailure(t *testing.T) {
+// TestRangeGetMissingVsEmpty verifies that a Get of a missing key can be
+// told apart from a Get of a key holding an empty value: the former
+// returns no value at all, the latter an empty one.
+func TestRangeGetMissingVsEmpty(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	// Test for both read & write attempts.
-	for i, read := range []bool{true, false} {
-		key := roachpb.Key(fmt.Sprintf("key-%d", i))
-
-		// Start by laying down an intent to trip up future read or write to same key.
-		pArgs := putArgs(key, []byte("value"))
-		txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+	for _, kv := range []struct{ key, value string }{{"empty", ""}, {"data", "value"}} {
+		pArgs := putArgs(roachpb.Key(kv.key), []byte(kv.value))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
 
-		reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-			Txn: txn,
-		}, &pArgs)
+	testCases := []struct {
+		key    string
+		exists bool
+		value  string
+	}{
+		{"missing", false, ""},
+		{"empty", true, ""},
+		{"data", true, "value"},
+	}
+	for _, test := range testCases {
+		gArgs := getArgs(roachpb.Key(test.key))
+		reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &gArgs)
 		if err != nil {
-			t.Fatalf("test %d: %s", i, err)
+			t.Fatal(err)
 		}
-		pReply := reply.(*roachpb.PutResponse)
-
-		// Now attempt read or write.
-		args := readOrWriteArgs(key, read)
-		ts := tc.clock.Now() // later timestamp
-
-		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-			Timestamp: ts,
-		}, args); err == nil {
-			t.Errorf("test %d: expected failure", i)
+		val := reply.(*roachpb.GetResponse).Value
+		if exists := val != nil; exists != test.exists {
+			t.Errorf("%s: expected value to exist: %t", test.key, test.exists)
+			continue
 		}
-
-		// Write the intent again -- should not have its timestamp upgraded!
-		txn.Sequence++
-		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-			Txn: txn,
-		}, &pArgs); err != nil {
-			t.Fatalf("test %d: %s", i, err)
+		if !test.exists {
+			continue
 		}
-		if !pReply.Timestamp.Equal(txn.Timestamp) {
-			t.Errorf("expected timestamp not to advance %s != %s", pReply.Timestamp, txn.Timestamp)
+		if b, err := val.GetBytes(); err != nil {
+			t.Errorf("%s: %s", test.key, err)
+		} else if string(b) != test.value {
+			t.Errorf("%s: expected %q; got %q", test.key, test.value, b)
 		}
 	}
 }
 
-// TestRangeNoTimestampIncrementWithinTxn verifies that successive
-// read and write commands within the same transaction do not cause
-// the write to receive an incremented timestamp.
-func TestRangeNoTimestampIncrementWithinTxn(t *testing.T) {
+// TestRangeGetMulti verifies that GetMulti returns the values of present
+// and absent keys in the order in which they were requested.
+func TestRangeGetMulti(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	// Test for both read & write attempts.
-	key := roachpb.Key("a")
-	txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-
-	// Start with a read to warm the timestamp cache.
-	gArgs := getArgs(key)
-
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-		Txn: txn,
-	}, &gArgs); err != nil {
-		t.Fatal(err)
+	for _, k := range []string{"a", "c", "e"} {
+		pArgs := putArgs(roachpb.Key(k), []byte("value-"+k))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
 	}
 
-	// Now try a write and verify timestamp isn't incremented.
-	pArgs := putArgs(key, []byte("value"))
-
-	txn.Sequence++
-	reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-		Txn: txn,
-	}, &pArgs)
+	keys := []roachpb.Key{roachpb.Key("e"), roachpb.Key("b"), roachpb.Key("a"), roachpb.Key("d"), roachpb.Key("c")}
+	args := roachpb.NewGetMulti(keys...)
+	reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), args)
 	if err != nil {
 		t.Fatal(err)
 	}
-	pReply := reply.(*roachpb.PutResponse)
-	if !pReply.Timestamp.Equal(txn.Timestamp) {
-		t.Errorf("expected timestamp to remain %s; got %s", txn.Timestamp, pReply.Timestamp)
-	}
-
-	// Resolve the intent.
-	rArgs := &roachpb.ResolveIntentRequest{
-		Span:      *pArgs.Header(),
-		IntentTxn: *txn,
+	values := reply.(*roachpb.GetMultiResponse).Values
+	if len(values) != len(keys) {
+		t.Fatalf("expected %d values; got %d", len(keys), len(values))
 	}
-	txn.Sequence++
-	rArgs.IntentTxn.Status = roachpb.COMMITTED
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Txn: txn, Timestamp: txn.Timestamp}, rArgs); err != nil {
-		t.Fatal(err)
+	for i, exp := range []string{"value-e", "", "value-a", "", "value-c"} {
+		if exp == "" {
+			if values[i].RawBytes != nil {
+				t.Errorf("%d: expected no value for key %q; got %+v", i, keys[i], values[i])
+			}
+			continue
+		}
+		if b, err := values[i].GetBytes(); err != nil {
+			t.Errorf("%d: %s", i, err)
+		} else if string(b) != exp {
+			t.Errorf("%d: expected %q; got %q", i, exp, b)
+		}
 	}
 
-	// Finally, try a non-transactional write and verify timestamp is incremented.
-	ts := txn.Timestamp
-	expTS := ts
-	expTS.Logical++
-
-	if reply, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Timestamp: ts}, &pArgs); err != nil {
-		t.Errorf("unexpected error: %s", err)
-	}
-	pReply = reply.(*roachpb.PutResponse)
-	if !pReply.Timestamp.Equal(expTS) {
-		t.Errorf("expected timestamp to increment to %s; got %s", expTS, pReply.Timestamp)
+	// A GetMulti without keys has no span to read.
+	if _, err := client.SendWrappedWith(tc.store, nil, roachpb.Header{RangeID: 1}, roachpb.NewGetMulti()); !testutils.IsError(err, "end key must be specified") {
+		t.Errorf("expected empty GetMulti to be rejected; got %v", err)
 	}
 }
 
-// TestRangeSequenceCacheReadError verifies that an error is returned to the
-// client in the event that a sequence cache entry is found but is not
-// decodable.
-func TestRangeSequenceCacheReadError(t *testing.T) {
+// TestRangeWriteBatch verifies that all pairs of a WriteBatch are written
+// and accounted for in the range's stats, and that pairs outside of the
+// request span are left to the ranges serving them.
+func TestRangeWriteBatch(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	k := []byte("a")
-	txn := newTransaction("test", k, 10, roachpb.SERIALIZABLE, tc.clock)
-	args := incrementArgs(k, 1)
-	txn.Sequence = 1
-
-	if _, pErr := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-		Txn: txn,
-	}, &args); pErr != nil {
-		t.Fatal(pErr)
+	var pairs []roachpb.KeyValue
+	for _, k := range []string{"c", "a", "b"} {
+		pairs = append(pairs, roachpb.KeyValue{Key: roachpb.Key(k), Value: roachpb.MakeValueFromBytes([]byte("value-" + k))})
 	}
-
-	// Overwrite sequence cache entry with garbage for the last op.
-	key := keys.SequenceCacheKey(tc.rng.Desc().RangeID, txn.ID, uint32(txn.Epoch), txn.Sequence)
-	// Make garbageKey sort before key (we've chosen Sequence=1 above,
-	// the last byte of which isn't \x00); add an extra byte of garbage.
-	garbageKey := append(roachpb.Key(nil), key[:len(key)-1]...)
-	garbageKey = append(garbageKey, '\x00', '!')
-	err := engine.MVCCPut(tc.engine, nil, garbageKey, roachpb.ZeroTimestamp, roachpb.MakeValueFromString("never read in this test"), nil)
-	if err != nil {
+	keyCount := tc.rng.GetMVCCStats().KeyCount
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), roachpb.NewWriteBatch(pairs...)); err != nil {
 		t.Fatal(err)
 	}
+	for _, kv := range pairs {
+		gArgs := getArgs(kv.Key)
+		reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &gArgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, err := reply.(*roachpb.GetResponse).Value.GetBytes(); err != nil {
+			t.Fatal(err)
+		} else if exp := "value-" + string(kv.Key); string(b) != exp {
+			t.Errorf("expected %q; got %q", exp, b)
+		}
+	}
+	if delta := tc.rng.GetMVCCStats().KeyCount - keyCount; delta != int64(len(pairs)) {
+		t.Errorf("expected key count to grow by %d; got %d", len(pairs), delta)
+	}
 
-	// Now try increment again and verify error.
-	_, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-		Txn: txn,
-	}, &args)
-	if !testutils.IsError(err, "replica corruption") {
+	// Narrow the span so that one of the pairs falls outside of it, as the
+	// DistSender does for a batch spanning several ranges.
+	args := roachpb.NewWriteBatch(roachpb.KeyValue{Key: roachpb.Key("d"), Value: roachpb.MakeValueFromBytes([]byte("d"))},
+		roachpb.KeyValue{Key: roachpb.Key("e"), Value: roachpb.MakeValueFromBytes([]byte("e"))})
+	args.Header().EndKey = roachpb.Key("e")
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), args); err != nil {
 		t.Fatal(err)
 	}
+	for key, exists := range map[string]bool{"d": true, "e": false} {
+		gArgs := getArgs(roachpb.Key(key))
+		if reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &gArgs); err != nil {
+			t.Fatal(err)
+		} else if v := reply.(*roachpb.GetResponse).Value; (v != nil) != exists {
+			t.Errorf("%s: expected written %t; got %+v", key, exists, v)
+		}
+	}
 }
 
-// TestRangeSequenceCacheStoredError verifies that if a cached entry is present,
-// a transaction restart error is returned.
-func TestRangeSequenceCacheStoredTxnRetryError(t *testing.T) {
+// TestRangeConditionalPutBatch verifies that a ConditionalPutBatch writes
+// all of its values when every condition holds, and that a single failed
+// condition leaves all keys unchanged and reports the offending key.
+func TestRangeConditionalPutBatch(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	key := []byte("a")
-	for i, pastError := range []error{errors.New("boom"), nil} {
-		txn := newTransaction("test", key, 10, roachpb.SERIALIZABLE, tc.clock)
-		txn.Sequence = uint32(1 + i)
-		_ = tc.rng.sequence.Put(tc.engine, txn.ID, uint32(txn.Epoch), txn.Sequence, txn.Key, txn.Timestamp, pastError)
-
-		args := incrementArgs(key, 1)
-		_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-			Txn: txn,
-		}, &args)
-		if _, ok := err.(*roachpb.TransactionRetryError); !ok {
-			t.Fatalf("%d: unexpected error %v", i, err)
+	cputKeys := []roachpb.Key{roachpb.Key("a"), roachpb.Key("b"), roachpb.Key("c")}
+	verify := func(exp string) {
+		for _, key := range cputKeys {
+			gArgs := getArgs(key)
+			reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &gArgs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b, err := reply.(*roachpb.GetResponse).Value.GetBytes(); err != nil {
+				t.Fatal(err)
+			} else if string(b) != exp {
+				t.Errorf("%s: expected %q; got %q", key, exp, b)
+			}
 		}
 	}
 
-	// Try the same again, this time verifying that the Put will actually
-	// populate the cache appropriately.
-	txn := newTransaction("test", key, 10, roachpb.SERIALIZABLE, tc.clock)
-	txn.Sequence = 321
-	args := incrementArgs(key, 1)
-	try := func() error {
-		_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-			Txn: txn,
-		}, &args)
-		return err
+	// All keys are expected to be absent.
+	var puts []roachpb.ConditionalPutRequest
+	for _, key := range cputKeys {
+		puts = append(puts, roachpb.ConditionalPutRequest{
+			Span:  roachpb.Span{Key: key},
+			Value: roachpb.MakeValueFromBytes([]byte("v1")),
+		})
 	}
-
-	if err := try(); err != nil {
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), roachpb.NewConditionalPutBatch(puts...)); err != nil {
 		t.Fatal(err)
 	}
-	txn.Timestamp.Forward(txn.Timestamp.Add(10, 10)) // can't hurt
-	{
-		err := try()
-		if _, ok := err.(*roachpb.TransactionRetryError); !ok {
-			t.Fatal(err)
+	verify("v1")
+
+	// Expect "v1" everywhere, but "b" is expected to be absent.
+	expValue := roachpb.MakeValueFromBytes([]byte("v1"))
+	for i := range puts {
+		puts[i].Value = roachpb.MakeValueFromBytes([]byte("v2"))
+		if !puts[i].Key.Equal(roachpb.Key("b")) {
+			puts[i].ExpValue = &expValue
 		}
 	}
+	_, err := client.SendWrapped(tc.Sender(), tc.rng.context(), roachpb.NewConditionalPutBatch(puts...))
+	if cErr, ok := err.(*roachpb.ConditionFailedError); !ok {
+		t.Fatalf("expected ConditionFailedError; got %v", err)
+	} else if !cErr.Key.Equal(roachpb.Key("b")) {
+		t.Errorf("expected failed key %q; got %q", "b", cErr.Key)
+	} else if b, err := cErr.ActualValue.GetBytes(); err != nil || string(b) != "v1" {
+		t.Errorf("expected actual value %q; got %q (%v)", "v1", b, err)
+	}
+	verify("v1")
+}
 
-	//  Pretend we restarted by increasing the epoch. That's all that's needed.
-	txn.Epoch++
-	if err := try(); err != nil {
+// TestRangePutTTL verifies that a value written with a TTL can be read
+// until the TTL has elapsed relative to its write timestamp, and is
+// absent afterwards.
+func TestRangePutTTL(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	key := roachpb.Key("a")
+	pArgs := putArgs(key, []byte("value"))
+	pArgs.TTL = time.Second
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+		Timestamp: makeTS(1*time.Second.Nanoseconds(), 0),
+	}, &pArgs); err != nil {
 		t.Fatal(err)
 	}
 
-	// Now increase the sequence as well. Still good to go.
-	txn.Sequence++
-	if err := try(); err != nil {
-		t.Fatal(err)
+	testCases := []struct {
+		ts     roachpb.Timestamp
+		exists bool
+	}{
+		{makeTS(1*time.Second.Nanoseconds(), 0), true},
+		{makeTS(2*time.Second.Nanoseconds()-1, 0), true},
+		{makeTS(2*time.Second.Nanoseconds(), 0), false},
+		{makeTS(3*time.Second.Nanoseconds(), 0), false},
+	}
+	for i, test := range testCases {
+		gArgs := getArgs(key)
+		reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Timestamp: test.ts,
+		}, &gArgs)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if exists := reply.(*roachpb.GetResponse).Value != nil; exists != test.exists {
+			t.Errorf("%d: expected value to exist at %s: %t", i, test.ts, test.exists)
+		}
+
+		sArgs := scanArgs(key, key.Next())
+		reply, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Timestamp: test.ts,
+		}, &sArgs)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if exists := len(reply.(*roachpb.ScanResponse).Rows) == 1; exists != test.exists {
+			t.Errorf("%d: expected scan to return value at %s: %t", i, test.ts, test.exists)
+		}
 	}
 }
 
-// TestEndTransactionDeadline verifies that EndTransaction respects the
-// transaction deadline.
-func TestEndTransactionDeadline(t *testing.T) {
+// TestRangeScanFiltered verifies that scans restricted by a key prefix
+// or suffix return only matching rows and that MaxResults counts only
+// those rows.
+func TestRangeScanFiltered(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	// 4 cases: no deadline, past deadline, equal deadline, future deadline.
-	for i := 0; i < 4; i++ {
-		key := roachpb.Key("key: " + strconv.Itoa(i))
-		txn := newTransaction("txn: "+strconv.Itoa(i), key, 1, roachpb.SERIALIZABLE, tc.clock)
-
-		btArgs, btHeader := beginTxnArgs(key, txn)
-		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btHeader, &btArgs); err != nil {
+	for _, k := range []string{"a/1/x", "a/1/y", "a/2/x", "a/2/y", "a/3/x", "b/1/x"} {
+		pArgs := putArgs(roachpb.Key(k), []byte(k))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
 			t.Fatal(err)
 		}
+	}
 
-		etArgs, etHeader := endTxnArgs(txn, true /* commit */)
-		switch i {
-		case 0:
-			// No deadline.
-		case 1:
-			// Past deadline.
-			ts := txn.Timestamp.Prev()
-			etArgs.Deadline = &ts
-		case 2:
-			// Equal deadline.
-			etArgs.Deadline = &txn.Timestamp
-		case 3:
-			// Future deadline.
-			ts := txn.Timestamp.Next()
-			etArgs.Deadline = &ts
+	testCases := []struct {
+		key, endKey    string
+		prefix, suffix string
+		max            int64
+		expKeys        []string
+	}{
+		{"a", "c", "a/2", "", 0, []string{"a/2/x", "a/2/y"}},
+		{"a/2/y", "c", "a/2", "", 0, []string{"a/2/y"}},
+		{"a", "a/2/y", "a/2", "", 0, []string{"a/2/x"}},
+		{"a", "c", "c", "", 0, nil},
+		{"a", "c", "", "/x", 0, []string{"a/1/x", "a/2/x", "a/3/x", "b/1/x"}},
+		{"a", "c", "", "/x", 3, []string{"a/1/x", "a/2/x", "a/3/x"}},
+		{"a", "c", "", "/y", 1, []string{"a/1/y"}},
+		{"a", "c", "a/", "/x", 0, []string{"a/1/x", "a/2/x", "a/3/x"}},
+		{"a", "c", "", "/z", 0, nil},
+	}
+	for i, test := range testCases {
+		sArgs := scanArgs([]byte(test.key), []byte(test.endKey))
+		sArgs.Prefix = roachpb.Key(test.prefix)
+		sArgs.Suffix = []byte(test.suffix)
+		sArgs.MaxResults = test.max
+		reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &sArgs)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
 		}
+		rows := reply.(*roachpb.ScanResponse).Rows
+		var keys []string
+		for _, kv := range rows {
+			keys = append(keys, string(kv.Key))
+		}
+		if !reflect.DeepEqual(keys, test.expKeys) {
+			t.Errorf("%d: expected keys %q; got %q", i, test.expKeys, keys)
+		}
+	}
+}
 
-		{
-			txn.Sequence++
-			_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), etHeader, &etArgs)
-			switch i {
-			case 0:
-				// No deadline.
-				if err != nil {
-					t.Error(err)
-				}
-			case 1:
-				// Past deadline.
-				if _, ok := err.(*roachpb.TransactionAbortedError); !ok {
-					t.Errorf("expected TransactionAbortedError but got %T: %s", err, err)
-				}
-			case 2:
-				// Equal deadline.
-				if err != nil {
-					t.Error(err)
-				}
-			case 3:
-				// Future deadline.
-				if err != nil {
-					t.Error(err)
-				}
-			}
+// TestRangeScanMaxBytes verifies that a scan is truncated by whichever
+// of MaxResults and MaxBytes is reached first, and that the returned
+// resume key allows the scan to be continued.
+func TestRangeScanMaxBytes(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	val := bytes.Repeat([]byte("x"), 100)
+	for _, k := range []string{"a", "b", "c", "d"} {
+		pArgs := putArgs(roachpb.Key(k), val)
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	valSize := int64(len(roachpb.MakeValueFromBytes(val).RawBytes))
+
+	testCases := []struct {
+		maxResults, maxBytes int64
+		expKeys              []string
+		expResumeKey         roachpb.Key
+	}{
+		{0, 0, []string{"a", "b", "c", "d"}, nil},
+		{0, 1, []string{"a"}, roachpb.Key("a").Next()},
+		{0, valSize, []string{"a"}, roachpb.Key("a").Next()},
+		{0, valSize + 1, []string{"a", "b"}, roachpb.Key("b").Next()},
+		{0, 10 * valSize, []string{"a", "b", "c", "d"}, nil},
+		// Row limit reached before the byte limit.
+		{1, 10 * valSize, []string{"a"}, roachpb.Key("a").Next()},
+		// Byte limit reached before the row limit.
+		{3, 2 * valSize, []string{"a", "b"}, roachpb.Key("b").Next()},
+		// Row limit alone.
+		{2, 0, []string{"a", "b"}, roachpb.Key("b").Next()},
+	}
+	for i, test := range testCases {
+		sArgs := scanArgs([]byte("a"), []byte("z"))
+		sArgs.MaxResults = test.maxResults
+		sArgs.MaxBytes = test.maxBytes
+		reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &sArgs)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		sReply := reply.(*roachpb.ScanResponse)
+		var keys []string
+		for _, kv := range sReply.Rows {
+			keys = append(keys, string(kv.Key))
+		}
+		if !reflect.DeepEqual(keys, test.expKeys) {
+			t.Errorf("%d: expected keys %q; got %q", i, test.expKeys, keys)
+		}
+		if !sReply.ResumeKey.Equal(test.expResumeKey) {
+			t.Errorf("%d: expected resume key %q; got %q", i, test.expResumeKey, sReply.ResumeKey)
 		}
 	}
 }
 
-// TestEndTransactionWithMalformedSplitTrigger verifies an
-// EndTransaction call with a malformed commit trigger fails.
-func TestEndTransactionWithMalformedSplitTrigger(t *testing.T) {
+// TestReplicaApplyConfigChange verifies that zone config changes take
+// effect on a running replica: ApplyConfigChange refreshes the split size,
+// idempotently, and a new GC TTL changes the GC queue's decision.
+func TestReplicaApplyConfigChange(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	key := roachpb.Key("foo")
-	txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-	bt, h := beginTxnArgs(key, txn)
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &bt); err != nil {
-		t.Fatal(err)
+	cfg := tc.gossip.GetSystemConfig()
+	if cfg == nil {
+		t.Fatal("nil config")
 	}
-	pArgs := putArgs(key, []byte("only here to make this a rw transaction"))
-	txn.Sequence++
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-		Txn: txn,
-	}, &pArgs); err != nil {
-		t.Fatal(err)
+	const day = 24 * 60 * 60
+	config.TestingSetZoneConfig(keys.RootNamespaceID, &config.ZoneConfig{
+		RangeMaxBytes: 1 << 20,
+		GC:            &config.GCPolicy{TTLSeconds: day},
+	})
+	for i := 0; i < 2; i++ {
+		if err := tc.rng.ApplyConfigChange(cfg); err != nil {
+			t.Fatal(err)
+		}
+		if maxBytes := tc.rng.GetMaxBytes(); maxBytes != 1<<20 {
+			t.Errorf("%d: expected max bytes %d; got %d", i, 1<<20, maxBytes)
+		}
 	}
 
-	args, h := endTxnArgs(txn, true /* commit */)
-	// Make an EndTransaction request which would fail if not
-	// stripped. In this case, we set the start key to "bar" for a
-	// split of the default range; start key must be "" in this case.
-	args.InternalCommitTrigger = &roachpb.InternalCommitTrigger{
-		SplitTrigger: &roachpb.SplitTrigger{
-			UpdatedDesc: roachpb.RangeDescriptor{StartKey: roachpb.RKey("bar")},
-		},
+	// GC'able bytes with an average age of two hours.
+	bc := int64(gcByteCountNormalization)
+	stats := engine.MVCCStats{KeyBytes: bc, GCBytesAge: 2 * 60 * 60 * bc}
+	if err := tc.rng.stats.SetMVCCStats(tc.rng.store.Engine(), stats); err != nil {
+		t.Fatal(err)
+	}
+	gcQ := newGCQueue(tc.gossip)
+	if shouldQ, _ := gcQ.shouldQueue(makeTS(0, 0), tc.rng, cfg); shouldQ {
+		t.Error("expected no GC with a TTL of a day")
 	}
 
-	txn.Sequence++
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args); !testutils.IsError(err, "range does not match splits") {
-		t.Errorf("expected range does not match splits error; got %s", err)
+	config.TestingSetZoneConfig(keys.RootNamespaceID, &config.ZoneConfig{
+		RangeMaxBytes: 2 << 20,
+		GC:            &config.GCPolicy{TTLSeconds: 60 * 60},
+	})
+	if err := tc.rng.ApplyConfigChange(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if maxBytes := tc.rng.GetMaxBytes(); maxBytes != 2<<20 {
+		t.Errorf("expected max bytes %d; got %d", 2<<20, maxBytes)
+	}
+	if shouldQ, _ := gcQ.shouldQueue(makeTS(0, 0), tc.rng, cfg); !shouldQ {
+		t.Error("expected GC once the TTL drops to an hour")
 	}
 }
 
-// TestEndTransactionBeforeHeartbeat verifies that a transaction
-// can be committed/aborted before being heartbeat.
-func TestEndTransactionBeforeHeartbeat(t *testing.T) {
+// TestRangeScanResumeReason verifies that Scan reports why it stopped, and
+// in particular distinguishes a span exhausted within the range from one
+// which was cut off by the end of the range.
+func TestRangeScanResumeReason(t *testing.T) {
 	defer leaktest.AfterTest(t)
-	// Don't automatically GC the Txn record: We want to heartbeat the
-	// committed Transaction and compare it against our expectations.
-	// When it's removed, the heartbeat would recreate it.
-	defer setTxnAutoGC(false)()
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	key := []byte("a")
-	for _, commit := range []bool{true, false} {
-		txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-		bt, btH := beginTxnArgs(key, txn)
-		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
+	newRng := splitTestRange(tc.store, roachpb.RKeyMin, roachpb.RKey("m"), t)
+	for _, k := range []string{"a", "b", "c"} {
+		pArgs := putArgs(roachpb.Key(k), []byte("value"))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
 			t.Fatal(err)
 		}
-		txn.Sequence++
-		args, h := endTxnArgs(txn, commit)
-		resp, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args)
+	}
+
+	testCases := []struct {
+		rng                  *Replica
+		start, end           string
+		maxResults, maxBytes int64
+		expRows              int
+		expReason            roachpb.ScanResumeReason
+	}{
+		{tc.rng, "a", "c", 0, 0, 2, roachpb.COMPLETE},
+		{tc.rng, "a", "m", 0, 0, 3, roachpb.RANGE_BOUNDARY},
+		{tc.rng, "a", "m", 3, 0, 3, roachpb.MAX_RESULTS},
+		{tc.rng, "a", "m", 1, 0, 1, roachpb.MAX_RESULTS},
+		{tc.rng, "a", "m", 0, 1, 1, roachpb.MAX_BYTES},
+		// The last range has no successor to continue on.
+		{newRng, "m", string(roachpb.KeyMax), 0, 0, 0, roachpb.COMPLETE},
+	}
+	for i, test := range testCases {
+		sArgs := scanArgs(roachpb.Key(test.start), roachpb.Key(test.end))
+		sArgs.MaxResults = test.maxResults
+		sArgs.MaxBytes = test.maxBytes
+		reply, _, err := test.rng.Scan(tc.engine, roachpb.Header{Timestamp: tc.clock.Now()}, sArgs)
 		if err != nil {
-			t.Error(err)
-		}
-		reply := resp.(*roachpb.EndTransactionResponse)
-		expStatus := roachpb.COMMITTED
-		if !commit {
-			expStatus = roachpb.ABORTED
+			t.Fatalf("%d: %s", i, err)
 		}
-		if reply.Txn.Status != expStatus {
-			t.Errorf("expected transaction status to be %s; got %s", expStatus, reply.Txn.Status)
+		if len(reply.Rows) != test.expRows {
+			t.Errorf("%d: expected %d rows; got %d", i, test.expRows, len(reply.Rows))
 		}
-
-		// Try a heartbeat to the already-committed transaction; should get
-		// committed txn back, but without last heartbeat timestamp set.
-		txn.Sequence++
-		hBA, h := heartbeatArgs(txn)
-
-		resp, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &hBA)
-		if err != nil {
-			t.Error(err)
+		if reply.ResumeReason != test.expReason {
+			t.Errorf("%d: expected resume reason %s; got %s", i, test.expReason, reply.ResumeReason)
 		}
-		hBR := resp.(*roachpb.HeartbeatTxnResponse)
-		if hBR.Txn.Status != expStatus || hBR.Txn.LastHeartbeat != nil {
-			t.Errorf("unexpected heartbeat reply contents: %+v", hBR)
+		if expResume := test.expReason != roachpb.COMPLETE && test.expReason != roachpb.RANGE_BOUNDARY; expResume != (reply.ResumeKey != nil) {
+			t.Errorf("%d: expected resume key %t; got %q", i, expResume, reply.ResumeKey)
 		}
 	}
 }
 
-// TestEndTransactionAfterHeartbeat verifies that a transaction
-// can be committed/aborted after being heartbeat.
-func TestEndTransactionAfterHeartbeat(t *testing.T) {
+// TestRangeScanStream verifies that ScanStream invokes the callback for
+// each row in order, honors the scan limits and stops at the first error
+// returned by the callback.
+func TestRangeScanStream(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	key := []byte("a")
-	for _, commit := range []bool{true, false} {
-		txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-		bt, btH := beginTxnArgs(key, txn)
-		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
+	allKeys := []string{"a", "b", "c", "d", "e"}
+	for _, k := range allKeys {
+		pArgs := putArgs(roachpb.Key(k), []byte(k))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
 			t.Fatal(err)
 		}
+	}
 
-		// Start out with a heartbeat to the transaction.
-		hBA, h := heartbeatArgs(txn)
-		txn.Sequence++
+	h := roachpb.Header{Timestamp: tc.clock.Now()}
+	var keys []string
+	collect := func(kv roachpb.KeyValue) error {
+		keys = append(keys, string(kv.Key))
+		return nil
+	}
+	resumeKey, _, err := tc.rng.ScanStream(tc.engine, h, scanArgs([]byte("a"), []byte("z")), collect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(keys, allKeys) || resumeKey != nil {
+		t.Errorf("expected keys %q and no resume key; got %q and %q", allKeys, keys, resumeKey)
+	}
 
-		resp, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &hBA)
-		if err != nil {
-			t.Fatal(err)
-		}
-		hBR := resp.(*roachpb.HeartbeatTxnResponse)
-		if hBR.Txn.Status != roachpb.PENDING || hBR.Txn.LastHeartbeat == nil {
-			t.Errorf("unexpected heartbeat reply contents: %+v", hBR)
+	keys = nil
+	sArgs := scanArgs([]byte("a"), []byte("z"))
+	sArgs.MaxResults = 2
+	if resumeKey, _, err = tc.rng.ScanStream(tc.engine, h, sArgs, collect); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(keys, allKeys[:2]) || !resumeKey.Equal(roachpb.Key("b").Next()) {
+		t.Errorf("expected keys %q and resume key %q; got %q and %q", allKeys[:2], roachpb.Key("b").Next(), keys, resumeKey)
+	}
+
+	keys = nil
+	_, _, err = tc.rng.ScanStream(tc.engine, h, scanArgs([]byte("a"), []byte("z")), func(kv roachpb.KeyValue) error {
+		keys = append(keys, string(kv.Key))
+		if len(keys) == 3 {
+			return util.Errorf("stop at %s", kv.Key)
 		}
+		return nil
+	})
+	if !testutils.IsError(err, "stop at c") {
+		t.Errorf("expected callback error; got %v", err)
+	}
+	if !reflect.DeepEqual(keys, allKeys[:3]) {
+		t.Errorf("expected the scan to stop after %q; got %q", allKeys[:3], keys)
+	}
+}
 
-		args, h := endTxnArgs(txn, commit)
-		txn.Sequence++
+// TestRangeContainsRange verifies that ContainsRange reports whether any
+// live key exists in the requested span.
+func TestRangeContainsRange(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
 
-		resp, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args)
-		if err != nil {
-			t.Error(err)
+	for _, k := range []string{"b", "d", "e", "f"} {
+		pArgs := putArgs(roachpb.Key(k), []byte(k))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
 		}
-		reply := resp.(*roachpb.EndTransactionResponse)
-		expStatus := roachpb.COMMITTED
-		if !commit {
-			expStatus = roachpb.ABORTED
+	}
+	// A deleted key doesn't count.
+	dArgs := deleteArgs(roachpb.Key("f"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &dArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		start, end string
+		exists     bool
+	}{
+		// Empty span.
+		{"a", "b", false},
+		{"f", "z", false},
+		// Exactly one key.
+		{"b", "c", true},
+		{"a", "d", true},
+		// Populated span.
+		{"a", "z", true},
+		{"c", "f", true},
+	}
+	for i, test := range testCases {
+		args := &roachpb.ContainsRangeRequest{
+			Span: roachpb.Span{Key: roachpb.Key(test.start), EndKey: roachpb.Key(test.end)},
 		}
-		if reply.Txn.Status != expStatus {
-			t.Errorf("expected transaction status to be %s; got %s", expStatus, reply.Txn.Status)
+		reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), args)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
 		}
-		if reply.Txn.LastHeartbeat == nil || !reply.Txn.LastHeartbeat.Equal(*hBR.Txn.LastHeartbeat) {
-			t.Errorf("expected heartbeats to remain equal: %+v != %+v",
-				reply.Txn.LastHeartbeat, hBR.Txn.LastHeartbeat)
+		if exists := reply.(*roachpb.ContainsRangeResponse).Exists; exists != test.exists {
+			t.Errorf("%d: expected [%s,%s) to contain a key: %t; got %t", i, test.start, test.end, test.exists, exists)
 		}
 	}
 }
 
-// TestEndTransactionWithPushedTimestamp verifies that txn can be
-// ended (both commit or abort) correctly when the commit timestamp is
-// greater than the transaction timestamp, depending on the isolation
-// level.
-func TestEndTransactionWithPushedTimestamp(t *testing.T) {
+// TestRangeScanVersions verifies that ScanVersions returns the history of
+// each key in write order, restricted to the requested timestamp window.
+func TestRangeScanVersions(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
+	// Write "a" three times and then delete it; write "b" once.
+	var tss []roachpb.Timestamp
+	write := func(args roachpb.Request) {
+		ts := tc.clock.Now()
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Timestamp: ts,
+		}, args); err != nil {
+			t.Fatal(err)
+		}
+		tss = append(tss, ts)
+	}
+	for _, v := range []string{"1", "2", "3"} {
+		pArgs := putArgs(roachpb.Key("a"), []byte(v))
+		write(&pArgs)
+	}
+	dArgs := deleteArgs(roachpb.Key("a"))
+	write(&dArgs)
+	pArgs := putArgs(roachpb.Key("b"), []byte("4"))
+	write(&pArgs)
+
+	scan := func(minTS, maxTS roachpb.Timestamp) []string {
+		svArgs := &roachpb.ScanVersionsRequest{
+			Span:         roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("z")},
+			MinTimestamp: minTS,
+			MaxTimestamp: maxTS,
+		}
+		reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Timestamp: tc.clock.Now(),
+		}, svArgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var history []string
+		for _, kv := range reply.(*roachpb.ScanVersionsResponse).Rows {
+			val := "-"
+			if !kv.Value.IsTombstone() {
+				b, err := kv.Value.GetBytes()
+				if err != nil {
+					t.Fatal(err)
+				}
+				val = string(b)
+			}
+			history = append(history, string(kv.Key)+val)
+		}
+		return history
+	}
+
 	testCases := []struct {
-		commit    bool
-		isolation roachpb.IsolationType
-		expErr    bool
+		minTS, maxTS roachpb.Timestamp
+		expected     []string
 	}{
-		{true, roachpb.SERIALIZABLE, true},
-		{true, roachpb.SNAPSHOT, false},
-		{false, roachpb.SERIALIZABLE, false},
-		{false, roachpb.SNAPSHOT, false},
+		// A zero max timestamp reads up to the request timestamp.
+		{roachpb.ZeroTimestamp, roachpb.ZeroTimestamp, []string{"a1", "a2", "a3", "a-", "b4"}},
+		{tss[1], tss[3], []string{"a2", "a3", "a-"}},
+		{tss[0], tss[0], []string{"a1"}},
+		{tss[3].Add(0, 1), roachpb.ZeroTimestamp, []string{"b4"}},
 	}
-	key := []byte("a")
-	for _, test := range testCases {
-		txn := newTransaction("test", key, 1, test.isolation, tc.clock)
-		bt, btH := beginTxnArgs(key, txn)
-		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
-			t.Fatal(err)
+	for i, test := range testCases {
+		if history := scan(test.minTS, test.maxTS); !reflect.DeepEqual(history, test.expected) {
+			t.Errorf("%d: expected %q; got %q", i, test.expected, history)
 		}
-		// End the transaction with args timestamp moved forward in time.
-		args, h := endTxnArgs(txn, test.commit)
-		// TODO(tschottdorf): this test is pretty dirty. It should really
-		// write a txn entry and then try to commit that; the way it works
-		// now is by supplying its txn (which has its own timestamp) at
-		// another timestamp. This constrains changes we want to make on
-		// how timestamps work.
-		tc.manualClock.Set(1)
-		ts := tc.clock.Now()
-		h.Timestamp = ts
-		txn.Sequence++
-
-		resp, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args)
+	}
 
-		if test.expErr {
-			if err == nil {
-				t.Errorf("expected error")
-			}
-			if _, ok := err.(*roachpb.TransactionRetryError); !ok {
-				t.Errorf("expected retry error; got %s", err)
-			}
-		} else {
-			if err != nil {
-				t.Errorf("unexpected error: %s", err)
-			}
-			expStatus := roachpb.COMMITTED
-			if !test.commit {
-				expStatus = roachpb.ABORTED
-			}
-			reply := resp.(*roachpb.EndTransactionResponse)
-			if reply.Txn.Status != expStatus {
-				t.Errorf("expected transaction status to be %s; got %s", expStatus, reply.Txn.Status)
-			}
-		}
+	// A window extending beyond the read timestamp is rejected.
+	svArgs := &roachpb.ScanVersionsRequest{
+		Span:         roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("z")},
+		MaxTimestamp: tc.clock.Now().Add(1000, 0),
+	}
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+		Timestamp: tc.clock.Now(),
+	}, svArgs); !testutils.IsError(err, "exceeds read timestamp") {
+		t.Errorf("expected error for max timestamp beyond read timestamp; got %v", err)
 	}
 }
 
-// TestEndTransactionWithIncrementedEpoch verifies that txn ended with
-// a higher epoch (and priority) correctly assumes the higher epoch.
-func TestEndTransactionWithIncrementedEpoch(t *testing.T) {
+// slowEngine wraps an engine so that every positioning call on its
+// iterators advances a manual clock by step.
+type slowEngine struct {
+	engine.Engine
+	clock *hlc.ManualClock
+	step  time.Duration
+}
+
+func (e slowEngine) NewIterator(prefix bool) engine.Iterator {
+	return slowIterator{Iterator: e.Engine.NewIterator(prefix), e: e}
+}
+
+type slowIterator struct {
+	engine.Iterator
+	e slowEngine
+}
+
+func (i slowIterator) Seek(key engine.MVCCKey) {
+	i.e.clock.Increment(i.e.step.Nanoseconds())
+	i.Iterator.Seek(key)
+}
+
+func (i slowIterator) Next() {
+	i.e.clock.Increment(i.e.step.Nanoseconds())
+	i.Iterator.Next()
+}
+
+// TestRangeScanTimeBudget verifies that a scan which runs past the store's
+// time budget returns the rows read so far, flagged as timed out, and that
+// the scan can be resumed from the returned key.
+func TestRangeScanTimeBudget(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	key := []byte("a")
-	txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-	bt, btH := beginTxnArgs(key, txn)
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
-		t.Fatal(err)
+	const numKeys = 10
+	for i := 0; i < numKeys; i++ {
+		pArgs := putArgs(roachpb.Key(fmt.Sprintf("k%02d", i)), []byte("value"))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
 	}
 
-	// Start out with a heartbeat to the transaction.
-	hBA, h := heartbeatArgs(txn)
-	txn.Sequence++
-
-	_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &hBA)
+	if budget := tc.store.ctx.ScanTimeBudget; budget != defaultScanTimeBudget {
+		t.Fatalf("expected default scan time budget %s; got %s", defaultScanTimeBudget, budget)
+	}
+	tc.store.ctx.ScanTimeBudget = time.Second
+	slow := slowEngine{Engine: tc.engine, clock: tc.manualClock, step: 400 * time.Millisecond}
+	h := roachpb.Header{Timestamp: tc.clock.Now()}
+	reply, _, err := tc.rng.Scan(slow, h, scanArgs(roachpb.Key("k"), roachpb.Key("l")))
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
+	}
+	if !reply.TimedOut {
+		t.Fatal("expected scan to time out")
+	}
+	n := len(reply.Rows)
+	if n == 0 || n >= numKeys {
+		t.Fatalf("expected a partial result; got %d of %d rows", n, numKeys)
+	}
+	if lastKey := reply.Rows[n-1].Key; bytes.Compare(lastKey, reply.ResumeKey) >= 0 {
+		t.Fatalf("expected resume key after %q; got %q", lastKey, reply.ResumeKey)
 	}
 
-	// Now end the txn with increased epoch and priority.
-	args, h := endTxnArgs(txn, true)
-	h.Txn.Epoch = txn.Epoch + 1
-	h.Txn.Priority = txn.Priority + 1
-
-	txn.Sequence++
-	resp, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args)
+	// A scan which filters out every row times out as well.
+	filtered := scanArgs(roachpb.Key("k"), roachpb.Key("l"))
+	filtered.Suffix = []byte("none")
+	fReply, _, err := tc.rng.Scan(slow, h, filtered)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
-	reply := resp.(*roachpb.EndTransactionResponse)
-	if reply.Txn.Status != roachpb.COMMITTED {
-		t.Errorf("expected transaction status to be COMMITTED; got %s", reply.Txn.Status)
+	if !fReply.TimedOut || len(fReply.Rows) != 0 || fReply.ResumeKey == nil {
+		t.Fatalf("expected filtered scan to time out without rows; got timed out %t, %d rows, resume key %q",
+			fReply.TimedOut, len(fReply.Rows), fReply.ResumeKey)
 	}
-	if reply.Txn.Epoch != txn.Epoch {
-		t.Errorf("expected epoch to equal %d; got %d", txn.Epoch, reply.Txn.Epoch)
+
+	// Without a budget, resuming returns the remaining rows.
+	tc.store.ctx.ScanTimeBudget = 0
+	rest, _, err := tc.rng.Scan(slow, h, scanArgs(reply.ResumeKey, roachpb.Key("l")))
+	if err != nil {
+		t.Fatal(err)
 	}
-	if reply.Txn.Priority != txn.Priority {
-		t.Errorf("expected priority to equal %d; got %d", txn.Priority, reply.Txn.Priority)
+	if rest.TimedOut || rest.ResumeKey != nil {
+		t.Errorf("expected complete scan; got timed out %t, resume key %q", rest.TimedOut, rest.ResumeKey)
+	}
+	if total := n + len(rest.Rows); total != numKeys {
+		t.Errorf("expected %d rows in total; got %d", numKeys, total)
 	}
 }
 
-// TestEndTransactionWithErrors verifies various error conditions
-// are checked such as transaction already being committed or
-// aborted, or timestamp or epoch regression.
-func TestEndTransactionWithErrors(t *testing.T) {
+// TestRangeScanTombstones verifies that deleted keys are omitted from
+// scans unless tombstones are requested, in which case they are returned
+// as rows without data.
+func TestRangeScanTombstones(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	regressTS := tc.clock.Now()
-	tc.manualClock.Set(1)
-	txn := newTransaction("test", roachpb.Key(""), 1, roachpb.SERIALIZABLE, tc.clock)
+	for _, k := range []string{"a", "b", "c", "d"} {
+		pArgs := putArgs(roachpb.Key(k), []byte(k))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, k := range []string{"b", "d"} {
+		dArgs := deleteArgs(roachpb.Key(k))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &dArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
 
 	testCases := []struct {
-		key          roachpb.Key
-		existStatus  roachpb.TransactionStatus
-		existEpoch   uint32
-		existTS      roachpb.Timestamp
-		expErrRegexp string
+		tombstones bool
+		max        int64
+		expKeys    []string
+		expDeleted []bool
 	}{
-		{roachpb.Key("a"), roachpb.COMMITTED, txn.Epoch, txn.Timestamp, "txn \"test\" id=.*: already committed"},
-		{roachpb.Key("b"), roachpb.ABORTED, txn.Epoch, txn.Timestamp, "txn aborted \"test\" id=.*"},
-		{roachpb.Key("c"), roachpb.PENDING, txn.Epoch + 1, txn.Timestamp, "txn \"test\" id=.*: epoch regression: 0"},
-		{roachpb.Key("d"), roachpb.PENDING, txn.Epoch, regressTS, `txn "test" id=.*: timestamp regression: 0.000000001,\d+`},
+		{false, 0, []string{"a", "c"}, []bool{false, false}},
+		{true, 0, []string{"a", "b", "c", "d"}, []bool{false, true, false, true}},
+		{true, 2, []string{"a", "b"}, []bool{false, true}},
 	}
-	for _, test := range testCases {
-		// Establish existing txn state by writing directly to range engine.
-		var existTxn roachpb.Transaction
-		proto.Merge(&existTxn, txn)
-		existTxn.Key = test.key
-		existTxn.Status = test.existStatus
-		existTxn.Epoch = test.existEpoch
-		existTxn.Timestamp = test.existTS
-		txnKey := keys.TransactionKey(test.key, txn.ID)
-		if err := engine.MVCCPutProto(tc.rng.store.Engine(), nil, txnKey, roachpb.ZeroTimestamp,
-			nil, &existTxn); err != nil {
-			t.Fatal(err)
+	for i, test := range testCases {
+		sArgs := scanArgs([]byte("a"), []byte("z"))
+		sArgs.ReturnTombstones = test.tombstones
+		sArgs.MaxResults = test.max
+		reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &sArgs)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
 		}
-
-		// End the transaction, verify expected error.
-		txn.Key = test.key
-		args, h := endTxnArgs(txn, true)
-		txn.Sequence++
-
-		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args); !testutils.IsError(err, test.expErrRegexp) {
-			t.Errorf("expected error:\n%s\nto match:\n%s", err, test.expErrRegexp)
+		var keys []string
+		var deleted []bool
+		for _, kv := range reply.(*roachpb.ScanResponse).Rows {
+			keys = append(keys, string(kv.Key))
+			deleted = append(deleted, kv.Value.IsTombstone())
+			if kv.Value.Timestamp.Equal(roachpb.ZeroTimestamp) {
+				t.Errorf("%d: expected row %q to carry a timestamp", i, kv.Key)
+			}
+		}
+		if !reflect.DeepEqual(keys, test.expKeys) {
+			t.Errorf("%d: expected keys %q; got %q", i, test.expKeys, keys)
+		}
+		if !reflect.DeepEqual(deleted, test.expDeleted) {
+			t.Errorf("%d: expected tombstones %v; got %v", i, test.expDeleted, deleted)
 		}
 	}
 }
 
-// TestEndTransactionGC verifies that a transaction record is immediately
-// garbage-collected upon EndTransaction iff all of the supplied intents are
-// local relative to the transaction record's location.
-func TestEndTransactionLocalGC(t *testing.T) {
+// TestRangeNoTSCacheInconsistent verifies that the timestamp cache
+// is not affected by inconsistent reads.
+func TestRangeNoTSCacheInconsistent(t *testing.T) {
 	defer leaktest.AfterTest(t)
-	defer setTxnAutoGC(true)()
-	defer func() { TestingCommandFilter = nil }()
-	TestingCommandFilter = func(_ roachpb.StoreID, args roachpb.Request, _ roachpb.Header) error {
-		// Make sure the direct GC path doesn't interfere with this test.
-		if args.Method() == roachpb.GC {
-			return util.Errorf("boom")
-		}
-		return nil
-	}
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
+	// Set clock to time 1s and do the read.
+	t0 := 1 * time.Second
+	tc.manualClock.Set(t0.Nanoseconds())
+	args := getArgs([]byte("a"))
+	ts := tc.clock.Now()
 
-	splitKey := roachpb.RKey("c")
-	splitTestRange(tc.store, splitKey, splitKey, t)
-	for i, test := range []struct {
-		intents []roachpb.Span
-		expGC   bool
-	}{
-		// Range inside.
-		{[]roachpb.Span{{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}}, true},
-		// Two intents inside.
-		{[]roachpb.Span{{Key: roachpb.Key("a")}, {Key: roachpb.Key("b")}}, true},
-		// Intent range spilling over right endpoint.
-		{[]roachpb.Span{{Key: roachpb.Key("a"), EndKey: splitKey.Next().AsRawKey()}}, false},
-		// Intent range completely outside.
-		{[]roachpb.Span{{Key: splitKey.AsRawKey(), EndKey: roachpb.Key("q")}}, false},
-		// Intent inside and outside.
-		{[]roachpb.Span{{Key: roachpb.Key("a")}, {Key: splitKey.AsRawKey()}}, false},
-	} {
-		key := roachpb.Key("a")
+	_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+		Timestamp:       ts,
+		ReadConsistency: roachpb.INCONSISTENT,
+	}, &args)
+
+	if err != nil {
+		t.Error(err)
+	}
+	pArgs := putArgs([]byte("a"), []byte("value"))
+
+	reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Timestamp: roachpb.ZeroTimestamp.Add(0, 1)}, &pArgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pReply := reply.(*roachpb.PutResponse)
+	if pReply.Timestamp.WallTime == tc.clock.Timestamp().WallTime {
+		t.Errorf("expected write timestamp not to upgrade to 1s; got %s", pReply.Timestamp)
+	}
+}
+
+// TestRangeNoTSCacheUpdateOnFailure verifies that read and write
+// commands do not update the timestamp cache if they result in
+// failure.
+func TestRangeNoTSCacheUpdateOnFailure(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	// Test for both read & write attempts.
+	for i, read := range []bool{true, false} {
+		key := roachpb.Key(fmt.Sprintf("key-%d", i))
+
+		// Start by laying down an intent to trip up future read or write to same key.
+		pArgs := putArgs(key, []byte("value"))
 		txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-		bt, btH := beginTxnArgs(key, txn)
-		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
-			t.Fatal(err)
+
+		reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Txn: txn,
+		}, &pArgs)
+		if err != nil {
+			t.Fatalf("test %d: %s", i, err)
 		}
-		args, h := endTxnArgs(txn, true)
-		args.IntentSpans = test.intents
-		txn.Sequence++
-		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args); err != nil {
-			t.Fatal(err)
+		pReply := reply.(*roachpb.PutResponse)
+
+		// Now attempt read or write.
+		args := readOrWriteArgs(key, read)
+		ts := tc.clock.Now() // later timestamp
+
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Timestamp: ts,
+		}, args); err == nil {
+			t.Errorf("test %d: expected failure", i)
 		}
-		var readTxn roachpb.Transaction
-		txnKey := keys.TransactionKey(txn.Key, txn.ID)
-		ok, err := engine.MVCCGetProto(tc.rng.store.Engine(), txnKey, roachpb.ZeroTimestamp,
-			true /* consistent */, nil /* txn */, &readTxn)
-		if err != nil {
-			t.Fatal(err)
+
+		// Write the intent again -- should not have its timestamp upgraded!
+		txn.Sequence++
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Txn: txn,
+		}, &pArgs); err != nil {
+			t.Fatalf("test %d: %s", i, err)
 		}
-		if !ok != test.expGC {
-			t.Errorf("%d: unexpected gc'ed: %t", i, !ok)
+		if !pReply.Timestamp.Equal(txn.Timestamp) {
+			t.Errorf("expected timestamp not to advance %s != %s", pReply.Timestamp, txn.Timestamp)
 		}
 	}
 }
 
-func setupResolutionTest(t *testing.T, tc testContext, key roachpb.Key, splitKey roachpb.RKey) (*Replica, *roachpb.Transaction) {
-	// Split the range and create an intent in each range.
-	// The keys of the two intents are next to each other.
-	newRng := splitTestRange(tc.store, splitKey, splitKey, t)
+// TestRangeNoTimestampIncrementWithinTxn verifies that successive
+// read and write commands within the same transaction do not cause
+// the write to receive an incremented timestamp.
+func TestRangeNoTimestampIncrementWithinTxn(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
 
+	// Test for both read & write attempts.
+	key := roachpb.Key("a")
 	txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-	bt, btH := beginTxnArgs(key, txn)
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
+
+	// Start with a read to warm the timestamp cache.
+	gArgs := getArgs(key)
+
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+		Txn: txn,
+	}, &gArgs); err != nil {
 		t.Fatal(err)
 	}
+
+	// Now try a write and verify timestamp isn't incremented.
 	pArgs := putArgs(key, []byte("value"))
-	h := roachpb.Header{Txn: txn}
+
 	txn.Sequence++
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &pArgs); err != nil {
+	reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+		Txn: txn,
+	}, &pArgs)
+	if err != nil {
 		t.Fatal(err)
 	}
+	pReply := reply.(*roachpb.PutResponse)
+	if !pReply.Timestamp.Equal(txn.Timestamp) {
+		t.Errorf("expected timestamp to remain %s; got %s", txn.Timestamp, pReply.Timestamp)
+	}
 
-	pArgs = putArgs(splitKey.AsRawKey(), []byte("value"))
+	// Resolve the intent.
+	rArgs := &roachpb.ResolveIntentRequest{
+		Span:      *pArgs.Header(),
+		IntentTxn: *txn,
+	}
 	txn.Sequence++
-	if _, err := client.SendWrappedWith(newRng, newRng.context(), h, &pArgs); err != nil {
+	rArgs.IntentTxn.Status = roachpb.COMMITTED
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Txn: txn, Timestamp: txn.Timestamp}, rArgs); err != nil {
 		t.Fatal(err)
 	}
 
-	// End the transaction and resolve the intents.
-	args, h := endTxnArgs(txn, true /* commit */)
-	args.IntentSpans = []roachpb.Span{{Key: key, EndKey: splitKey.Next().AsRawKey()}}
-	txn.Sequence++
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args); err != nil {
-		t.Fatal(err)
+	// Finally, try a non-transactional write and verify timestamp is incremented.
+	ts := txn.Timestamp
+	expTS := ts
+	expTS.Logical++
+
+	if reply, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Timestamp: ts}, &pArgs); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	pReply = reply.(*roachpb.PutResponse)
+	if !pReply.Timestamp.Equal(expTS) {
+		t.Errorf("expected timestamp to increment to %s; got %s", expTS, pReply.Timestamp)
 	}
-	return newRng, txn
 }
 
-// TestEndTransactionResolveOnlyLocalIntents verifies that an end transaction
-// request resolves only local intents within the same batch.
-func TestEndTransactionResolveOnlyLocalIntents(t *testing.T) {
+// TestRangeSequenceCacheReadError verifies that an error is returned to the
+// client in the event that a sequence cache entry is found but is not
+// decodable.
+func TestRangeSequenceCacheReadError(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
-	key := roachpb.Key("a")
-	splitKey := roachpb.RKey(key).Next()
-	defer func() { TestingCommandFilter = nil }()
-	TestingCommandFilter = func(_ roachpb.StoreID, args roachpb.Request, _ roachpb.Header) error {
-		if args.Method() == roachpb.ResolveIntentRange && args.Header().Key.Equal(splitKey.AsRawKey()) {
-			return util.Errorf("boom")
-		}
-		return nil
-	}
 	tc.Start(t)
 	defer tc.Stop()
 
-	newRng, txn := setupResolutionTest(t, tc, key, splitKey)
+	k := []byte("a")
+	txn := newTransaction("test", k, 10, roachpb.SERIALIZABLE, tc.clock)
+	args := incrementArgs(k, 1)
+	txn.Sequence = 1
 
-	// Check if the intent in the other range has not yet been resolved.
-	gArgs := getArgs(splitKey)
-	_, err := client.SendWrapped(newRng, newRng.context(), &gArgs)
-	if _, ok := err.(*roachpb.WriteIntentError); !ok {
-		t.Errorf("expected write intent error, but got %s", err)
+	if _, pErr := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+		Txn: txn,
+	}, &args); pErr != nil {
+		t.Fatal(pErr)
 	}
 
-	txn.Sequence++
-	hbArgs, h := heartbeatArgs(txn)
-	reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &hbArgs)
+	// Overwrite sequence cache entry with garbage for the last op.
+	key := keys.SequenceCacheKey(tc.rng.Desc().RangeID, txn.ID, uint32(txn.Epoch), txn.Sequence)
+	// Make garbageKey sort before key (we've chosen Sequence=1 above,
+	// the last byte of which isn't \x00); add an extra byte of garbage.
+	garbageKey := append(roachpb.Key(nil), key[:len(key)-1]...)
+	garbageKey = append(garbageKey, '\x00', '!')
+	err := engine.MVCCPut(tc.engine, nil, garbageKey, roachpb.ZeroTimestamp, roachpb.MakeValueFromString("never read in this test"), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	hbResp := reply.(*roachpb.HeartbeatTxnResponse)
-	expIntents := []roachpb.Span{{Key: splitKey.AsRawKey(), EndKey: splitKey.AsRawKey().Next()}}
-	if !reflect.DeepEqual(hbResp.Txn.Intents, expIntents) {
-		t.Fatalf("expected persisted intents %v, got %v",
-			expIntents, hbResp.Txn.Intents)
+
+	// Now try increment again and verify error.
+	_, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+		Txn: txn,
+	}, &args)
+	if !testutils.IsError(err, "replica corruption") {
+		t.Fatal(err)
 	}
 }
 
-// TestEndTransactionDirectGC verifies that after successfully resolving the
-// external intents of a transaction after EndTransaction, the transaction and
-// sequence cache records are purged.
-func TestEndTransactionDirectGC(t *testing.T) {
+// TestRangeSequenceCacheStoredError verifies that if a cached entry is present,
+// a transaction restart error is returned.
+func TestRangeSequenceCacheStoredTxnRetryError(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
-	key := roachpb.Key("a")
-	splitKey := roachpb.RKey(key).Next()
 	tc.Start(t)
 	defer tc.Stop()
 
-	_, txn := setupResolutionTest(t, tc, key, splitKey)
+	key := []byte("a")
+	for i, pastError := range []error{errors.New("boom"), nil} {
+		txn := newTransaction("test", key, 10, roachpb.SERIALIZABLE, tc.clock)
+		txn.Sequence = uint32(1 + i)
+		_ = tc.rng.sequence.Put(tc.engine, txn.ID, uint32(txn.Epoch), txn.Sequence, txn.Key, txn.Timestamp, pastError)
 
-	util.SucceedsWithin(t, 5*time.Second, func() error {
-		if gr, _, err := tc.rng.Get(tc.engine, roachpb.Header{}, roachpb.GetRequest{Span: roachpb.Span{Key: keys.TransactionKey(txn.Key, txn.ID)}}); err != nil {
-			return err
-		} else if gr.Value != nil {
-			return util.Errorf("txn entry still there: %+v", gr)
+		args := incrementArgs(key, 1)
+		_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Txn: txn,
+		}, &args)
+		if _, ok := err.(*roachpb.TransactionRetryError); !ok {
+			t.Fatalf("%d: unexpected error %v", i, err)
 		}
+	}
 
-		var entry roachpb.SequenceCacheEntry
-		if seq, _, err := tc.rng.sequence.Get(tc.engine, txn.ID, &entry); err != nil {
-			return err
-		} else if seq > 0 {
-			return util.Errorf("sequence cache still populated: %v", entry)
+	// Try the same again, this time verifying that the Put will actually
+	// populate the cache appropriately.
+	txn := newTransaction("test", key, 10, roachpb.SERIALIZABLE, tc.clock)
+	txn.Sequence = 321
+	args := incrementArgs(key, 1)
+	try := func() error {
+		_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Txn: txn,
+		}, &args)
+		return err
+	}
+
+	if err := try(); err != nil {
+		t.Fatal(err)
+	}
+	txn.Timestamp.Forward(txn.Timestamp.Add(10, 10)) // can't hurt
+	{
+		err := try()
+		if _, ok := err.(*roachpb.TransactionRetryError); !ok {
+			t.Fatal(err)
 		}
+	}
 
-		return nil
-	})
+	//  Pretend we restarted by increasing the epoch. That's all that's needed.
+	txn.Epoch++
+	if err := try(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Now increase the sequence as well. Still good to go.
+	txn.Sequence++
+	if err := try(); err != nil {
+		t.Fatal(err)
+	}
 }
 
-// TestEndTransactionDirectGCFailure verifies that no immediate GC takes place
-// if external intents can't be resolved (see also TestEndTransactionDirectGC).
-func TestEndTransactionDirectGCFailure(t *testing.T) {
+// TestRangeSequenceCacheReplay verifies that replaying a transactional
+// write with the same sequence number does not execute it a second
+// time, whether or not it succeeded originally. Instead, the replay is
+// answered from the sequence cache with a transaction retry error.
+func TestRangeSequenceCacheReplay(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
-	key := roachpb.Key("a")
-	splitKey := roachpb.RKey(key).Next()
-	var count int64
-	defer func() { TestingCommandFilter = nil }()
-	TestingCommandFilter = func(_ roachpb.StoreID, args roachpb.Request, _ roachpb.Header) error {
-		if args.Method() == roachpb.ResolveIntentRange && args.Header().Key.Equal(splitKey.AsRawKey()) {
-			atomic.AddInt64(&count, 1)
-			return util.Errorf("boom")
-		} else if args.Method() == roachpb.GC {
-			t.Fatalf("unexpected GCRequest: %+v", args)
-		}
-		return nil
-	}
 	tc.Start(t)
 	defer tc.Stop()
 
-	setupResolutionTest(t, tc, key, splitKey)
-
-	// Now test that no GCRequest is issued. We can't test that directly (since
-	// it's completely asynchronous), so we first make sure ResolveIntent
-	// happened and subsequently issue a bogus Put which is likely to make it
-	// into Raft only after a rogue GCRequest (at least sporadically), which
-	// would trigger a Fatal from the command filter.
-	util.SucceedsWithin(t, 5*time.Second, func() error {
-		if atomic.LoadInt64(&count) == 0 {
-			return util.Errorf("intent resolution not attempted yet")
-		} else if err := tc.store.DB().Put("panama", "banana"); err != nil {
-			return err
-		}
-		return nil
-	})
-}
-
-// TestSequenceCachePoisonOnResolve verifies that when an intent is pushed into
-// the future or aborted, the sequence cache on the respective Range is
-// poisoned and the pushee is presented with a txn retry or abort on its next
-// contact with the Range in the same epoch.
-func TestSequenceCachePoisonOnResolve(t *testing.T) {
-	defer leaktest.AfterTest(t)
 	key := roachpb.Key("a")
+	txn := newTransaction("test", key, 10, roachpb.SERIALIZABLE, tc.clock)
+	send := func(args roachpb.Request) (roachpb.Response, error) {
+		return client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Txn: txn,
+		}, args)
+	}
 
-	// Isolation of the pushee and whether we're going to abort it.
-	// Run the actual meat of the test, which pushes the pushee and
-	// checks whether we get the correct behaviour as it touches the
-	// Range again.
-	run := func(abort bool, iso roachpb.IsolationType) {
-		tc := testContext{}
-		tc.Start(t)
-		defer tc.Stop()
+	// A successful increment is not applied again on replay.
+	txn.Sequence = 1
+	iArgs := incrementArgs(key, 1)
+	if _, err := send(&iArgs); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := send(&iArgs); err == nil {
+		t.Fatal("expected replayed increment to fail")
+	} else if _, ok := err.(*roachpb.TransactionRetryError); !ok {
+		t.Fatalf("expected TransactionRetryError on replay; got %v", err)
+	}
+	txn.Sequence = 2
+	gArgs := getArgs(key)
+	reply, err := send(&gArgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := reply.(*roachpb.GetResponse).Value.GetInt(); err != nil || v != 1 {
+		t.Fatalf("expected increment to be applied once; got %d, %v", v, err)
+	}
 
-		pushee := newTransaction("test", key, 1, iso, tc.clock)
-		pusher := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-		pusher.Priority = 2
-		pushee.Priority = 1 // pusher will win
+	// A failed conditional put is not re-evaluated on replay either: the
+	// condition would fail again, but the replay never gets that far.
+	txn.Sequence = 3
+	cpArgs := roachpb.ConditionalPutRequest{
+		Span: roachpb.Span{
+			Key: key,
+		},
+		Value:    roachpb.MakeValueFromString("v"),
+		ExpValue: &roachpb.Value{},
+	}
+	cpArgs.ExpValue.SetInt(2)
+	if _, err := send(&cpArgs); err == nil {
+		t.Fatal("expected conditional put to fail")
+	} else if _, ok := err.(*roachpb.ConditionFailedError); !ok {
+		t.Fatalf("expected ConditionFailedError; got %v", err)
+	}
+	if _, err := send(&cpArgs); err == nil {
+		t.Fatal("expected replayed conditional put to fail")
+	} else if _, ok := err.(*roachpb.TransactionRetryError); !ok {
+		t.Fatalf("expected TransactionRetryError on replay; got %v", err)
+	}
+}
 
-		inc := func(actor *roachpb.Transaction, k roachpb.Key) (*roachpb.IncrementResponse, error) {
-			actor.Sequence++
-			reply, err := client.SendWrappedWith(tc.store, nil, roachpb.Header{
-				Txn:     actor,
-				RangeID: 1,
-			}, &roachpb.IncrementRequest{Span: roachpb.Span{Key: k}, Increment: 123})
-			if err != nil {
-				return nil, err
-			}
-			return reply.(*roachpb.IncrementResponse), err
-		}
+// TestEndTransactionDeadline verifies that EndTransaction respects the
+// transaction deadline.
+func TestEndTransactionDeadline(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
 
-		get := func(actor *roachpb.Transaction, k roachpb.Key) error {
-			actor.Sequence++
-			_, err := client.SendWrappedWith(tc.store, nil, roachpb.Header{
-				Txn:       actor,
-				Timestamp: actor.Timestamp,
-				RangeID:   1,
-			}, &roachpb.GetRequest{Span: roachpb.Span{Key: k}})
-			return err
-		}
+	// 4 cases: no deadline, past deadline, equal deadline, future deadline.
+	for i := 0; i < 4; i++ {
+		key := roachpb.Key("key: " + strconv.Itoa(i))
+		txn := newTransaction("txn: "+strconv.Itoa(i), key, 1, roachpb.SERIALIZABLE, tc.clock)
 
-		// Begin the pushee's transaction and write an intent.
-		btArgs, btH := beginTxnArgs(key, pushee)
-		if _, err := client.SendWrappedWith(tc.Sender(), nil,
-			btH, &btArgs); err != nil {
+		btArgs, btHeader := beginTxnArgs(key, txn)
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btHeader, &btArgs); err != nil {
 			t.Fatal(err)
 		}
-		if _, err := inc(pushee, key); err != nil {
-			t.Fatal(err)
+
+		etArgs, etHeader := endTxnArgs(txn, true /* commit */)
+		switch i {
+		case 0:
+			// No deadline.
+		case 1:
+			// Past deadline.
+			ts := txn.Timestamp.Prev()
+			etArgs.Deadline = &ts
+		case 2:
+			// Equal deadline.
+			etArgs.Deadline = &txn.Timestamp
+		case 3:
+			// Future deadline.
+			ts := txn.Timestamp.Next()
+			etArgs.Deadline = &ts
 		}
 
-		// Have the pusher run into the intent. That pushes our pushee and resolves
-		// the intent, which in turn should poison the sequence cache.
-		var assert func(error)
-		if abort {
-			// Write/Write conflict will abort pushee.
-			if _, err := inc(pusher, key); err != nil {
-				t.Fatal(err)
-			}
-			assert = func(err error) {
+		{
+			txn.Sequence++
+			_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), etHeader, &etArgs)
+			switch i {
+			case 0:
+				// No deadline.
+				if err != nil {
+					t.Error(err)
+				}
+			case 1:
+				// Past deadline.
 				if _, ok := err.(*roachpb.TransactionAbortedError); !ok {
-					t.Fatalf("abort=%t, iso=%s: expected txn abort, got %s", abort, iso, err)
+					t.Errorf("expected TransactionAbortedError but got %T: %s", err, err)
 				}
-			}
-		} else if iso == roachpb.SNAPSHOT {
-			// At SNAPSHOT, we shouldn't be restart-poisoned.
-			assert = func(err error) {
+			case 2:
+				// Equal deadline.
 				if err != nil {
-					t.Fatalf("abort=%t, iso=%s: unexpected: %s", abort, iso, err)
+					t.Error(err)
 				}
-			}
-		} else {
-			// Trigger a Read/Write conflict which pushes pushee's timestamp.
-			if err := get(pusher, key); err != nil {
-				t.Fatal(err)
-			}
-			assert = func(err error) {
-				if _, ok := err.(*roachpb.TransactionRetryError); !ok {
-					t.Fatalf("abort=%t, iso=%s: expected txn retry, got %s",
-						abort, iso, err)
+			case 3:
+				// Future deadline.
+				if err != nil {
+					t.Error(err)
 				}
 			}
 		}
-
-		// We shouldn't be able to read or write within the transaction on this
-		// Range.
-		err := get(pushee, key)
-		assert(err)
-		_, err = inc(pushee, key)
-		assert(err)
-		// Still poisoned (on any key on the Range).
-		err = get(pushee, key.Next())
-		assert(err)
-		_, err = inc(pushee, key.Next())
-		assert(err)
-
-		// Pretend we're coming back. This works regardless of retry or restart,
-		// but obviously in practice only on a retry would the Txn actually come
-		// back with an increased epoch.
-		pushee.Epoch++
-		if _, err := inc(pushee, roachpb.Key("b")); err != nil {
-			t.Fatal(err)
-		}
-	}
-
-	for _, abort := range []bool{false, true} {
-		run(abort, roachpb.SERIALIZABLE)
-		run(abort, roachpb.SNAPSHOT)
 	}
 }
 
-// TestPushTxnBadKey verifies that args.Key equals args.PusheeTxn.ID.
-func TestPushTxnBadKey(t *testing.T) {
+// TestEndTransactionWithMalformedSplitTrigger verifies an
+// EndTransaction call with a malformed commit trigger fails.
+func TestEndTransactionWithMalformedSplitTrigger(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	pusher := newTransaction("test", roachpb.Key("a"), 1, roachpb.SERIALIZABLE, tc.clock)
-	pushee := newTransaction("test", roachpb.Key("b"), 1, roachpb.SERIALIZABLE, tc.clock)
+	key := roachpb.Key("foo")
+	txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+	bt, h := beginTxnArgs(key, txn)
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &bt); err != nil {
+		t.Fatal(err)
+	}
+	pArgs := putArgs(key, []byte("only here to make this a rw transaction"))
+	txn.Sequence++
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+		Txn: txn,
+	}, &pArgs); err != nil {
+		t.Fatal(err)
+	}
 
-	args := pushTxnArgs(pusher, pushee, roachpb.PUSH_ABORT)
-	args.Key = pusher.Key
+	args, h := endTxnArgs(txn, true /* commit */)
+	// Make an EndTransaction request which would fail if not
+	// stripped. In this case, we set the start key to "bar" for a
+	// split of the default range; start key must be "" in this case.
+	args.InternalCommitTrigger = &roachpb.InternalCommitTrigger{
+		SplitTrigger: &roachpb.SplitTrigger{
+			UpdatedDesc: roachpb.RangeDescriptor{StartKey: roachpb.RKey("bar")},
+		},
+	}
 
-	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args); !testutils.IsError(err, ".*should match pushee.*") {
-		t.Errorf("unexpected error %s", err)
+	txn.Sequence++
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args); !testutils.IsError(err, "range does not match splits") {
+		t.Errorf("expected range does not match splits error; got %s", err)
 	}
 }
 
-// TestPushTxnAlreadyCommittedOrAborted verifies success
-// (noop) in event that pushee is already committed or aborted.
-func TestPushTxnAlreadyCommittedOrAborted(t *testing.T) {
+// TestRangeTxnOnlyMethodsRequireTxn verifies that the methods which may
+// only be carried out within a transaction are rejected when the batch
+// header carries no transaction, and succeed when it does.
+func TestRangeTxnOnlyMethodsRequireTxn(t *testing.T) {
 	defer leaktest.AfterTest(t)
-	// This test simulates running into an open intent and resolving it using
-	// the transaction record. If we auto-gc'ed entries here, the entry would
-	// be deleted and the intents resolved instantaneously on successful commit
-	// (since they're on the same Range). Could split the range and have
-	// non-local intents if we ever wanted to get rid of this.
-	defer setTxnAutoGC(false)()
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	for i, status := range []roachpb.TransactionStatus{roachpb.COMMITTED, roachpb.ABORTED} {
-		key := roachpb.Key(fmt.Sprintf("key-%d", i))
-		pusher := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-		pushee := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-		pusher.Priority = 1
-		pushee.Priority = 2 // pusher will lose, meaning we shouldn't push unless pushee is already ended.
-
-		// Begin the pushee's transaction.
-		btArgs, btH := beginTxnArgs(key, pushee)
-		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &btArgs); err != nil {
-			t.Fatal(err)
-		}
-		// End the pushee's transaction.
-		etArgs, h := endTxnArgs(pushee, status == roachpb.COMMITTED)
-		pushee.Sequence++
-		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &etArgs); err != nil {
-			t.Fatal(err)
+	key := roachpb.Key("a")
+	txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+	bt, btH := beginTxnArgs(key, txn)
+	hb, hbH := heartbeatArgs(txn)
+	et, etH := endTxnArgs(txn, true /* commit */)
+	testCases := []struct {
+		args roachpb.Request
+		h    roachpb.Header
+	}{
+		{&bt, btH},
+		{&hb, hbH},
+		{&et, etH},
+	}
+	for i, test := range testCases {
+		if !roachpb.RequiresTxn(test.args) {
+			t.Errorf("%d: expected %s to require a transaction", i, test.args.Method())
 		}
-
-		// Now try to push what's already committed or aborted.
-		args := pushTxnArgs(pusher, pushee, roachpb.PUSH_ABORT)
-		resp, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
-		if err != nil {
-			t.Fatal(err)
+		_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{}, test.args)
+		if !testutils.IsError(err, "no transaction specified to "+test.args.Method().String()) {
+			t.Errorf("%d: expected %s without transaction to fail; got %v", i, test.args.Method(), err)
 		}
-		reply := resp.(*roachpb.PushTxnResponse)
-		if reply.PusheeTxn.Status != status {
-			t.Errorf("expected push txn to return with status == %s; got %+v", status, reply.PusheeTxn)
+	}
+	for i, test := range testCases {
+		txn.Sequence++
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), test.h, test.args); err != nil {
+			t.Errorf("%d: %s", i, err)
 		}
 	}
+
+	// Transactional methods which don't require a transaction are still
+	// accepted without one.
+	pArgs := putArgs(key, []byte("value"))
+	if roachpb.RequiresTxn(&pArgs) {
+		t.Error("expected Put not to require a transaction")
+	}
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
 }
 
-// TestPushTxnUpgradeExistingTxn verifies that pushing
-// a transaction record with a new epoch upgrades the pushee's
-// epoch and timestamp if greater. In all test cases, the
-// priorities are set such that the push will succeed.
-func TestPushTxnUpgradeExistingTxn(t *testing.T) {
+// TestEndTransactionBeforeHeartbeat verifies that a transaction
+// can be committed/aborted before being heartbeat.
+func TestEndTransactionBeforeHeartbeat(t *testing.T) {
 	defer leaktest.AfterTest(t)
+	// Don't automatically GC the Txn record: We want to heartbeat the
+	// committed Transaction and compare it against our expectations.
+	// When it's removed, the heartbeat would recreate it.
+	defer setTxnAutoGC(false)()
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	ts1 := roachpb.Timestamp{WallTime: 1}
-	ts2 := roachpb.Timestamp{WallTime: 2}
-	testCases := []struct {
-		startEpoch, epoch, expEpoch uint32
-		startTS, ts, expTS          roachpb.Timestamp
-	}{
-		// Move epoch forward.
-		{0, 1, 1, ts1, ts1, ts1},
-		// Move timestamp forward.
-		{0, 0, 0, ts1, ts2, ts2},
-		// Move epoch backwards (has no effect).
-		{1, 0, 1, ts1, ts1, ts1},
-		// Move timestamp backwards (has no effect).
-		{0, 0, 0, ts2, ts1, ts2},
-		// Move both epoch & timestamp forward.
-		{0, 1, 1, ts1, ts2, ts2},
-		// Move both epoch & timestamp backward (has no effect).
-		{1, 0, 1, ts2, ts1, ts2},
-	}
-
-	for i, test := range testCases {
-		key := roachpb.Key(fmt.Sprintf("key-%d", i))
-		pusher := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-		pushee := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-		pushee.Priority = 1
-		pusher.Priority = 2   // Pusher will win
-		pusher.Writing = true // expected when a txn is heartbeat
-
-		// First, establish "start" of existing pushee's txn via BeginTransaction.
-		pushee.Epoch = test.startEpoch
-		pushee.Timestamp = test.startTS
-		pushee.LastHeartbeat = &test.startTS
-		bt, btH := beginTxnArgs(key, pushee)
+	key := []byte("a")
+	for _, commit := range []bool{true, false} {
+		txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+		bt, btH := beginTxnArgs(key, txn)
 		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
 			t.Fatal(err)
 		}
+		txn.Sequence++
+		args, h := endTxnArgs(txn, commit)
+		resp, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args)
+		if err != nil {
+			t.Error(err)
+		}
+		reply := resp.(*roachpb.EndTransactionResponse)
+		expStatus := roachpb.COMMITTED
+		if !commit {
+			expStatus = roachpb.ABORTED
+		}
+		if reply.Txn.Status != expStatus {
+			t.Errorf("expected transaction status to be %s; got %s", expStatus, reply.Txn.Status)
+		}
 
-		// Now, attempt to push the transaction using updated values for epoch & timestamp.
-		pushee.Epoch = test.epoch
-		pushee.Timestamp = test.ts
-		args := pushTxnArgs(pusher, pushee, roachpb.PUSH_ABORT)
+		// Try a heartbeat to the already-committed transaction; should get
+		// committed txn back, but without last heartbeat timestamp set.
+		txn.Sequence++
+		hBA, h := heartbeatArgs(txn)
 
-		resp, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+		resp, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &hBA)
 		if err != nil {
-			t.Fatal(err)
+			t.Error(err)
 		}
-		reply := resp.(*roachpb.PushTxnResponse)
-		expTxn := proto.Clone(pushee).(*roachpb.Transaction)
-		expTxn.Epoch = test.expEpoch
-		expTxn.Timestamp = test.expTS
-		expTxn.Status = roachpb.ABORTED
-		expTxn.LastHeartbeat = &test.startTS
-
-		if !reflect.DeepEqual(expTxn, &reply.PusheeTxn) {
-			t.Errorf("unexpected push txn in trial %d; expected %+v, got %+v", i, expTxn, reply.PusheeTxn)
+		hBR := resp.(*roachpb.HeartbeatTxnResponse)
+		if hBR.Txn.Status != expStatus || hBR.Txn.LastHeartbeat != nil {
+			t.Errorf("unexpected heartbeat reply contents: %+v", hBR)
 		}
 	}
 }
 
-// TestPushTxnHeartbeatTimeout verifies that a txn which
-// hasn't been heartbeat within 2x the heartbeat interval can be
-// pushed/aborted.
-func TestPushTxnHeartbeatTimeout(t *testing.T) {
+// TestEndTransactionAfterHeartbeat verifies that a transaction
+// can be committed/aborted after being heartbeat.
+func TestEndTransactionAfterHeartbeat(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	ts := roachpb.Timestamp{WallTime: 1}
-	ns := DefaultHeartbeatInterval.Nanoseconds()
-	testCases := []struct {
-		heartbeat   roachpb.Timestamp // zero value indicates no heartbeat
-		currentTime int64             // nanoseconds
-		pushType    roachpb.PushTxnType
-		expSuccess  bool
-	}{
-		{roachpb.ZeroTimestamp, 1, roachpb.PUSH_TIMESTAMP, false}, // using 0 as time is awkward
-		{roachpb.ZeroTimestamp, 1, roachpb.PUSH_ABORT, false},
-		{roachpb.ZeroTimestamp, 1, roachpb.PUSH_TOUCH, false},
-		{roachpb.ZeroTimestamp, ns, roachpb.PUSH_TIMESTAMP, false},
-		{roachpb.ZeroTimestamp, ns, roachpb.PUSH_ABORT, false},
-		{roachpb.ZeroTimestamp, ns, roachpb.PUSH_TOUCH, false},
-		{roachpb.ZeroTimestamp, ns*2 - 1, roachpb.PUSH_TIMESTAMP, false},
-		{roachpb.ZeroTimestamp, ns*2 - 1, roachpb.PUSH_ABORT, false},
-		{roachpb.ZeroTimestamp, ns*2 - 1, roachpb.PUSH_TOUCH, false},
-		{roachpb.ZeroTimestamp, ns * 2, roachpb.PUSH_TIMESTAMP, false},
-		{roachpb.ZeroTimestamp, ns * 2, roachpb.PUSH_ABORT, false},
-		{roachpb.ZeroTimestamp, ns * 2, roachpb.PUSH_TOUCH, false},
-		{ts, ns*2 + 1, roachpb.PUSH_TIMESTAMP, false},
-		{ts, ns*2 + 1, roachpb.PUSH_ABORT, false},
-		{ts, ns*2 + 1, roachpb.PUSH_TOUCH, false},
-		{ts, ns*2 + 2, roachpb.PUSH_TIMESTAMP, true},
-		{ts, ns*2 + 2, roachpb.PUSH_ABORT, true},
-		{ts, ns*2 + 2, roachpb.PUSH_TOUCH, true},
-	}
-
-	for i, test := range testCases {
-		tc.manualClock.Set(0)
-		key := roachpb.Key(fmt.Sprintf("key-%d", i))
-		pushee := newTransaction(fmt.Sprintf("test-%d", i), key, 1, roachpb.SERIALIZABLE, nil /* clock */)
-		pusher := newTransaction("pusher", key, 1, roachpb.SERIALIZABLE, nil /* clock */)
-		pushee.Priority = 2
-		pusher.Priority = 1 // Pusher won't win based on priority.
-
-		// First, establish "start" of existing pushee's txn via BeginTransaction.
-		if !test.heartbeat.Equal(roachpb.ZeroTimestamp) {
-			pushee.LastHeartbeat = &test.heartbeat
-		}
-		bt, btH := beginTxnArgs(key, pushee)
+	key := []byte("a")
+	for _, commit := range []bool{true, false} {
+		txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+		bt, btH := beginTxnArgs(key, txn)
 		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
 			t.Fatal(err)
 		}
 
-		// Now, attempt to push the transaction with Now set to our current time.
-		args := pushTxnArgs(pusher, pushee, test.pushType)
-		args.Now = roachpb.Timestamp{WallTime: test.currentTime}
-
-		reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+		// Start out with a heartbeat to the transaction.
+		hBA, h := heartbeatArgs(txn)
+		txn.Sequence++
 
-		if test.expSuccess != (err == nil) {
-			t.Errorf("%d: expSuccess=%t; got err %s", i, test.expSuccess, err)
-			continue
+		resp, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &hBA)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hBR := resp.(*roachpb.HeartbeatTxnResponse)
+		if hBR.Txn.Status != roachpb.PENDING || hBR.Txn.LastHeartbeat == nil {
+			t.Errorf("unexpected heartbeat reply contents: %+v", hBR)
 		}
+
+		args, h := endTxnArgs(txn, commit)
+		txn.Sequence++
+
+		resp, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args)
 		if err != nil {
-			if _, ok := err.(*roachpb.TransactionPushError); !ok {
-				t.Errorf("%d: expected txn push error: %s", i, err)
-			}
-		} else if txn := reply.(*roachpb.PushTxnResponse).PusheeTxn; txn.Status != roachpb.ABORTED {
-			t.Errorf("%d: expected aborted transaction, got %s", i, txn)
+			t.Error(err)
+		}
+		reply := resp.(*roachpb.EndTransactionResponse)
+		expStatus := roachpb.COMMITTED
+		if !commit {
+			expStatus = roachpb.ABORTED
+		}
+		if reply.Txn.Status != expStatus {
+			t.Errorf("expected transaction status to be %s; got %s", expStatus, reply.Txn.Status)
+		}
+		if reply.Txn.LastHeartbeat == nil || !reply.Txn.LastHeartbeat.Equal(*hBR.Txn.LastHeartbeat) {
+			t.Errorf("expected heartbeats to remain equal: %+v != %+v",
+				reply.Txn.LastHeartbeat, hBR.Txn.LastHeartbeat)
 		}
 	}
 }
 
-// TestPushTxnPriorities verifies that txns with lower
-// priority are pushed; if priorities are equal, then the txns
-// are ordered by txn timestamp, with the more recent timestamp
-// being pushable.
-func TestPushTxnPriorities(t *testing.T) {
+// TestEndTransactionWithPushedTimestamp verifies that txn can be
+// ended (both commit or abort) correctly when the commit timestamp is
+// greater than the transaction timestamp, depending on the isolation
+// level.
+func TestEndTransactionWithPushedTimestamp(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	ts1 := roachpb.Timestamp{WallTime: 1}
-	ts2 := roachpb.Timestamp{WallTime: 2}
 	testCases := []struct {
-		pusherPriority, pusheePriority int32
-		pusherTS, pusheeTS             roachpb.Timestamp
-		pushType                       roachpb.PushTxnType
-		expSuccess                     bool
+		commit    bool
+		isolation roachpb.IsolationType
+		expErr    bool
 	}{
-		// Pusher has higher priority succeeds.
-		{2, 1, ts1, ts1, roachpb.PUSH_ABORT, true},
-		// Pusher has lower priority fails.
-		{1, 2, ts1, ts1, roachpb.PUSH_ABORT, false},
-		{1, 2, ts1, ts1, roachpb.PUSH_TIMESTAMP, false},
-		// Pusher has lower priority fails, even with older txn timestamp.
-		{1, 2, ts1, ts2, roachpb.PUSH_ABORT, false},
-		// Pusher has lower priority, but older txn timestamp allows success if !abort.
-		{1, 2, ts1, ts2, roachpb.PUSH_TIMESTAMP, true},
-		// With same priorities, older txn timestamp succeeds.
-		{1, 1, ts1, ts2, roachpb.PUSH_ABORT, true},
-		// With same priorities, same txn timestamp fails.
-		{1, 1, ts1, ts1, roachpb.PUSH_ABORT, false},
-		{1, 1, ts1, ts1, roachpb.PUSH_TIMESTAMP, false},
-		// With same priorities, newer txn timestamp fails.
-		{1, 1, ts2, ts1, roachpb.PUSH_ABORT, false},
-		{1, 1, ts2, ts1, roachpb.PUSH_TIMESTAMP, false},
-		// When confirming, priority never wins.
-		{2, 1, ts1, ts1, roachpb.PUSH_TOUCH, false},
-		{1, 2, ts1, ts1, roachpb.PUSH_TOUCH, false},
+		{true, roachpb.SERIALIZABLE, true},
+		{true, roachpb.SNAPSHOT, false},
+		{false, roachpb.SERIALIZABLE, false},
+		{false, roachpb.SNAPSHOT, false},
 	}
-
-	for i, test := range testCases {
-		key := roachpb.Key(fmt.Sprintf("key-%d", i))
-		pusher := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-		pushee := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-		pusher.Priority = test.pusherPriority
-		pushee.Priority = test.pusheePriority
-		pusher.Timestamp = test.pusherTS
-		pushee.Timestamp = test.pusheeTS
-
-		bt, btH := beginTxnArgs(key, pushee)
+	key := []byte("a")
+	for _, test := range testCases {
+		txn := newTransaction("test", key, 1, test.isolation, tc.clock)
+		bt, btH := beginTxnArgs(key, txn)
 		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
 			t.Fatal(err)
 		}
-		// Now, attempt to push the transaction with intent epoch set appropriately.
-		args := pushTxnArgs(pusher, pushee, test.pushType)
+		// End the transaction with args timestamp moved forward in time.
+		args, h := endTxnArgs(txn, test.commit)
+		// TODO(tschottdorf): this test is pretty dirty. It should really
+		// write a txn entry and then try to commit that; the way it works
+		// now is by supplying its txn (which has its own timestamp) at
+		// another timestamp. This constrains changes we want to make on
+		// how timestamps work.
+		tc.manualClock.Set(1)
+		ts := tc.clock.Now()
+		h.Timestamp = ts
+		txn.Sequence++
 
-		_, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+		resp, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args)
 
-		if test.expSuccess != (err == nil) {
-			t.Errorf("expected success on trial %d? %t; got err %s", i, test.expSuccess, err)
-		}
-		if err != nil {
-			if _, ok := err.(*roachpb.TransactionPushError); !ok {
-				t.Errorf("expected txn push error: %s", err)
+		if test.expErr {
+			if err == nil {
+				t.Errorf("expected error")
+			}
+			if _, ok := err.(*roachpb.TransactionRetryError); !ok {
+				t.Errorf("expected retry error; got %s", err)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			expStatus := roachpb.COMMITTED
+			if !test.commit {
+				expStatus = roachpb.ABORTED
+			}
+			reply := resp.(*roachpb.EndTransactionResponse)
+			if reply.Txn.Status != expStatus {
+				t.Errorf("expected transaction status to be %s; got %s", expStatus, reply.Txn.Status)
 			}
 		}
 	}
 }
 
-// TestPushTxnPushTimestamp verifies that with args.Abort is
-// false (i.e. for read/write conflict), the pushed txn keeps status
-// PENDING, but has its txn Timestamp moved forward to the pusher's
-// txn Timestamp + 1.
-func TestPushTxnPushTimestamp(t *testing.T) {
+// TestEndTransactionWithIncrementedEpoch verifies that txn ended with
+// a higher epoch (and priority) correctly assumes the higher epoch.
+func TestEndTransactionWithIncrementedEpoch(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	pusher := newTransaction("test", roachpb.Key("a"), 1, roachpb.SERIALIZABLE, tc.clock)
-	pushee := newTransaction("test", roachpb.Key("b"), 1, roachpb.SERIALIZABLE, tc.clock)
-	pusher.Priority = 2
-	pushee.Priority = 1 // pusher will win
-	pusher.Timestamp = roachpb.Timestamp{WallTime: 50, Logical: 25}
-	pushee.Timestamp = roachpb.Timestamp{WallTime: 5, Logical: 1}
-
-	bt, btH := beginTxnArgs(roachpb.Key("a"), pushee)
+	key := []byte("a")
+	txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+	bt, btH := beginTxnArgs(key, txn)
 	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
 		t.Fatal(err)
 	}
 
-	// Now, push the transaction with args.Abort=false.
-	args := pushTxnArgs(pusher, pushee, roachpb.PUSH_TIMESTAMP)
+	// Start out with a heartbeat to the transaction.
+	hBA, h := heartbeatArgs(txn)
+	txn.Sequence++
 
-	resp, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+	_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &hBA)
 	if err != nil {
-		t.Errorf("unexpected error on push: %s", err)
+		t.Error(err)
 	}
-	expTS := pusher.Timestamp
-	expTS.Logical++
-	reply := resp.(*roachpb.PushTxnResponse)
-	if !reply.PusheeTxn.Timestamp.Equal(expTS) {
-		t.Errorf("expected timestamp to be pushed to %+v; got %+v", expTS, reply.PusheeTxn.Timestamp)
+
+	// Now end the txn with increased epoch and priority.
+	args, h := endTxnArgs(txn, true)
+	h.Txn.Epoch = txn.Epoch + 1
+	h.Txn.Priority = txn.Priority + 1
+
+	txn.Sequence++
+	resp, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args)
+	if err != nil {
+		t.Error(err)
 	}
-	if reply.PusheeTxn.Status != roachpb.PENDING {
-		t.Errorf("expected pushed txn to have status PENDING; got %s", reply.PusheeTxn.Status)
+	reply := resp.(*roachpb.EndTransactionResponse)
+	if reply.Txn.Status != roachpb.COMMITTED {
+		t.Errorf("expected transaction status to be COMMITTED; got %s", reply.Txn.Status)
+	}
+	if reply.Txn.Epoch != txn.Epoch {
+		t.Errorf("expected epoch to equal %d; got %d", txn.Epoch, reply.Txn.Epoch)
+	}
+	if reply.Txn.Priority != txn.Priority {
+		t.Errorf("expected priority to equal %d; got %d", txn.Priority, reply.Txn.Priority)
 	}
 }
 
-// TestPushTxnPushTimestampAlreadyPushed verifies that pushing
-// a timestamp forward which is already far enough forward is a simple
-// noop. We do this by ensuring that priorities would otherwise make
-// pushing impossible.
-func TestPushTxnPushTimestampAlreadyPushed(t *testing.T) {
+// TestEndTransactionWithErrors verifies various error conditions
+// are checked such as transaction already being committed or
+// aborted, or timestamp or epoch regression.
+func TestEndTransactionWithErrors(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	pusher := newTransaction("test", roachpb.Key("a"), 1, roachpb.SERIALIZABLE, tc.clock)
-	pushee := newTransaction("test", roachpb.Key("b"), 1, roachpb.SERIALIZABLE, tc.clock)
-	pusher.Priority = 1
-	pushee.Priority = 2 // pusher will lose
-	pusher.Timestamp = roachpb.Timestamp{WallTime: 50, Logical: 0}
-	pushee.Timestamp = roachpb.Timestamp{WallTime: 50, Logical: 1}
+	regressTS := tc.clock.Now()
+	tc.manualClock.Set(1)
+	txn := newTransaction("test", roachpb.Key(""), 1, roachpb.SERIALIZABLE, tc.clock)
 
-	bt, btH := beginTxnArgs(roachpb.Key("a"), pushee)
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
-		t.Fatal(err)
+	testCases := []struct {
+		key          roachpb.Key
+		existStatus  roachpb.TransactionStatus
+		existEpoch   uint32
+		existTS      roachpb.Timestamp
+		expErrRegexp string
+	}{
+		{roachpb.Key("a"), roachpb.COMMITTED, txn.Epoch, txn.Timestamp, "txn \"test\" id=.*: already committed"},
+		{roachpb.Key("b"), roachpb.ABORTED, txn.Epoch, txn.Timestamp, "txn aborted \"test\" id=.*"},
+		{roachpb.Key("c"), roachpb.PENDING, txn.Epoch + 1, txn.Timestamp, "txn \"test\" id=.*: epoch regression: 0"},
+		{roachpb.Key("d"), roachpb.PENDING, txn.Epoch, regressTS, `txn "test" id=.*: timestamp regression: 0.000000001,\d+`},
 	}
+	for _, test := range testCases {
+		// Establish existing txn state by writing directly to range engine.
+		var existTxn roachpb.Transaction
+		proto.Merge(&existTxn, txn)
+		existTxn.Key = test.key
+		existTxn.Status = test.existStatus
+		existTxn.Epoch = test.existEpoch
+		existTxn.Timestamp = test.existTS
+		txnKey := keys.TransactionKey(test.key, txn.ID)
+		if err := engine.MVCCPutProto(tc.rng.store.Engine(), nil, txnKey, roachpb.ZeroTimestamp,
+			nil, &existTxn); err != nil {
+			t.Fatal(err)
+		}
 
-	// Now, push the transaction with args.Abort=false.
-	args := pushTxnArgs(pusher, pushee, roachpb.PUSH_TIMESTAMP)
+		// End the transaction, verify expected error.
+		txn.Key = test.key
+		args, h := endTxnArgs(txn, true)
+		txn.Sequence++
 
-	resp, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
-	if err != nil {
-		t.Errorf("unexpected error on push: %s", err)
-	}
-	reply := resp.(*roachpb.PushTxnResponse)
-	if !reply.PusheeTxn.Timestamp.Equal(pushee.Timestamp) {
-		t.Errorf("expected timestamp to be equal to original %+v; got %+v", pushee.Timestamp, reply.PusheeTxn.Timestamp)
-	}
-	if reply.PusheeTxn.Status != roachpb.PENDING {
-		t.Errorf("expected pushed txn to have status PENDING; got %s", reply.PusheeTxn.Status)
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args); !testutils.IsError(err, test.expErrRegexp) {
+			t.Errorf("expected error:\n%s\nto match:\n%s", err, test.expErrRegexp)
+		}
 	}
 }
 
-// TestRangeResolveIntentRange verifies resolving a range of intents.
-func TestRangeResolveIntentRange(t *testing.T) {
+// TestEndTransactionGC verifies that a transaction record is immediately
+// garbage-collected upon EndTransaction iff all of the supplied intents are
+// local relative to the transaction record's location.
+func TestEndTransactionLocalGC(t *testing.T) {
 	defer leaktest.AfterTest(t)
+	defer setTxnAutoGC(true)()
+	defer func() { TestingCommandFilter = nil }()
+	TestingCommandFilter = func(_ roachpb.StoreID, args roachpb.Request, _ roachpb.Header) error {
+		// Make sure the direct GC path doesn't interfere with this test.
+		if args.Method() == roachpb.GC {
+			return util.Errorf("boom")
+		}
+		return nil
+	}
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	// Put two values transactionally.
-	txn := &roachpb.Transaction{ID: uuid.NewUUID4(), Timestamp: tc.clock.Now(), Sequence: 1}
-	for _, key := range []roachpb.Key{roachpb.Key("a"), roachpb.Key("b")} {
-		pArgs := putArgs(key, []byte("value1"))
+	splitKey := roachpb.RKey("c")
+	splitTestRange(tc.store, splitKey, splitKey, t)
+	for i, test := range []struct {
+		intents []roachpb.Span
+		expGC   bool
+	}{
+		// Range inside.
+		{[]roachpb.Span{{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}}, true},
+		// Two intents inside.
+		{[]roachpb.Span{{Key: roachpb.Key("a")}, {Key: roachpb.Key("b")}}, true},
+		// Intent range spilling over right endpoint.
+		{[]roachpb.Span{{Key: roachpb.Key("a"), EndKey: splitKey.Next().AsRawKey()}}, false},
+		// Intent range completely outside.
+		{[]roachpb.Span{{Key: splitKey.AsRawKey(), EndKey: roachpb.Key("q")}}, false},
+		// Intent inside and outside.
+		{[]roachpb.Span{{Key: roachpb.Key("a")}, {Key: splitKey.AsRawKey()}}, false},
+	} {
+		key := roachpb.Key("a")
+		txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+		bt, btH := beginTxnArgs(key, txn)
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
+			t.Fatal(err)
+		}
+		args, h := endTxnArgs(txn, true)
+		args.IntentSpans = test.intents
 		txn.Sequence++
-		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Txn: txn}, &pArgs); err != nil {
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args); err != nil {
+			t.Fatal(err)
+		}
+		var readTxn roachpb.Transaction
+		txnKey := keys.TransactionKey(txn.Key, txn.ID)
+		ok, err := engine.MVCCGetProto(tc.rng.store.Engine(), txnKey, roachpb.ZeroTimestamp,
+			true /* consistent */, nil /* txn */, &readTxn)
+		if err != nil {
 			t.Fatal(err)
 		}
+		if !ok != test.expGC {
+			t.Errorf("%d: unexpected gc'ed: %t", i, !ok)
+		}
 	}
+}
 
-	// Resolve the intents.
-	rArgs := &roachpb.ResolveIntentRangeRequest{
-		Span: roachpb.Span{
-			Key:    roachpb.Key("a"),
-			EndKey: roachpb.Key("c"),
-		},
-		IntentTxn: *txn,
+func setupResolutionTest(t *testing.T, tc testContext, key roachpb.Key, splitKey roachpb.RKey) (*Replica, *roachpb.Transaction) {
+	// Split the range and create an intent in each range.
+	// The keys of the two intents are next to each other.
+	newRng := splitTestRange(tc.store, splitKey, splitKey, t)
+
+	txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+	bt, btH := beginTxnArgs(key, txn)
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
+		t.Fatal(err)
 	}
-	rArgs.IntentTxn.Status = roachpb.COMMITTED
-	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), rArgs); err != nil {
+	pArgs := putArgs(key, []byte("value"))
+	h := roachpb.Header{Txn: txn}
+	txn.Sequence++
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &pArgs); err != nil {
 		t.Fatal(err)
 	}
 
-	// Do a consistent scan to verify intents have been cleared.
-	sArgs := scanArgs(roachpb.Key("a"), roachpb.Key("c"))
-	reply, err := client.SendWrapped(tc.Sender(), nil, &sArgs)
-	if err != nil {
-		t.Fatalf("unexpected error on scan: %s", err)
-	}
-	sReply := reply.(*roachpb.ScanResponse)
-	if len(sReply.Rows) != 2 {
-		t.Errorf("expected 2 rows; got %v", sReply.Rows)
+	pArgs = putArgs(splitKey.AsRawKey(), []byte("value"))
+	txn.Sequence++
+	if _, err := client.SendWrappedWith(newRng, newRng.context(), h, &pArgs); err != nil {
+		t.Fatal(err)
 	}
-}
 
-func verifyRangeStats(eng engine.Engine, rangeID roachpb.RangeID, expMS engine.MVCCStats, t *testing.T) {
-	var ms engine.MVCCStats
-	if err := engine.MVCCGetRangeStats(eng, rangeID, &ms); err != nil {
+	// End the transaction and resolve the intents.
+	args, h := endTxnArgs(txn, true /* commit */)
+	args.IntentSpans = []roachpb.Span{{Key: key, EndKey: splitKey.Next().AsRawKey()}}
+	txn.Sequence++
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &args); err != nil {
 		t.Fatal(err)
 	}
-	if !reflect.DeepEqual(expMS, ms) {
-		t.Errorf("expected stats \n  %+v;\ngot \n  %+v", expMS, ms)
-	}
+	return newRng, txn
 }
 
-// TestRangeStatsComputation verifies that commands executed against a
-// range update the range stat counters. The stat values are
-// empirically derived; we're really just testing that they increment
-// in the right ways, not the exact amounts. If the encodings change,
-// will need to update this test.
-func TestRangeStatsComputation(t *testing.T) {
+// TestEndTransactionResolveOnlyLocalIntents verifies that an end transaction
+// request resolves only local intents within the same batch.
+func TestEndTransactionResolveOnlyLocalIntents(t *testing.T) {
 	defer leaktest.AfterTest(t)
-	tc := testContext{
-		bootstrapMode: bootstrapRangeOnly,
+	tc := testContext{}
+	key := roachpb.Key("a")
+	splitKey := roachpb.RKey(key).Next()
+	defer func() { TestingCommandFilter = nil }()
+	TestingCommandFilter = func(_ roachpb.StoreID, args roachpb.Request, _ roachpb.Header) error {
+		if args.Method() == roachpb.ResolveIntentRange && args.Header().Key.Equal(splitKey.AsRawKey()) {
+			return util.Errorf("boom")
+		}
+		return nil
 	}
 	tc.Start(t)
 	defer tc.Stop()
 
-	// Put a value.
-	pArgs := putArgs([]byte("a"), []byte("value1"))
-
-	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
-		t.Fatal(err)
-	}
-	expMS := engine.MVCCStats{LiveBytes: 25, KeyBytes: 14, ValBytes: 11, IntentBytes: 0, LiveCount: 1, KeyCount: 1, ValCount: 1, IntentCount: 0, IntentAge: 0, GCBytesAge: 0, SysBytes: 51, SysCount: 1, LastUpdateNanos: 0}
-	verifyRangeStats(tc.engine, tc.rng.Desc().RangeID, expMS, t)
-
-	// Put a 2nd value transactionally.
-	pArgs = putArgs([]byte("b"), []byte("value2"))
-	txn := &roachpb.Transaction{ID: uuid.NewUUID4(), Timestamp: tc.clock.Now(), Sequence: 1}
-
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Txn: txn}, &pArgs); err != nil {
-		t.Fatal(err)
-	}
-	expMS = engine.MVCCStats{LiveBytes: 116, KeyBytes: 28, ValBytes: 88, IntentBytes: 23, LiveCount: 2, KeyCount: 2, ValCount: 2, IntentCount: 1, IntentAge: 0, GCBytesAge: 0, SysBytes: 51, SysCount: 1, LastUpdateNanos: 0}
-	verifyRangeStats(tc.engine, tc.rng.Desc().RangeID, expMS, t)
+	newRng, txn := setupResolutionTest(t, tc, key, splitKey)
 
-	// Resolve the 2nd value.
-	rArgs := &roachpb.ResolveIntentRequest{
-		Span: roachpb.Span{
-			Key: pArgs.Key,
-		},
-		IntentTxn: *txn,
+	// Check if the intent in the other range has not yet been resolved.
+	gArgs := getArgs(splitKey)
+	_, err := client.SendWrapped(newRng, newRng.context(), &gArgs)
+	if _, ok := err.(*roachpb.WriteIntentError); !ok {
+		t.Errorf("expected write intent error, but got %s", err)
 	}
-	rArgs.IntentTxn.Status = roachpb.COMMITTED
 
-	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), rArgs); err != nil {
+	txn.Sequence++
+	hbArgs, h := heartbeatArgs(txn)
+	reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &hbArgs)
+	if err != nil {
 		t.Fatal(err)
 	}
-	expMS = engine.MVCCStats{LiveBytes: 50, KeyBytes: 28, ValBytes: 22, IntentBytes: 0, LiveCount: 2, KeyCount: 2, ValCount: 2, IntentCount: 0, IntentAge: 0, GCBytesAge: 0, SysBytes: 51, SysCount: 1, LastUpdateNanos: 0}
-	verifyRangeStats(tc.engine, tc.rng.Desc().RangeID, expMS, t)
-
-	// Delete the 1st value.
-	dArgs := deleteArgs([]byte("a"))
-
-	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &dArgs); err != nil {
-		t.Fatal(err)
+	hbResp := reply.(*roachpb.HeartbeatTxnResponse)
+	expIntents := []roachpb.Span{{Key: splitKey.AsRawKey(), EndKey: splitKey.AsRawKey().Next()}}
+	if !reflect.DeepEqual(hbResp.Txn.Intents, expIntents) {
+		t.Fatalf("expected persisted intents %v, got %v",
+			expIntents, hbResp.Txn.Intents)
 	}
-	expMS = engine.MVCCStats{LiveBytes: 25, KeyBytes: 40, ValBytes: 22, IntentBytes: 0, LiveCount: 1, KeyCount: 2, ValCount: 3, IntentCount: 0, IntentAge: 0, GCBytesAge: 0, SysBytes: 51, SysCount: 1, LastUpdateNanos: 0}
-	verifyRangeStats(tc.engine, tc.rng.Desc().RangeID, expMS, t)
 }
 
-// TestMerge verifies that the Merge command is behaving as
-// expected. Merge semantics for different data types are tested more
-// robustly at the engine level; this test is intended only to show
-// that values passed to Merge are being merged.
-func TestMerge(t *testing.T) {
+// TestEndTransactionDirectGC verifies that after successfully resolving the
+// external intents of a transaction after EndTransaction, the transaction and
+// sequence cache records are purged.
+func TestEndTransactionDirectGC(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
+	key := roachpb.Key("a")
+	splitKey := roachpb.RKey(key).Next()
 	tc.Start(t)
 	defer tc.Stop()
 
-	key := []byte("mergedkey")
-	stringArgs := []string{"a", "b", "c", "d"}
-	stringExpected := "abcd"
-
-	for _, str := range stringArgs {
-		mergeArgs := internalMergeArgs(key, roachpb.MakeValueFromString(str))
+	_, txn := setupResolutionTest(t, tc, key, splitKey)
 
-		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &mergeArgs); err != nil {
-			t.Fatalf("unexpected error from Merge: %s", err.Error())
+	util.SucceedsWithin(t, 5*time.Second, func() error {
+		if gr, _, err := tc.rng.Get(tc.engine, roachpb.Header{}, roachpb.GetRequest{Span: roachpb.Span{Key: keys.TransactionKey(txn.Key, txn.ID)}}); err != nil {
+			return err
+		} else if gr.Value != nil {
+			return util.Errorf("txn entry still there: %+v", gr)
 		}
-	}
 
-	getArgs := getArgs(key)
+		var entry roachpb.SequenceCacheEntry
+		if seq, _, err := tc.rng.sequence.Get(tc.engine, txn.ID, &entry); err != nil {
+			return err
+		} else if seq > 0 {
+			return util.Errorf("sequence cache still populated: %v", entry)
+		}
 
-	reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &getArgs)
-	if err != nil {
-		t.Fatalf("unexpected error from Get: %s", err)
-	}
-	resp := reply.(*roachpb.GetResponse)
-	if resp.Value == nil {
-		t.Fatal("GetResponse had nil value")
-	}
-	a, err := resp.Value.GetBytes()
-	if err != nil {
-		t.Fatal(err)
-	}
-	if e := []byte(stringExpected); !bytes.Equal(a, e) {
-		t.Errorf("Get did not return expected value: %s != %s", string(a), e)
-	}
+		return nil
+	})
 }
 
-// TestTruncateLog verifies that the TruncateLog command removes a
-// prefix of the raft logs (modifying FirstIndex() and making them
-// inaccessible via Entries()).
-func TestTruncateLog(t *testing.T) {
+// TestEndTransactionDirectGCFailure verifies that no immediate GC takes place
+// if external intents can't be resolved (see also TestEndTransactionDirectGC).
+func TestEndTransactionDirectGCFailure(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
+	key := roachpb.Key("a")
+	splitKey := roachpb.RKey(key).Next()
+	var count int64
+	defer func() { TestingCommandFilter = nil }()
+	TestingCommandFilter = func(_ roachpb.StoreID, args roachpb.Request, _ roachpb.Header) error {
+		if args.Method() == roachpb.ResolveIntentRange && args.Header().Key.Equal(splitKey.AsRawKey()) {
+			atomic.AddInt64(&count, 1)
+			return util.Errorf("boom")
+		} else if args.Method() == roachpb.GC {
+			t.Fatalf("unexpected GCRequest: %+v", args)
+		}
+		return nil
+	}
 	tc.Start(t)
 	defer tc.Stop()
-	tc.rng.store.DisableRaftLogQueue(true)
 
-	// Populate the log with 10 entries. Save the LastIndex after each write.
-	var indexes []uint64
-	for i := 0; i < 10; i++ {
-		args := incrementArgs([]byte("a"), int64(i))
+	setupResolutionTest(t, tc, key, splitKey)
 
-		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args); err != nil {
-			t.Fatal(err)
-		}
-		idx, err := tc.rng.LastIndex()
-		if err != nil {
-			t.Fatal(err)
+	// Now test that no GCRequest is issued. We can't test that directly (since
+	// it's completely asynchronous), so we first make sure ResolveIntent
+	// happened and subsequently issue a bogus Put which is likely to make it
+	// into Raft only after a rogue GCRequest (at least sporadically), which
+	// would trigger a Fatal from the command filter.
+	util.SucceedsWithin(t, 5*time.Second, func() error {
+		if atomic.LoadInt64(&count) == 0 {
+			return util.Errorf("intent resolution not attempted yet")
+		} else if err := tc.store.DB().Put("panama", "banana"); err != nil {
+			return err
 		}
-		indexes = append(indexes, idx)
-	}
-
-	rangeID := tc.rng.Desc().RangeID
+		return nil
+	})
+}
 
-	// Discard the first half of the log.
-	truncateArgs := truncateLogArgs(indexes[5], rangeID)
-	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &truncateArgs); err != nil {
-		t.Fatal(err)
-	}
+// TestSequenceCachePoisonOnResolve verifies that when an intent is pushed into
+// the future or aborted, the sequence cache on the respective Range is
+// poisoned and the pushee is presented with a txn retry or abort on its next
+// contact with the Range in the same epoch.
+func TestSequenceCachePoisonOnResolve(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	key := roachpb.Key("a")
 
-	// FirstIndex has changed.
-	firstIndex, err := tc.rng.FirstIndex()
-	if err != nil {
-		t.Fatal(err)
-	}
-	if firstIndex != indexes[5] {
-		t.Errorf("expected firstIndex == %d, got %d", indexes[5], firstIndex)
-	}
+	// Isolation of the pushee and whether we're going to abort it.
+	// Run the actual meat of the test, which pushes the pushee and
+	// checks whether we get the correct behaviour as it touches the
+	// Range again.
+	run := func(abort bool, iso roachpb.IsolationType) {
+		tc := testContext{}
+		tc.Start(t)
+		defer tc.Stop()
 
-	// We can still get what remains of the log.
-	entries, err := tc.rng.Entries(indexes[5], indexes[9], math.MaxUint64)
-	if err != nil {
-		t.Fatal(err)
-	}
-	if len(entries) != int(indexes[9]-indexes[5]) {
-		t.Errorf("expected %d entries, got %d", indexes[9]-indexes[5], len(entries))
-	}
+		pushee := newTransaction("test", key, 1, iso, tc.clock)
+		pusher := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+		pusher.Priority = 2
+		pushee.Priority = 1 // pusher will win
 
-	// But any range that includes the truncated entries returns an error.
-	_, err = tc.rng.Entries(indexes[4], indexes[9], math.MaxUint64)
-	if err != raft.ErrCompacted {
-		t.Errorf("expected ErrCompacted, got %s", err)
-	}
+		inc := func(actor *roachpb.Transaction, k roachpb.Key) (*roachpb.IncrementResponse, error) {
+			actor.Sequence++
+			reply, err := client.SendWrappedWith(tc.store, nil, roachpb.Header{
+				Txn:     actor,
+				RangeID: 1,
+			}, &roachpb.IncrementRequest{Span: roachpb.Span{Key: k}, Increment: 123})
+			if err != nil {
+				return nil, err
+			}
+			return reply.(*roachpb.IncrementResponse), err
+		}
 
-	// The term of the last truncated entry is still available.
-	term, err := tc.rng.Term(indexes[4])
-	if err != nil {
-		t.Fatal(err)
+		get := func(actor *roachpb.Transaction, k roachpb.Key) error {
+			actor.Sequence++
+			_, err := client.SendWrappedWith(tc.store, nil, roachpb.Header{
+				Txn:       actor,
+				Timestamp: actor.Timestamp,
+				RangeID:   1,
+			}, &roachpb.GetRequest{Span: roachpb.Span{Key: k}})
+			return err
+		}
+
+		// Begin the pushee's transaction and write an intent.
+		btArgs, btH := beginTxnArgs(key, pushee)
+		if _, err := client.SendWrappedWith(tc.Sender(), nil,
+			btH, &btArgs); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := inc(pushee, key); err != nil {
+			t.Fatal(err)
+		}
+
+		// Have the pusher run into the intent. That pushes our pushee and resolves
+		// the intent, which in turn should poison the sequence cache.
+		var assert func(error)
+		if abort {
+			// Write/Write conflict will abort pushee.
+			if _, err := inc(pusher, key); err != nil {
+				t.Fatal(err)
+			}
+			assert = func(err error) {
+				if _, ok := err.(*roachpb.TransactionAbortedError); !ok {
+					t.Fatalf("abort=%t, iso=%s: expected txn abort, got %s", abort, iso, err)
+				}
+			}
+		} else if iso == roachpb.SNAPSHOT {
+			// At SNAPSHOT, we shouldn't be restart-poisoned.
+			assert = func(err error) {
+				if err != nil {
+					t.Fatalf("abort=%t, iso=%s: unexpected: %s", abort, iso, err)
+				}
+			}
+		} else {
+			// Trigger a Read/Write conflict which pushes pushee's timestamp.
+			if err := get(pusher, key); err != nil {
+				t.Fatal(err)
+			}
+			assert = func(err error) {
+				if _, ok := err.(*roachpb.TransactionRetryError); !ok {
+					t.Fatalf("abort=%t, iso=%s: expected txn retry, got %s",
+						abort, iso, err)
+				}
+			}
+		}
+
+		// We shouldn't be able to read or write within the transaction on this
+		// Range.
+		err := get(pushee, key)
+		assert(err)
+		_, err = inc(pushee, key)
+		assert(err)
+		// Still poisoned (on any key on the Range).
+		err = get(pushee, key.Next())
+		assert(err)
+		_, err = inc(pushee, key.Next())
+		assert(err)
+
+		// Pretend we're coming back. This works regardless of retry or restart,
+		// but obviously in practice only on a retry would the Txn actually come
+		// back with an increased epoch.
+		pushee.Epoch++
+		if _, err := inc(pushee, roachpb.Key("b")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, abort := range []bool{false, true} {
+		run(abort, roachpb.SERIALIZABLE)
+		run(abort, roachpb.SNAPSHOT)
+	}
+}
+
+// TestPushTxnBadKey verifies that args.Key equals args.PusheeTxn.ID.
+func TestPushTxnBadKey(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	pusher := newTransaction("test", roachpb.Key("a"), 1, roachpb.SERIALIZABLE, tc.clock)
+	pushee := newTransaction("test", roachpb.Key("b"), 1, roachpb.SERIALIZABLE, tc.clock)
+
+	args := pushTxnArgs(pusher, pushee, roachpb.PUSH_ABORT)
+	args.Key = pusher.Key
+
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args); !testutils.IsError(err, ".*should match pushee.*") {
+		t.Errorf("unexpected error %s", err)
+	}
+}
+
+// TestPushTxnAlreadyCommittedOrAborted verifies success
+// (noop) in event that pushee is already committed or aborted.
+func TestPushTxnAlreadyCommittedOrAborted(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	// This test simulates running into an open intent and resolving it using
+	// the transaction record. If we auto-gc'ed entries here, the entry would
+	// be deleted and the intents resolved instantaneously on successful commit
+	// (since they're on the same Range). Could split the range and have
+	// non-local intents if we ever wanted to get rid of this.
+	defer setTxnAutoGC(false)()
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	for i, status := range []roachpb.TransactionStatus{roachpb.COMMITTED, roachpb.ABORTED} {
+		key := roachpb.Key(fmt.Sprintf("key-%d", i))
+		pusher := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+		pushee := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+		pusher.Priority = 1
+		pushee.Priority = 2 // pusher will lose, meaning we shouldn't push unless pushee is already ended.
+
+		// Begin the pushee's transaction.
+		btArgs, btH := beginTxnArgs(key, pushee)
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &btArgs); err != nil {
+			t.Fatal(err)
+		}
+		// End the pushee's transaction.
+		etArgs, h := endTxnArgs(pushee, status == roachpb.COMMITTED)
+		pushee.Sequence++
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &etArgs); err != nil {
+			t.Fatal(err)
+		}
+
+		// Now try to push what's already committed or aborted.
+		args := pushTxnArgs(pusher, pushee, roachpb.PUSH_ABORT)
+		resp, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reply := resp.(*roachpb.PushTxnResponse)
+		if reply.PusheeTxn.Status != status {
+			t.Errorf("expected push txn to return with status == %s; got %+v", status, reply.PusheeTxn)
+		}
+	}
+}
+
+// TestPushTxnUpgradeExistingTxn verifies that pushing
+// a transaction record with a new epoch upgrades the pushee's
+// epoch and timestamp if greater. In all test cases, the
+// priorities are set such that the push will succeed.
+func TestPushTxnUpgradeExistingTxn(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	ts1 := roachpb.Timestamp{WallTime: 1}
+	ts2 := roachpb.Timestamp{WallTime: 2}
+	testCases := []struct {
+		startEpoch, epoch, expEpoch uint32
+		startTS, ts, expTS          roachpb.Timestamp
+	}{
+		// Move epoch forward.
+		{0, 1, 1, ts1, ts1, ts1},
+		// Move timestamp forward.
+		{0, 0, 0, ts1, ts2, ts2},
+		// Move epoch backwards (has no effect).
+		{1, 0, 1, ts1, ts1, ts1},
+		// Move timestamp backwards (has no effect).
+		{0, 0, 0, ts2, ts1, ts2},
+		// Move both epoch & timestamp forward.
+		{0, 1, 1, ts1, ts2, ts2},
+		// Move both epoch & timestamp backward (has no effect).
+		{1, 0, 1, ts2, ts1, ts2},
+	}
+
+	for i, test := range testCases {
+		key := roachpb.Key(fmt.Sprintf("key-%d", i))
+		pusher := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+		pushee := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+		pushee.Priority = 1
+		pusher.Priority = 2   // Pusher will win
+		pusher.Writing = true // expected when a txn is heartbeat
+
+		// First, establish "start" of existing pushee's txn via BeginTransaction.
+		pushee.Epoch = test.startEpoch
+		pushee.Timestamp = test.startTS
+		pushee.LastHeartbeat = &test.startTS
+		bt, btH := beginTxnArgs(key, pushee)
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
+			t.Fatal(err)
+		}
+
+		// Now, attempt to push the transaction using updated values for epoch & timestamp.
+		pushee.Epoch = test.epoch
+		pushee.Timestamp = test.ts
+		args := pushTxnArgs(pusher, pushee, roachpb.PUSH_ABORT)
+
+		resp, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reply := resp.(*roachpb.PushTxnResponse)
+		expTxn := proto.Clone(pushee).(*roachpb.Transaction)
+		expTxn.Epoch = test.expEpoch
+		expTxn.Timestamp = test.expTS
+		expTxn.Status = roachpb.ABORTED
+		expTxn.LastHeartbeat = &test.startTS
+
+		if !reflect.DeepEqual(expTxn, &reply.PusheeTxn) {
+			t.Errorf("unexpected push txn in trial %d; expected %+v, got %+v", i, expTxn, reply.PusheeTxn)
+		}
+	}
+}
+
+// TestPushTxnHeartbeatTimeout verifies that a txn which
+// hasn't been heartbeat within 2x the heartbeat interval can be
+// pushed/aborted.
+func TestPushTxnHeartbeatTimeout(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	ts := roachpb.Timestamp{WallTime: 1}
+	ns := DefaultHeartbeatInterval.Nanoseconds()
+	testCases := []struct {
+		heartbeat   roachpb.Timestamp // zero value indicates no heartbeat
+		currentTime int64             // nanoseconds
+		pushType    roachpb.PushTxnType
+		expSuccess  bool
+	}{
+		{roachpb.ZeroTimestamp, 1, roachpb.PUSH_TIMESTAMP, false}, // using 0 as time is awkward
+		{roachpb.ZeroTimestamp, 1, roachpb.PUSH_ABORT, false},
+		{roachpb.ZeroTimestamp, 1, roachpb.PUSH_TOUCH, false},
+		{roachpb.ZeroTimestamp, ns, roachpb.PUSH_TIMESTAMP, false},
+		{roachpb.ZeroTimestamp, ns, roachpb.PUSH_ABORT, false},
+		{roachpb.ZeroTimestamp, ns, roachpb.PUSH_TOUCH, false},
+		{roachpb.ZeroTimestamp, ns*2 - 1, roachpb.PUSH_TIMESTAMP, false},
+		{roachpb.ZeroTimestamp, ns*2 - 1, roachpb.PUSH_ABORT, false},
+		{roachpb.ZeroTimestamp, ns*2 - 1, roachpb.PUSH_TOUCH, false},
+		{roachpb.ZeroTimestamp, ns * 2, roachpb.PUSH_TIMESTAMP, false},
+		{roachpb.ZeroTimestamp, ns * 2, roachpb.PUSH_ABORT, false},
+		{roachpb.ZeroTimestamp, ns * 2, roachpb.PUSH_TOUCH, false},
+		{ts, ns*2 + 1, roachpb.PUSH_TIMESTAMP, false},
+		{ts, ns*2 + 1, roachpb.PUSH_ABORT, false},
+		{ts, ns*2 + 1, roachpb.PUSH_TOUCH, false},
+		{ts, ns*2 + 2, roachpb.PUSH_TIMESTAMP, true},
+		{ts, ns*2 + 2, roachpb.PUSH_ABORT, true},
+		{ts, ns*2 + 2, roachpb.PUSH_TOUCH, true},
+	}
+
+	for i, test := range testCases {
+		tc.manualClock.Set(0)
+		key := roachpb.Key(fmt.Sprintf("key-%d", i))
+		pushee := newTransaction(fmt.Sprintf("test-%d", i), key, 1, roachpb.SERIALIZABLE, nil /* clock */)
+		pusher := newTransaction("pusher", key, 1, roachpb.SERIALIZABLE, nil /* clock */)
+		pushee.Priority = 2
+		pusher.Priority = 1 // Pusher won't win based on priority.
+
+		// First, establish "start" of existing pushee's txn via BeginTransaction.
+		if !test.heartbeat.Equal(roachpb.ZeroTimestamp) {
+			pushee.LastHeartbeat = &test.heartbeat
+		}
+		bt, btH := beginTxnArgs(key, pushee)
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
+			t.Fatal(err)
+		}
+
+		// Now, attempt to push the transaction with Now set to our current time.
+		args := pushTxnArgs(pusher, pushee, test.pushType)
+		args.Now = roachpb.Timestamp{WallTime: test.currentTime}
+
+		reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+
+		if test.expSuccess != (err == nil) {
+			t.Errorf("%d: expSuccess=%t; got err %s", i, test.expSuccess, err)
+			continue
+		}
+		if err != nil {
+			if _, ok := err.(*roachpb.TransactionPushError); !ok {
+				t.Errorf("%d: expected txn push error: %s", i, err)
+			}
+		} else if txn := reply.(*roachpb.PushTxnResponse).PusheeTxn; txn.Status != roachpb.ABORTED {
+			t.Errorf("%d: expected aborted transaction, got %s", i, txn)
+		}
+	}
+}
+
+// TestPushTxnPriorities verifies that txns with lower
+// priority are pushed; if priorities are equal, then the txns
+// are ordered by txn timestamp, with the more recent timestamp
+// being pushable.
+func TestPushTxnPriorities(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	ts1 := roachpb.Timestamp{WallTime: 1}
+	ts2 := roachpb.Timestamp{WallTime: 2}
+	testCases := []struct {
+		pusherPriority, pusheePriority int32
+		pusherTS, pusheeTS             roachpb.Timestamp
+		pushType                       roachpb.PushTxnType
+		expSuccess                     bool
+	}{
+		// Pusher has higher priority succeeds.
+		{2, 1, ts1, ts1, roachpb.PUSH_ABORT, true},
+		// Pusher has lower priority fails.
+		{1, 2, ts1, ts1, roachpb.PUSH_ABORT, false},
+		{1, 2, ts1, ts1, roachpb.PUSH_TIMESTAMP, false},
+		// Pusher has lower priority fails, even with older txn timestamp.
+		{1, 2, ts1, ts2, roachpb.PUSH_ABORT, false},
+		// Pusher has lower priority, but older txn timestamp allows success if !abort.
+		{1, 2, ts1, ts2, roachpb.PUSH_TIMESTAMP, true},
+		// With same priorities, older txn timestamp succeeds.
+		{1, 1, ts1, ts2, roachpb.PUSH_ABORT, true},
+		// With same priorities, same txn timestamp fails.
+		{1, 1, ts1, ts1, roachpb.PUSH_ABORT, false},
+		{1, 1, ts1, ts1, roachpb.PUSH_TIMESTAMP, false},
+		// With same priorities, newer txn timestamp fails.
+		{1, 1, ts2, ts1, roachpb.PUSH_ABORT, false},
+		{1, 1, ts2, ts1, roachpb.PUSH_TIMESTAMP, false},
+		// When confirming, priority never wins.
+		{2, 1, ts1, ts1, roachpb.PUSH_TOUCH, false},
+		{1, 2, ts1, ts1, roachpb.PUSH_TOUCH, false},
+	}
+
+	for i, test := range testCases {
+		key := roachpb.Key(fmt.Sprintf("key-%d", i))
+		pusher := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+		pushee := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+		pusher.Priority = test.pusherPriority
+		pushee.Priority = test.pusheePriority
+		pusher.Timestamp = test.pusherTS
+		pushee.Timestamp = test.pusheeTS
+
+		bt, btH := beginTxnArgs(key, pushee)
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
+			t.Fatal(err)
+		}
+		// Now, attempt to push the transaction with intent epoch set appropriately.
+		args := pushTxnArgs(pusher, pushee, test.pushType)
+
+		_, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+
+		if test.expSuccess != (err == nil) {
+			t.Errorf("expected success on trial %d? %t; got err %s", i, test.expSuccess, err)
+		}
+		if err != nil {
+			if _, ok := err.(*roachpb.TransactionPushError); !ok {
+				t.Errorf("expected txn push error: %s", err)
+			}
+		}
+	}
+}
+
+// TestPushTxnPushTimestamp verifies that with args.Abort is
+// false (i.e. for read/write conflict), the pushed txn keeps status
+// PENDING, but has its txn Timestamp moved forward to the pusher's
+// txn Timestamp + 1.
+func TestPushTxnPushTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	pusher := newTransaction("test", roachpb.Key("a"), 1, roachpb.SERIALIZABLE, tc.clock)
+	pushee := newTransaction("test", roachpb.Key("b"), 1, roachpb.SERIALIZABLE, tc.clock)
+	pusher.Priority = 2
+	pushee.Priority = 1 // pusher will win
+	pusher.Timestamp = roachpb.Timestamp{WallTime: 50, Logical: 25}
+	pushee.Timestamp = roachpb.Timestamp{WallTime: 5, Logical: 1}
+
+	bt, btH := beginTxnArgs(roachpb.Key("a"), pushee)
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
+		t.Fatal(err)
+	}
+
+	// Now, push the transaction with args.Abort=false.
+	args := pushTxnArgs(pusher, pushee, roachpb.PUSH_TIMESTAMP)
+
+	resp, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+	if err != nil {
+		t.Errorf("unexpected error on push: %s", err)
+	}
+	expTS := pusher.Timestamp
+	expTS.Logical++
+	reply := resp.(*roachpb.PushTxnResponse)
+	if !reply.PusheeTxn.Timestamp.Equal(expTS) {
+		t.Errorf("expected timestamp to be pushed to %+v; got %+v", expTS, reply.PusheeTxn.Timestamp)
+	}
+	if reply.PusheeTxn.Status != roachpb.PENDING {
+		t.Errorf("expected pushed txn to have status PENDING; got %s", reply.PusheeTxn.Status)
+	}
+}
+
+// TestPushTxnPushTimestampAlreadyPushed verifies that pushing
+// a timestamp forward which is already far enough forward is a simple
+// noop. We do this by ensuring that priorities would otherwise make
+// pushing impossible.
+func TestPushTxnPushTimestampAlreadyPushed(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	pusher := newTransaction("test", roachpb.Key("a"), 1, roachpb.SERIALIZABLE, tc.clock)
+	pushee := newTransaction("test", roachpb.Key("b"), 1, roachpb.SERIALIZABLE, tc.clock)
+	pusher.Priority = 1
+	pushee.Priority = 2 // pusher will lose
+	pusher.Timestamp = roachpb.Timestamp{WallTime: 50, Logical: 0}
+	pushee.Timestamp = roachpb.Timestamp{WallTime: 50, Logical: 1}
+
+	bt, btH := beginTxnArgs(roachpb.Key("a"), pushee)
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), btH, &bt); err != nil {
+		t.Fatal(err)
+	}
+
+	// Now, push the transaction with args.Abort=false.
+	args := pushTxnArgs(pusher, pushee, roachpb.PUSH_TIMESTAMP)
+
+	resp, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+	if err != nil {
+		t.Errorf("unexpected error on push: %s", err)
+	}
+	reply := resp.(*roachpb.PushTxnResponse)
+	if !reply.PusheeTxn.Timestamp.Equal(pushee.Timestamp) {
+		t.Errorf("expected timestamp to be equal to original %+v; got %+v", pushee.Timestamp, reply.PusheeTxn.Timestamp)
+	}
+	if reply.PusheeTxn.Status != roachpb.PENDING {
+		t.Errorf("expected pushed txn to have status PENDING; got %s", reply.PusheeTxn.Status)
+	}
+}
+
+// TestRangeResolveIntentRange verifies resolving a range of intents.
+func TestRangeResolveIntentRange(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	// Put two values transactionally.
+	txn := &roachpb.Transaction{ID: uuid.NewUUID4(), Timestamp: tc.clock.Now(), Sequence: 1}
+	for _, key := range []roachpb.Key{roachpb.Key("a"), roachpb.Key("b")} {
+		pArgs := putArgs(key, []byte("value1"))
+		txn.Sequence++
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Txn: txn}, &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Resolve the intents.
+	rArgs := &roachpb.ResolveIntentRangeRequest{
+		Span: roachpb.Span{
+			Key:    roachpb.Key("a"),
+			EndKey: roachpb.Key("c"),
+		},
+		IntentTxn: *txn,
+	}
+	rArgs.IntentTxn.Status = roachpb.COMMITTED
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), rArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	// Do a consistent scan to verify intents have been cleared.
+	sArgs := scanArgs(roachpb.Key("a"), roachpb.Key("c"))
+	reply, err := client.SendWrapped(tc.Sender(), nil, &sArgs)
+	if err != nil {
+		t.Fatalf("unexpected error on scan: %s", err)
+	}
+	sReply := reply.(*roachpb.ScanResponse)
+	if len(sReply.Rows) != 2 {
+		t.Errorf("expected 2 rows; got %v", sReply.Rows)
+	}
+}
+
+func verifyRangeStats(eng engine.Engine, rangeID roachpb.RangeID, expMS engine.MVCCStats, t *testing.T) {
+	var ms engine.MVCCStats
+	if err := engine.MVCCGetRangeStats(eng, rangeID, &ms); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expMS, ms) {
+		t.Errorf("expected stats \n  %+v;\ngot \n  %+v", expMS, ms)
+	}
+}
+
+// TestRangeStatsComputation verifies that commands executed against a
+// range update the range stat counters. The stat values are
+// empirically derived; we're really just testing that they increment
+// in the right ways, not the exact amounts. If the encodings change,
+// will need to update this test.
+func TestRangeStatsComputation(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{
+		bootstrapMode: bootstrapRangeOnly,
+	}
+	tc.Start(t)
+	defer tc.Stop()
+
+	// Put a value.
+	pArgs := putArgs([]byte("a"), []byte("value1"))
+
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+	expMS := engine.MVCCStats{LiveBytes: 25, KeyBytes: 14, ValBytes: 11, IntentBytes: 0, LiveCount: 1, KeyCount: 1, ValCount: 1, IntentCount: 0, IntentAge: 0, GCBytesAge: 0, SysBytes: 51, SysCount: 1, LastUpdateNanos: 0}
+	verifyRangeStats(tc.engine, tc.rng.Desc().RangeID, expMS, t)
+
+	// Put a 2nd value transactionally.
+	pArgs = putArgs([]byte("b"), []byte("value2"))
+	txn := &roachpb.Transaction{ID: uuid.NewUUID4(), Timestamp: tc.clock.Now(), Sequence: 1}
+
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Txn: txn}, &pArgs); err != nil {
+		t.Fatal(err)
+	}
+	expMS = engine.MVCCStats{LiveBytes: 116, KeyBytes: 28, ValBytes: 88, IntentBytes: 23, LiveCount: 2, KeyCount: 2, ValCount: 2, IntentCount: 1, IntentAge: 0, GCBytesAge: 0, SysBytes: 51, SysCount: 1, LastUpdateNanos: 0}
+	verifyRangeStats(tc.engine, tc.rng.Desc().RangeID, expMS, t)
+
+	// Resolve the 2nd value.
+	rArgs := &roachpb.ResolveIntentRequest{
+		Span: roachpb.Span{
+			Key: pArgs.Key,
+		},
+		IntentTxn: *txn,
+	}
+	rArgs.IntentTxn.Status = roachpb.COMMITTED
+
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), rArgs); err != nil {
+		t.Fatal(err)
+	}
+	expMS = engine.MVCCStats{LiveBytes: 50, KeyBytes: 28, ValBytes: 22, IntentBytes: 0, LiveCount: 2, KeyCount: 2, ValCount: 2, IntentCount: 0, IntentAge: 0, GCBytesAge: 0, SysBytes: 51, SysCount: 1, LastUpdateNanos: 0}
+	verifyRangeStats(tc.engine, tc.rng.Desc().RangeID, expMS, t)
+
+	// Delete the 1st value.
+	dArgs := deleteArgs([]byte("a"))
+
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &dArgs); err != nil {
+		t.Fatal(err)
+	}
+	expMS = engine.MVCCStats{LiveBytes: 25, KeyBytes: 40, ValBytes: 22, IntentBytes: 0, LiveCount: 1, KeyCount: 2, ValCount: 3, IntentCount: 0, IntentAge: 0, GCBytesAge: 0, SysBytes: 51, SysCount: 1, LastUpdateNanos: 0}
+	verifyRangeStats(tc.engine, tc.rng.Desc().RangeID, expMS, t)
+}
+
+// TestMerge verifies that the Merge command is behaving as
+// expected. Merge semantics for different data types are tested more
+// robustly at the engine level; this test is intended only to show
+// that values passed to Merge are being merged.
+func TestMerge(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	key := []byte("mergedkey")
+	stringArgs := []string{"a", "b", "c", "d"}
+	stringExpected := "abcd"
+
+	for _, str := range stringArgs {
+		mergeArgs := internalMergeArgs(key, roachpb.MakeValueFromString(str))
+
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &mergeArgs); err != nil {
+			t.Fatalf("unexpected error from Merge: %s", err.Error())
+		}
+	}
+
+	getArgs := getArgs(key)
+
+	reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &getArgs)
+	if err != nil {
+		t.Fatalf("unexpected error from Get: %s", err)
+	}
+	resp := reply.(*roachpb.GetResponse)
+	if resp.Value == nil {
+		t.Fatal("GetResponse had nil value")
+	}
+	a, err := resp.Value.GetBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := []byte(stringExpected); !bytes.Equal(a, e) {
+		t.Errorf("Get did not return expected value: %s != %s", string(a), e)
+	}
+}
+
+// TestTruncateLog verifies that the TruncateLog command removes a
+// prefix of the raft logs (modifying FirstIndex() and making them
+// inaccessible via Entries()).
+func TestTruncateLog(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+	tc.rng.store.DisableRaftLogQueue(true)
+
+	// Populate the log with 10 entries. Save the LastIndex after each write.
+	var indexes []uint64
+	for i := 0; i < 10; i++ {
+		args := incrementArgs([]byte("a"), int64(i))
+
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args); err != nil {
+			t.Fatal(err)
+		}
+		idx, err := tc.rng.LastIndex()
+		if err != nil {
+			t.Fatal(err)
+		}
+		indexes = append(indexes, idx)
+	}
+
+	rangeID := tc.rng.Desc().RangeID
+
+	// Discard the first half of the log.
+	truncateArgs := truncateLogArgs(indexes[5], rangeID)
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &truncateArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	// FirstIndex has changed.
+	firstIndex, err := tc.rng.FirstIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firstIndex != indexes[5] {
+		t.Errorf("expected firstIndex == %d, got %d", indexes[5], firstIndex)
+	}
+
+	// We can still get what remains of the log.
+	entries, err := tc.rng.Entries(indexes[5], indexes[9], math.MaxUint64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != int(indexes[9]-indexes[5]) {
+		t.Errorf("expected %d entries, got %d", indexes[9]-indexes[5], len(entries))
+	}
+
+	// But any range that includes the truncated entries returns an error.
+	_, err = tc.rng.Entries(indexes[4], indexes[9], math.MaxUint64)
+	if err != raft.ErrCompacted {
+		t.Errorf("expected ErrCompacted, got %s", err)
+	}
+
+	// The term of the last truncated entry is still available.
+	term, err := tc.rng.Term(indexes[4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if term == 0 {
+		t.Errorf("invalid term 0 for truncated entry")
+	}
+
+	// The terms of older entries are gone.
+	_, err = tc.rng.Term(indexes[3])
+	if err != raft.ErrCompacted {
+		t.Errorf("expected ErrCompacted, got %s", err)
+	}
+
+	// Truncating logs that have already been truncated should not return an
+	// error.
+	truncateArgs = truncateLogArgs(indexes[3], rangeID)
+	if _, err = client.SendWrapped(tc.Sender(), tc.rng.context(), &truncateArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	// Truncating logs that have the wrong rangeID included should not return
+	// an error but should not truncate any logs.
+	truncateArgs = truncateLogArgs(indexes[9], rangeID+1)
+	if _, err = client.SendWrapped(tc.Sender(), tc.rng.context(), &truncateArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	// The term of the last truncated entry is still available.
+	term, err = tc.rng.Term(indexes[4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if term == 0 {
+		t.Errorf("invalid term 0 for truncated entry")
+	}
+}
+
+func TestRaftStorage(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	var eng engine.Engine
+	stopper := stop.NewStopper()
+	defer stopper.Stop()
+	storagetest.RunTests(t,
+		func(t *testing.T) storagetest.WriteableStorage {
+			eng = engine.NewInMem(roachpb.Attributes{Attrs: []string{"dc1", "mem"}}, 1<<20, stopper)
+			// Fake store to house the engine.
+			store := &Store{
+				ctx: StoreContext{
+					Clock: hlc.NewClock(hlc.UnixNano),
+				},
+				engine: eng,
+			}
+			rng, err := NewReplica(&roachpb.RangeDescriptor{
+				RangeID:  1,
+				StartKey: roachpb.RKeyMin,
+				EndKey:   roachpb.RKeyMax,
+			}, store)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return rng
+		},
+		func(t *testing.T, r storagetest.WriteableStorage) {
+			// Do nothing
+		})
+}
+
+// TestConditionFailedError tests that a ConditionFailedError correctly
+// bubbles up from MVCC to Range.
+func TestConditionFailedError(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	key := []byte("k")
+	value := []byte("quack")
+	pArgs := putArgs(key, value)
+
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
 	}
-	if term == 0 {
-		t.Errorf("invalid term 0 for truncated entry")
+	val := roachpb.MakeValueFromString("moo")
+	args := roachpb.ConditionalPutRequest{
+		Span: roachpb.Span{
+			Key: key,
+		},
+		Value:    roachpb.MakeValueFromBytes(value),
+		ExpValue: &val,
 	}
 
-	// The terms of older entries are gone.
-	_, err = tc.rng.Term(indexes[3])
-	if err != raft.ErrCompacted {
-		t.Errorf("expected ErrCompacted, got %s", err)
+	_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Timestamp: roachpb.MinTimestamp}, &args)
+
+	if cErr, ok := err.(*roachpb.ConditionFailedError); err == nil || !ok {
+		t.Fatalf("expected ConditionFailedError, got %T with content %+v",
+			err, err)
+	} else if valueBytes, err := cErr.ActualValue.GetBytes(); err != nil {
+		t.Fatal(err)
+	} else if cErr.ActualValue == nil || !bytes.Equal(valueBytes, value) {
+		t.Errorf("ConditionFailedError with bytes %q expected, but got %+v",
+			value, cErr.ActualValue)
+	}
+}
+
+// TestReplicaMetrics verifies that the replica's operation counters
+// reflect the commands it executed, including failed ones.
+func TestReplicaMetrics(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	before := tc.rng.Metrics()
+	for i := 0; i < 3; i++ {
+		pArgs := putArgs([]byte(fmt.Sprintf("k%d", i)), []byte("value"))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		gArgs := getArgs([]byte("k0"))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &gArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sArgs := scanArgs([]byte("k"), []byte("l"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &sArgs); err != nil {
+		t.Fatal(err)
+	}
+	expVal := roachpb.MakeValueFromString("moo")
+	cpArgs := roachpb.ConditionalPutRequest{
+		Span:     roachpb.Span{Key: roachpb.Key("k0")},
+		Value:    roachpb.MakeValueFromString("quack"),
+		ExpValue: &expVal,
+	}
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &cpArgs); err == nil {
+		t.Fatal("expected conditional put to fail")
+	}
+	after := tc.rng.Metrics()
+
+	for _, c := range []struct {
+		method        roachpb.Method
+		count, errors int64
+	}{
+		{roachpb.Put, 3, 0},
+		{roachpb.Get, 2, 0},
+		{roachpb.Scan, 1, 0},
+		{roachpb.ConditionalPut, 1, 1},
+		{roachpb.Delete, 0, 0},
+	} {
+		if n := after.Count(c.method) - before.Count(c.method); n != c.count {
+			t.Errorf("%s: expected %d commands, got %d", c.method, c.count, n)
+		}
+		if n := after.ErrorCount(c.method) - before.ErrorCount(c.method); n != c.errors {
+			t.Errorf("%s: expected %d errors, got %d", c.method, c.errors, n)
+		}
+	}
+
+	var total, latencies int64
+	for i := range after.Counts {
+		total += after.Counts[i]
+	}
+	for _, n := range after.Latency {
+		latencies += n
+	}
+	if total != latencies {
+		t.Errorf("expected latency histogram to hold %d samples, got %d", total, latencies)
+	}
+}
+
+// TestReplicaSetsEqual tests to ensure that intersectReplicaSets
+// returns the correct responses.
+func TestReplicaSetsEqual(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	testData := []struct {
+		expected bool
+		a        []roachpb.ReplicaDescriptor
+		b        []roachpb.ReplicaDescriptor
+	}{
+		{true, []roachpb.ReplicaDescriptor{}, []roachpb.ReplicaDescriptor{}},
+		{true, createReplicaSets([]roachpb.StoreID{1}), createReplicaSets([]roachpb.StoreID{1})},
+		{true, createReplicaSets([]roachpb.StoreID{1, 2}), createReplicaSets([]roachpb.StoreID{1, 2})},
+		{true, createReplicaSets([]roachpb.StoreID{1, 2}), createReplicaSets([]roachpb.StoreID{2, 1})},
+		{false, createReplicaSets([]roachpb.StoreID{1}), createReplicaSets([]roachpb.StoreID{2})},
+		{false, createReplicaSets([]roachpb.StoreID{1, 2}), createReplicaSets([]roachpb.StoreID{2})},
+		{false, createReplicaSets([]roachpb.StoreID{1, 2}), createReplicaSets([]roachpb.StoreID{1})},
+		{false, createReplicaSets([]roachpb.StoreID{}), createReplicaSets([]roachpb.StoreID{1})},
+		{true, createReplicaSets([]roachpb.StoreID{1, 2, 3}), createReplicaSets([]roachpb.StoreID{2, 3, 1})},
+		{true, createReplicaSets([]roachpb.StoreID{1, 1}), createReplicaSets([]roachpb.StoreID{1, 1})},
+		{false, createReplicaSets([]roachpb.StoreID{1, 1}), createReplicaSets([]roachpb.StoreID{1, 1, 1})},
+		{true, createReplicaSets([]roachpb.StoreID{1, 2, 3, 1, 2, 3}), createReplicaSets([]roachpb.StoreID{1, 1, 2, 2, 3, 3})},
+	}
+	for _, test := range testData {
+		if replicaSetsEqual(test.a, test.b) != test.expected {
+			t.Fatalf("unexpected replica intersection: %+v", test)
+		}
+	}
+}
+
+func TestAppliedIndex(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	var appliedIndex uint64
+	var sum int64
+	for i := int64(1); i <= 10; i++ {
+		args := incrementArgs([]byte("a"), i)
+
+		resp, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reply := resp.(*roachpb.IncrementResponse)
+		sum += i
+
+		if reply.NewValue != sum {
+			t.Errorf("expected %d, got %d", sum, reply.NewValue)
+		}
+
+		newAppliedIndex := atomic.LoadUint64(&tc.rng.appliedIndex)
+		if newAppliedIndex <= appliedIndex {
+			t.Errorf("appliedIndex did not advance. Was %d, now %d", appliedIndex, newAppliedIndex)
+		}
+		appliedIndex = newAppliedIndex
+	}
+}
+
+// TestReplicaCorruption verifies that a replicaCorruptionError correctly marks
+// the range as corrupt.
+func TestReplicaCorruption(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	args := putArgs(roachpb.Key("test"), []byte("value"))
+	_, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Set the stored applied index sky high.
+	newIndex := 2*atomic.LoadUint64(&tc.rng.appliedIndex) + 1
+	atomic.StoreUint64(&tc.rng.appliedIndex, newIndex)
+	// Not really needed, but let's be thorough.
+	err = setAppliedIndex(tc.rng.store.Engine(), tc.rng.Desc().RangeID, newIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Should mark replica corrupt (and panic as a result) since we messed
+	// with the applied index.
+	_, err = client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
+
+	if err == nil || !strings.Contains(err.Error(), "replica corruption (processed=true)") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+// TestRangeUninitializedRejectsCommands verifies that a replica which
+// has not been initialized with a range descriptor rejects commands with
+// a RangeNotFoundError.
+func TestRangeUninitializedRejectsCommands(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	rng, err := NewReplica(&roachpb.RangeDescriptor{RangeID: 2}, tc.store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rng.isInitialized() {
+		t.Fatal("expected replica to be uninitialized")
+	}
+	gArgs := getArgs(roachpb.Key("a"))
+	if _, err := client.SendWrapped(rng, rng.context(), &gArgs); err == nil {
+		t.Fatal("expected error from uninitialized replica")
+	} else if _, ok := err.(*roachpb.RangeNotFoundError); !ok {
+		t.Fatalf("expected RangeNotFoundError; got %T: %s", err, err)
+	}
+
+	// The store's initialized replica continues to serve commands.
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &gArgs); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestChangeReplicasDuplicateError tests that a replica change that would
+// use a NodeID twice in the replica configuration fails.
+func TestChangeReplicasDuplicateError(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	if err := tc.rng.ChangeReplicas(roachpb.ADD_REPLICA, roachpb.ReplicaDescriptor{
+		NodeID:  tc.store.Ident.NodeID,
+		StoreID: 9999,
+	}, tc.rng.Desc()); err == nil || !strings.Contains(err.Error(),
+		"already present") {
+		t.Fatalf("must not be able to add second replica to same node (err=%s)",
+			err)
+	}
+}
+
+// TestChangeReplicasRemoveErrors tests that removing a replica which is
+// not part of the range, or the range's last replica, fails and leaves
+// the replica free to process further changes.
+func TestChangeReplicasRemoveErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	testCases := []struct {
+		replica roachpb.ReplicaDescriptor
+		expErr  string
+	}{
+		{roachpb.ReplicaDescriptor{NodeID: 9999, StoreID: 9999}, "not present"},
+		{*tc.rng.GetReplica(), "last replica"},
+	}
+	for i, c := range testCases {
+		if err := tc.rng.ChangeReplicas(roachpb.REMOVE_REPLICA, c.replica,
+			tc.rng.Desc()); err == nil || !strings.Contains(err.Error(), c.expErr) {
+			t.Errorf("%d: expected error containing %q; got %v", i, c.expErr, err)
+		}
+	}
+	if len(tc.rng.Desc().Replicas) != 1 {
+		t.Errorf("expected range to keep its replica; got %+v", tc.rng.Desc().Replicas)
+	}
+}
+
+// TestRangeDanglingMetaIntent creates a dangling intent on a meta2
+// record and verifies that RangeLookup requests behave
+// appropriately. Normally, the old value and a write intent error
+// should be returned. If IgnoreIntents is specified, then a random
+// choice of old or new is returned with no error.
+// TODO(tschottdorf): add a test in which there is a dangling intent on a
+// descriptor we would've otherwise discarded in a reverse scan; verify that
+// we don't erroneously return that descriptor (recently fixed bug) if the
+func TestRangeDanglingMetaIntent(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	// Test RangeLookup with Scan.
+	testRangeDanglingMetaIntent(t, false)
+	// Test RangeLookup with ReverseScan.
+	testRangeDanglingMetaIntent(t, true)
+}
+
+func testRangeDanglingMetaIntent(t *testing.T, isReverse bool) {
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	key := roachpb.Key("a")
+
+	// Get original meta2 descriptor.
+	rlArgs := &roachpb.RangeLookupRequest{
+		Span: roachpb.Span{
+			Key: keys.RangeMetaKey(roachpb.RKey(key)),
+		},
+		MaxRanges: 1,
+		Reverse:   isReverse,
+	}
+
+	var rlReply *roachpb.RangeLookupResponse
+
+	reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+		ReadConsistency: roachpb.INCONSISTENT,
+	}, rlArgs)
+	if err != nil {
+		t.Fatal(err)
 	}
+	rlReply = reply.(*roachpb.RangeLookupResponse)
 
-	// Truncating logs that have already been truncated should not return an
-	// error.
-	truncateArgs = truncateLogArgs(indexes[3], rangeID)
-	if _, err = client.SendWrapped(tc.Sender(), tc.rng.context(), &truncateArgs); err != nil {
+	origDesc := rlReply.Ranges[0]
+	newDesc := origDesc
+	newDesc.EndKey = keys.Addr(key)
+
+	// Write the new descriptor as an intent.
+	data, err := proto.Marshal(&newDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
+	// Officially begin the transaction. If not for this, the intent resolution
+	// machinery would simply remove the intent we write below, see #3020.
+	// We send directly to Replica throughout this test, so there's no danger
+	// of the Store aborting this transaction (i.e. we don't have to set a high
+	// priority).
+	bArgs, h := beginTxnArgs(key, txn)
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &bArgs); err != nil {
 		t.Fatal(err)
 	}
 
-	// Truncating logs that have the wrong rangeID included should not return
-	// an error but should not truncate any logs.
-	truncateArgs = truncateLogArgs(indexes[9], rangeID+1)
-	if _, err = client.SendWrapped(tc.Sender(), tc.rng.context(), &truncateArgs); err != nil {
+	pArgs := putArgs(keys.RangeMetaKey(roachpb.RKey(key)), data)
+	txn.Sequence++
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Txn: txn}, &pArgs); err != nil {
 		t.Fatal(err)
 	}
 
-	// The term of the last truncated entry is still available.
-	term, err = tc.rng.Term(indexes[4])
+	// Now lookup the range; should get the value. Since the lookup is
+	// inconsistent, there's no WriteIntentError.
+	// Note that 'A' < 'a'.
+	rlArgs.Key = keys.RangeMetaKey(roachpb.RKey{'A'})
+
+	reply, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+		Timestamp:       roachpb.MinTimestamp,
+		ReadConsistency: roachpb.INCONSISTENT,
+	}, rlArgs)
 	if err != nil {
-		t.Fatal(err)
+		t.Errorf("unexpected lookup error: %s", err)
 	}
-	if term == 0 {
-		t.Errorf("invalid term 0 for truncated entry")
+	rlReply = reply.(*roachpb.RangeLookupResponse)
+	if !reflect.DeepEqual(rlReply.Ranges[0], origDesc) {
+		t.Errorf("expected original descriptor %s; got %s", &origDesc, &rlReply.Ranges[0])
 	}
-}
 
-func TestRaftStorage(t *testing.T) {
-	defer leaktest.AfterTest(t)
-	var eng engine.Engine
-	stopper := stop.NewStopper()
-	defer stopper.Stop()
-	storagetest.RunTests(t,
-		func(t *testing.T) storagetest.WriteableStorage {
-			eng = engine.NewInMem(roachpb.Attributes{Attrs: []string{"dc1", "mem"}}, 1<<20, stopper)
-			// Fake store to house the engine.
-			store := &Store{
-				ctx: StoreContext{
-					Clock: hlc.NewClock(hlc.UnixNano),
-				},
-				engine: eng,
-			}
-			rng, err := NewReplica(&roachpb.RangeDescriptor{
-				RangeID:  1,
-				StartKey: roachpb.RKeyMin,
-				EndKey:   roachpb.RKeyMax,
-			}, store)
-			if err != nil {
-				t.Fatal(err)
-			}
-			return rng
-		},
-		func(t *testing.T, r storagetest.WriteableStorage) {
-			// Do nothing
-		})
+	// Switch to consistent lookups, which should run into the intent.
+	_, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+		ReadConsistency: roachpb.CONSISTENT,
+	}, rlArgs)
+	if _, ok := err.(*roachpb.WriteIntentError); !ok {
+		t.Fatalf("expected WriteIntentError, not %s", err)
+	}
+
+	// Try 100 lookups with IgnoreIntents. Expect to see each descriptor at least once.
+	// First, try this consistently, which should not be allowed.
+	rlArgs.ConsiderIntents = true
+	_, err = client.SendWrapped(tc.Sender(), tc.rng.context(), rlArgs)
+	if !testutils.IsError(err, "can not read consistently and special-case intents") {
+		t.Fatalf("wanted specific error, not %s", err)
+	}
+	// After changing back to inconsistent lookups, should be good to go.
+	var origSeen, newSeen bool
+
+	for !(origSeen && newSeen) {
+		clonedRLArgs := proto.Clone(rlArgs).(*roachpb.RangeLookupRequest)
+
+		reply, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			ReadConsistency: roachpb.INCONSISTENT,
+		}, clonedRLArgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rlReply = reply.(*roachpb.RangeLookupResponse)
+		seen := rlReply.Ranges[0]
+		if reflect.DeepEqual(seen, origDesc) {
+			origSeen = true
+		} else if reflect.DeepEqual(seen, newDesc) {
+			newSeen = true
+		} else {
+			t.Errorf("expected orig/new descriptor %s/%s; got %s", &origDesc, &newDesc, &seen)
+		}
+	}
 }
 
-// TestConditionFailedError tests that a ConditionFailedError correctly
-// bubbles up from MVCC to Range.
-func TestConditionFailedError(t *testing.T) {
+// TestRangeLookupUseReverseScan verifies the correctness of the results which are retrieved
+// from RangeLookup by using ReverseScan.
+func TestRangeLookupUseReverseScan(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	key := []byte("k")
-	value := []byte("quack")
-	pArgs := putArgs(key, value)
+	// Test ranges: ["a","c"), ["c","f"), ["f","h") and ["h","y").
+	testRanges := []roachpb.RangeDescriptor{
+		{RangeID: 2, StartKey: roachpb.RKey("a"), EndKey: roachpb.RKey("c")},
+		{RangeID: 3, StartKey: roachpb.RKey("c"), EndKey: roachpb.RKey("f")},
+		{RangeID: 4, StartKey: roachpb.RKey("f"), EndKey: roachpb.RKey("h")},
+		{RangeID: 5, StartKey: roachpb.RKey("h"), EndKey: roachpb.RKey("y")},
+	}
+	// The range ["f","h") has dangling intent in meta2.
+	withIntentRangeIndex := 2
 
-	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
-		t.Fatal(err)
+	testCases := []struct {
+		key      string
+		expected roachpb.RangeDescriptor
+	}{
+		// For testRanges[0|1|3] there is no intent. A key in the middle
+		// and the end key should both give us the range itself.
+		{key: "b", expected: testRanges[0]},
+		{key: "c", expected: testRanges[0]},
+		{key: "d", expected: testRanges[1]},
+		{key: "f", expected: testRanges[1]},
+		{key: "j", expected: testRanges[3]},
+		// testRanges[2] has an intent, so the inconsistent scan will read
+		// an old value (nil). Since we're in reverse mode, testRanges[1]
+		// is the result.
+		{key: "g", expected: testRanges[1]},
+		{key: "h", expected: testRanges[1]},
 	}
-	val := roachpb.MakeValueFromString("moo")
-	args := roachpb.ConditionalPutRequest{
+
+	txn := &roachpb.Transaction{ID: uuid.NewUUID4(), Timestamp: tc.clock.Now(), Sequence: 1}
+	for i, r := range testRanges {
+		if i != withIntentRangeIndex {
+			// Write the new descriptor as an intent.
+			data, err := proto.Marshal(&r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pArgs := putArgs(keys.RangeMetaKey(roachpb.RKey(r.EndKey)), data)
+
+			txn.Sequence++
+			if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Txn: txn}, &pArgs); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// Resolve the intents.
+	rArgs := &roachpb.ResolveIntentRangeRequest{
 		Span: roachpb.Span{
-			Key: key,
+			Key:    keys.RangeMetaKey(roachpb.RKey("a")),
+			EndKey: keys.RangeMetaKey(roachpb.RKey("z")),
 		},
-		Value:    roachpb.MakeValueFromBytes(value),
-		ExpValue: &val,
+		IntentTxn: *txn,
+	}
+	rArgs.IntentTxn.Status = roachpb.COMMITTED
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), rArgs); err != nil {
+		t.Fatal(err)
 	}
 
-	_, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Timestamp: roachpb.MinTimestamp}, &args)
+	// Get original meta2 descriptor.
+	rlArgs := &roachpb.RangeLookupRequest{
+		MaxRanges: 1,
+		Reverse:   true,
+	}
+	var rlReply *roachpb.RangeLookupResponse
 
-	if cErr, ok := err.(*roachpb.ConditionFailedError); err == nil || !ok {
-		t.Fatalf("expected ConditionFailedError, got %T with content %+v",
-			err, err)
-	} else if valueBytes, err := cErr.ActualValue.GetBytes(); err != nil {
-		t.Fatal(err)
-	} else if cErr.ActualValue == nil || !bytes.Equal(valueBytes, value) {
-		t.Errorf("ConditionFailedError with bytes %q expected, but got %+v",
-			value, cErr.ActualValue)
+	// Test ReverseScan without intents.
+	for _, c := range testCases {
+		clonedRLArgs := proto.Clone(rlArgs).(*roachpb.RangeLookupRequest)
+		clonedRLArgs.Key = keys.RangeMetaKey(roachpb.RKey(c.key))
+		reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			ReadConsistency: roachpb.INCONSISTENT,
+		}, clonedRLArgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rlReply = reply.(*roachpb.RangeLookupResponse)
+		seen := rlReply.Ranges[0]
+		if !(seen.StartKey.Equal(c.expected.StartKey) && seen.EndKey.Equal(c.expected.EndKey)) {
+			t.Errorf("expected descriptor %s; got %s", &c.expected, &seen)
+		}
 	}
-}
 
-// TestReplicaSetsEqual tests to ensure that intersectReplicaSets
-// returns the correct responses.
-func TestReplicaSetsEqual(t *testing.T) {
-	defer leaktest.AfterTest(t)
-	testData := []struct {
-		expected bool
-		a        []roachpb.ReplicaDescriptor
-		b        []roachpb.ReplicaDescriptor
-	}{
-		{true, []roachpb.ReplicaDescriptor{}, []roachpb.ReplicaDescriptor{}},
-		{true, createReplicaSets([]roachpb.StoreID{1}), createReplicaSets([]roachpb.StoreID{1})},
-		{true, createReplicaSets([]roachpb.StoreID{1, 2}), createReplicaSets([]roachpb.StoreID{1, 2})},
-		{true, createReplicaSets([]roachpb.StoreID{1, 2}), createReplicaSets([]roachpb.StoreID{2, 1})},
-		{false, createReplicaSets([]roachpb.StoreID{1}), createReplicaSets([]roachpb.StoreID{2})},
-		{false, createReplicaSets([]roachpb.StoreID{1, 2}), createReplicaSets([]roachpb.StoreID{2})},
-		{false, createReplicaSets([]roachpb.StoreID{1, 2}), createReplicaSets([]roachpb.StoreID{1})},
-		{false, createReplicaSets([]roachpb.StoreID{}), createReplicaSets([]roachpb.StoreID{1})},
-		{true, createReplicaSets([]roachpb.StoreID{1, 2, 3}), createReplicaSets([]roachpb.StoreID{2, 3, 1})},
-		{true, createReplicaSets([]roachpb.StoreID{1, 1}), createReplicaSets([]roachpb.StoreID{1, 1})},
-		{false, createReplicaSets([]roachpb.StoreID{1, 1}), createReplicaSets([]roachpb.StoreID{1, 1, 1})},
-		{true, createReplicaSets([]roachpb.StoreID{1, 2, 3, 1, 2, 3}), createReplicaSets([]roachpb.StoreID{1, 1, 2, 2, 3, 3})},
+	// Write the new descriptor as an intent.
+	intentRange := testRanges[withIntentRangeIndex]
+	data, err := proto.Marshal(&intentRange)
+	if err != nil {
+		t.Fatal(err)
 	}
-	for _, test := range testData {
-		if replicaSetsEqual(test.a, test.b) != test.expected {
-			t.Fatalf("unexpected replica intersection: %+v", test)
+	pArgs := putArgs(keys.RangeMetaKey(roachpb.RKey(intentRange.EndKey)), data)
+	txn2 := &roachpb.Transaction{ID: uuid.NewUUID4(), Timestamp: tc.clock.Now(), Sequence: 1}
+	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Txn: txn2}, &pArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test ReverseScan with intents.
+	for _, c := range testCases {
+		clonedRLArgs := proto.Clone(rlArgs).(*roachpb.RangeLookupRequest)
+		clonedRLArgs.Key = keys.RangeMetaKey(roachpb.RKey(c.key))
+		reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			ReadConsistency: roachpb.INCONSISTENT,
+		}, clonedRLArgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rlReply = reply.(*roachpb.RangeLookupResponse)
+		seen := rlReply.Ranges[0]
+		if !(seen.StartKey.Equal(c.expected.StartKey) && seen.EndKey.Equal(c.expected.EndKey)) {
+			t.Errorf("expected descriptor %s; got %s", &c.expected, &seen)
 		}
 	}
 }
 
-func TestAppliedIndex(t *testing.T) {
+func TestRangeLookup(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	var appliedIndex uint64
-	var sum int64
-	for i := int64(1); i <= 10; i++ {
-		args := incrementArgs([]byte("a"), i)
-
-		resp, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
-		if err != nil {
-			t.Fatal(err)
-		}
-		reply := resp.(*roachpb.IncrementResponse)
-		sum += i
+	expected := []roachpb.RangeDescriptor{*tc.rng.Desc()}
+	testCases := []struct {
+		key      roachpb.RKey
+		reverse  bool
+		expected []roachpb.RangeDescriptor
+	}{
 
-		if reply.NewValue != sum {
-			t.Errorf("expected %d, got %d", sum, reply.NewValue)
-		}
+		// Test with the first range (StartKey==KeyMin). Normally we look
+		// up this range in gossip instead of executing the RPC, but
+		// RangeLookup is still used when up-to-date information is
+		// required.
+		{key: roachpb.RKeyMin, reverse: false, expected: expected},
+		// Test with the last key in a meta prefix. This is an edge case in the
+		// implementation.
+		{key: keys.Addr(keys.Meta1KeyMax), reverse: false, expected: expected},
+		{key: keys.Addr(keys.Meta2KeyMax), reverse: false, expected: nil},
+		{key: keys.Addr(keys.Meta1KeyMax), reverse: true, expected: expected},
+		{key: keys.Addr(keys.Meta2KeyMax), reverse: true, expected: expected},
+	}
 
-		newAppliedIndex := atomic.LoadUint64(&tc.rng.appliedIndex)
-		if newAppliedIndex <= appliedIndex {
-			t.Errorf("appliedIndex did not advance. Was %d, now %d", appliedIndex, newAppliedIndex)
+	for _, c := range testCases {
+		resp, err := client.SendWrapped(tc.Sender(), nil, &roachpb.RangeLookupRequest{
+			Span: roachpb.Span{
+				Key: c.key.AsRawKey(),
+			},
+			MaxRanges: 1,
+			Reverse:   c.reverse,
+		})
+		if err != nil {
+			if c.expected != nil {
+				t.Fatal(err)
+			}
+		} else {
+			reply := resp.(*roachpb.RangeLookupResponse)
+			if !reflect.DeepEqual(reply.Ranges, c.expected) {
+				t.Fatalf("expected %+v, got %+v", c.expected, reply.Ranges)
+			}
 		}
-		appliedIndex = newAppliedIndex
 	}
 }
 
-// TestReplicaCorruption verifies that a replicaCorruptionError correctly marks
-// the range as corrupt.
-func TestReplicaCorruption(t *testing.T) {
+// TestRangeLookupMulti verifies that RangeLookupMulti returns the
+// descriptor of every range addressed by the requested keys exactly once.
+func TestRangeLookupMulti(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	args := putArgs(roachpb.Key("test"), []byte("value"))
-	_, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
-	if err != nil {
-		t.Fatal(err)
+	// Test ranges: ["a","c") and ["c","f").
+	testRanges := []roachpb.RangeDescriptor{
+		{RangeID: 2, StartKey: roachpb.RKey("a"), EndKey: roachpb.RKey("c")},
+		{RangeID: 3, StartKey: roachpb.RKey("c"), EndKey: roachpb.RKey("f")},
 	}
-	// Set the stored applied index sky high.
-	newIndex := 2*atomic.LoadUint64(&tc.rng.appliedIndex) + 1
-	atomic.StoreUint64(&tc.rng.appliedIndex, newIndex)
-	// Not really needed, but let's be thorough.
-	err = setAppliedIndex(tc.rng.store.Engine(), tc.rng.Desc().RangeID, newIndex)
-	if err != nil {
-		t.Fatal(err)
+	for _, r := range testRanges {
+		data, err := proto.Marshal(&r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pArgs := putArgs(keys.RangeMetaKey(r.EndKey), data)
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
 	}
-	// Should mark replica corrupt (and panic as a result) since we messed
-	// with the applied index.
-	_, err = client.SendWrapped(tc.Sender(), tc.rng.context(), &args)
 
-	if err == nil || !strings.Contains(err.Error(), "replica corruption (processed=true)") {
-		t.Fatalf("unexpected error: %s", err)
+	testCases := []struct {
+		keys     []string
+		expected []roachpb.RangeDescriptor
+	}{
+		// Keys addressing the same range.
+		{[]string{"a1", "b"}, testRanges[:1]},
+		// Keys addressing distinct ranges.
+		{[]string{"d", "b"}, []roachpb.RangeDescriptor{testRanges[1], testRanges[0]}},
+		// A mix of both.
+		{[]string{"a1", "d", "b", "e"}, testRanges},
+	}
+	for i, c := range testCases {
+		args := &roachpb.RangeLookupMultiRequest{
+			Span: roachpb.Span{
+				Key:    keys.RangeMetaKey(roachpb.RKey("a")),
+				EndKey: keys.RangeMetaKey(roachpb.RKey("z")),
+			},
+		}
+		for _, key := range c.keys {
+			args.Keys = append(args.Keys, keys.RangeMetaKey(roachpb.RKey(key)))
+		}
+		resp, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			ReadConsistency: roachpb.INCONSISTENT,
+		}, args)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if ranges := resp.(*roachpb.RangeLookupMultiResponse).Ranges; !reflect.DeepEqual(ranges, c.expected) {
+			t.Errorf("%d: expected %+v, got %+v", i, c.expected, ranges)
+		}
 	}
 }
 
-// TestChangeReplicasDuplicateError tests that a replica change that would
-// use a NodeID twice in the replica configuration fails.
-func TestChangeReplicasDuplicateError(t *testing.T) {
+// TestRangeLookupConsistency verifies that consistent and inconsistent
+// range lookups return the same descriptor.
+func TestRangeLookupConsistency(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	if err := tc.rng.ChangeReplicas(roachpb.ADD_REPLICA, roachpb.ReplicaDescriptor{
-		NodeID:  tc.store.Ident.NodeID,
-		StoreID: 9999,
-	}, tc.rng.Desc()); err == nil || !strings.Contains(err.Error(),
-		"already present") {
-		t.Fatalf("must not be able to add second replica to same node (err=%s)",
-			err)
+	expected := []roachpb.RangeDescriptor{*tc.rng.Desc()}
+	for _, consistency := range []roachpb.ReadConsistencyType{roachpb.CONSISTENT, roachpb.INCONSISTENT} {
+		resp, err := client.SendWrappedWith(tc.Sender(), nil, roachpb.Header{
+			ReadConsistency: consistency,
+		}, &roachpb.RangeLookupRequest{
+			Span: roachpb.Span{
+				Key: keys.RangeMetaKey(roachpb.RKey("a")),
+			},
+			MaxRanges: 1,
+		})
+		if err != nil {
+			t.Fatalf("%s: %s", consistency, err)
+		}
+		reply := resp.(*roachpb.RangeLookupResponse)
+		if !reflect.DeepEqual(reply.Ranges, expected) {
+			t.Errorf("%s: expected %+v, got %+v", consistency, expected, reply.Ranges)
+		}
 	}
 }
 
-// TestRangeDanglingMetaIntent creates a dangling intent on a meta2
-// record and verifies that RangeLookup requests behave
-// appropriately. Normally, the old value and a write intent error
-// should be returned. If IgnoreIntents is specified, then a random
-// choice of old or new is returned with no error.
-// TODO(tschottdorf): add a test in which there is a dangling intent on a
-// descriptor we would've otherwise discarded in a reverse scan; verify that
-// we don't erroneously return that descriptor (recently fixed bug) if the
-func TestRangeDanglingMetaIntent(t *testing.T) {
+// TestRangeDeleteRangePredicate verifies that a DeleteRange with a
+// predicate deletes and counts only the keys whose values match it.
+func TestRangeDeleteRangePredicate(t *testing.T) {
 	defer leaktest.AfterTest(t)
-	// Test RangeLookup with Scan.
-	testRangeDanglingMetaIntent(t, false)
-	// Test RangeLookup with ReverseScan.
-	testRangeDanglingMetaIntent(t, true)
-}
-
-func testRangeDanglingMetaIntent(t *testing.T, isReverse bool) {
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	key := roachpb.Key("a")
-
-	// Get original meta2 descriptor.
-	rlArgs := &roachpb.RangeLookupRequest{
-		Span: roachpb.Span{
-			Key: keys.RangeMetaKey(roachpb.RKey(key)),
-		},
-		MaxRanges: 1,
-		Reverse:   isReverse,
+	writes := []struct {
+		key   string
+		ts    roachpb.Timestamp
+		value string
+	}{
+		{"a", makeTS(1, 0), "keep-1"},
+		{"b", makeTS(1, 0), "drop-1"},
+		{"c", makeTS(3, 0), "drop-2"},
+		{"d", makeTS(3, 0), "keep-2"},
 	}
+	before := makeTS(2, 0)
+	testCases := []struct {
+		predicate roachpb.DeleteRangePredicate
+		expKeys   []string
+	}{
+		{roachpb.DeleteRangePredicate{Before: &before}, []string{"c", "d"}},
+		{roachpb.DeleteRangePredicate{ValuePrefix: []byte("drop")}, []string{"a", "d"}},
+		{roachpb.DeleteRangePredicate{Before: &before, ValuePrefix: []byte("drop")}, []string{"a", "c", "d"}},
+	}
+
+	for i, c := range testCases {
+		prefix := roachpb.Key(fmt.Sprintf("%d/", i))
+		for _, w := range writes {
+			pArgs := putArgs(roachpb.Key(fmt.Sprintf("%s%s", prefix, w.key)), []byte(w.value))
+			if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+				Timestamp: w.ts,
+			}, &pArgs); err != nil {
+				t.Fatal(err)
+			}
+		}
 
-	var rlReply *roachpb.RangeLookupResponse
+		ts := makeTS(5, 0)
+		predicate := c.predicate
+		resp, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Timestamp: ts,
+		}, &roachpb.DeleteRangeRequest{
+			Span: roachpb.Span{
+				Key:    prefix,
+				EndKey: prefix.PrefixEnd(),
+			},
+			Predicate: &predicate,
+		})
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if num, expNum := resp.(*roachpb.DeleteRangeResponse).NumDeleted, int64(len(writes)-len(c.expKeys)); num != expNum {
+			t.Errorf("%d: expected %d keys deleted; got %d", i, expNum, num)
+		}
 
-	reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-		ReadConsistency: roachpb.INCONSISTENT,
-	}, rlArgs)
-	if err != nil {
-		t.Fatal(err)
+		kvs, _, err := engine.MVCCScan(tc.engine, prefix, prefix.PrefixEnd(), 0, ts, true, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var keys []string
+		for _, kv := range kvs {
+			keys = append(keys, string(kv.Key[len(prefix):]))
+		}
+		if !reflect.DeepEqual(keys, c.expKeys) {
+			t.Errorf("%d: expected remaining keys %v; got %v", i, c.expKeys, keys)
+		}
 	}
-	rlReply = reply.(*roachpb.RangeLookupResponse)
-
-	origDesc := rlReply.Ranges[0]
-	newDesc := origDesc
-	newDesc.EndKey = keys.Addr(key)
+}
 
-	// Write the new descriptor as an intent.
-	data, err := proto.Marshal(&newDesc)
-	if err != nil {
-		t.Fatal(err)
-	}
-	txn := newTransaction("test", key, 1, roachpb.SERIALIZABLE, tc.clock)
-	// Officially begin the transaction. If not for this, the intent resolution
-	// machinery would simply remove the intent we write below, see #3020.
-	// We send directly to Replica throughout this test, so there's no danger
-	// of the Store aborting this transaction (i.e. we don't have to set a high
-	// priority).
-	bArgs, h := beginTxnArgs(key, txn)
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), h, &bArgs); err != nil {
-		t.Fatal(err)
-	}
+// TestRangeVerify verifies that Verify reports no discrepancies for
+// intact data, and reports both a corrupted value and inaccurate stats.
+func TestRangeVerify(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
 
-	pArgs := putArgs(keys.RangeMetaKey(roachpb.RKey(key)), data)
-	txn.Sequence++
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Txn: txn}, &pArgs); err != nil {
-		t.Fatal(err)
+	ts := makeTS(1, 0)
+	for _, key := range []string{"a", "b", "c"} {
+		pArgs := putArgs(roachpb.Key(key), []byte("value-"+key))
+		if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Timestamp: ts,
+		}, &pArgs); err != nil {
+			t.Fatal(err)
+		}
 	}
 
-	// Now lookup the range; should get the value. Since the lookup is
-	// inconsistent, there's no WriteIntentError.
-	// Note that 'A' < 'a'.
-	rlArgs.Key = keys.RangeMetaKey(roachpb.RKey{'A'})
-
-	reply, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-		Timestamp:       roachpb.MinTimestamp,
-		ReadConsistency: roachpb.INCONSISTENT,
-	}, rlArgs)
-	if err != nil {
-		t.Errorf("unexpected lookup error: %s", err)
-	}
-	rlReply = reply.(*roachpb.RangeLookupResponse)
-	if !reflect.DeepEqual(rlReply.Ranges[0], origDesc) {
-		t.Errorf("expected original descriptor %s; got %s", &origDesc, &rlReply.Ranges[0])
+	verify := func() []string {
+		resp, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &roachpb.VerifyRequest{
+			Span: roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("z")},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.(*roachpb.VerifyResponse).Discrepancies
 	}
-
-	// Switch to consistent lookups, which should run into the intent.
-	_, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-		ReadConsistency: roachpb.CONSISTENT,
-	}, rlArgs)
-	if _, ok := err.(*roachpb.WriteIntentError); !ok {
-		t.Fatalf("expected WriteIntentError, not %s", err)
+	if d := verify(); len(d) != 0 {
+		t.Fatalf("expected no discrepancies; got %v", d)
 	}
 
-	// Try 100 lookups with IgnoreIntents. Expect to see each descriptor at least once.
-	// First, try this consistently, which should not be allowed.
-	rlArgs.ConsiderIntents = true
-	_, err = client.SendWrapped(tc.Sender(), tc.rng.context(), rlArgs)
-	if !testutils.IsError(err, "can not read consistently and special-case intents") {
-		t.Fatalf("wanted specific error, not %s", err)
+	// Flip the last byte of the value stored for "b", leaving its size and
+	// therefore the stats unchanged.
+	versionKey := engine.MVCCKey{Key: roachpb.Key("b"), Timestamp: ts}
+	data, err := tc.engine.Get(versionKey)
+	if err != nil {
+		t.Fatal(err)
 	}
-	// After changing back to inconsistent lookups, should be good to go.
-	var origSeen, newSeen bool
-
-	for !(origSeen && newSeen) {
-		clonedRLArgs := proto.Clone(rlArgs).(*roachpb.RangeLookupRequest)
-
-		reply, err = client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-			ReadConsistency: roachpb.INCONSISTENT,
-		}, clonedRLArgs)
-		if err != nil {
-			t.Fatal(err)
-		}
-		rlReply = reply.(*roachpb.RangeLookupResponse)
-		seen := rlReply.Ranges[0]
-		if reflect.DeepEqual(seen, origDesc) {
-			origSeen = true
-		} else if reflect.DeepEqual(seen, newDesc) {
-			newSeen = true
-		} else {
-			t.Errorf("expected orig/new descriptor %s/%s; got %s", &origDesc, &newDesc, &seen)
-		}
+	data[len(data)-1] ^= 0x01
+	if err := tc.engine.Put(versionKey, data); err != nil {
+		t.Fatal(err)
+	}
+
+	// Inflate the maintained key count.
+	ms := tc.rng.stats.GetMVCC()
+	ms.KeyCount++
+	if err := tc.rng.stats.SetMVCCStats(tc.engine, ms); err != nil {
+		t.Fatal(err)
+	}
+
+	d := verify()
+	if len(d) != 2 {
+		t.Fatalf("expected 2 discrepancies; got %v", d)
+	}
+	if !strings.Contains(d[0], "invalid checksum") {
+		t.Errorf("expected checksum discrepancy; got %q", d[0])
+	}
+	if expected := fmt.Sprintf("key count: maintained %d, counted %d", ms.KeyCount, ms.KeyCount-1); d[1] != expected {
+		t.Errorf("expected %q; got %q", expected, d[1])
 	}
 }
 
-// TestRangeLookupUseReverseScan verifies the correctness of the results which are retrieved
-// from RangeLookup by using ReverseScan.
-func TestRangeLookupUseReverseScan(t *testing.T) {
+// TestReplicaComputeChecksum verifies that ComputeChecksum reports the same
+// checksum for identical data at the same applied index, ignores the raft
+// state held by each replica individually and changes with the data.
+func TestReplicaComputeChecksum(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	// Test ranges: ["a","c"), ["c","f"), ["f","h") and ["h","y").
-	testRanges := []roachpb.RangeDescriptor{
-		{RangeID: 2, StartKey: roachpb.RKey("a"), EndKey: roachpb.RKey("c")},
-		{RangeID: 3, StartKey: roachpb.RKey("c"), EndKey: roachpb.RKey("f")},
-		{RangeID: 4, StartKey: roachpb.RKey("f"), EndKey: roachpb.RKey("h")},
-		{RangeID: 5, StartKey: roachpb.RKey("h"), EndKey: roachpb.RKey("y")},
+	computeChecksum := func() *roachpb.ComputeChecksumResponse {
+		resp, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &roachpb.ComputeChecksumRequest{
+			Span: roachpb.Span{Key: roachpb.Key("a")},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.(*roachpb.ComputeChecksumResponse)
 	}
-	// The range ["f","h") has dangling intent in meta2.
-	withIntentRangeIndex := 2
 
-	testCases := []struct {
-		key      string
-		expected roachpb.RangeDescriptor
-	}{
-		// For testRanges[0|1|3] there is no intent. A key in the middle
-		// and the end key should both give us the range itself.
-		{key: "b", expected: testRanges[0]},
-		{key: "c", expected: testRanges[0]},
-		{key: "d", expected: testRanges[1]},
-		{key: "f", expected: testRanges[1]},
-		{key: "j", expected: testRanges[3]},
-		// testRanges[2] has an intent, so the inconsistent scan will read
-		// an old value (nil). Since we're in reverse mode, testRanges[1]
-		// is the result.
-		{key: "g", expected: testRanges[1]},
-		{key: "h", expected: testRanges[1]},
+	pArgs := putArgs(roachpb.Key("a"), []byte("value"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+	first := computeChecksum()
+	if len(first.Checksum) == 0 || first.AppliedIndex == 0 {
+		t.Fatalf("expected a checksum and an applied index; got %+v", first)
+	}
+	if second := computeChecksum(); !bytes.Equal(first.Checksum, second.Checksum) ||
+		first.AppliedIndex != second.AppliedIndex {
+		t.Fatalf("repeated computation differs: %+v != %+v", first, second)
 	}
 
-	txn := &roachpb.Transaction{ID: uuid.NewUUID4(), Timestamp: tc.clock.Now(), Sequence: 1}
-	for i, r := range testRanges {
-		if i != withIntentRangeIndex {
-			// Write the new descriptor as an intent.
-			data, err := proto.Marshal(&r)
-			if err != nil {
-				t.Fatal(err)
-			}
-			pArgs := putArgs(keys.RangeMetaKey(roachpb.RKey(r.EndKey)), data)
-
-			txn.Sequence++
-			if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Txn: txn}, &pArgs); err != nil {
-				t.Fatal(err)
-			}
+	// Copy the replica's data into a separate engine; it must checksum
+	// identically, even once its raft hard state has diverged.
+	clone := engine.NewInMem(roachpb.Attributes{}, 1<<20, tc.stopper)
+	iter := newReplicaDataIterator(tc.rng.Desc(), tc.engine)
+	for ; iter.Valid(); iter.Next() {
+		if err := clone.Put(iter.Key(), iter.Value()); err != nil {
+			t.Fatal(err)
 		}
 	}
+	iter.Close()
+	hs := raftpb.HardState{Term: 100, Vote: 2, Commit: first.AppliedIndex}
+	if err := engine.MVCCPutProto(clone, nil, keys.RaftHardStateKey(tc.rng.Desc().RangeID),
+		roachpb.ZeroTimestamp, nil, &hs); err != nil {
+		t.Fatal(err)
+	}
+	sum, index, err := tc.rng.checksum(clone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sum, first.Checksum) || index != first.AppliedIndex {
+		t.Fatalf("expected clone to match at index %d; got index %d", first.AppliedIndex, index)
+	}
 
-	// Resolve the intents.
-	rArgs := &roachpb.ResolveIntentRangeRequest{
-		Span: roachpb.Span{
-			Key:    keys.RangeMetaKey(roachpb.RKey("a")),
-			EndKey: keys.RangeMetaKey(roachpb.RKey("z")),
-		},
-		IntentTxn: *txn,
+	// Diverging user data on the clone must be detected.
+	if err := engine.MVCCPut(clone, nil, roachpb.Key("b"), makeTS(1, 0),
+		roachpb.MakeValueFromString("diverged"), nil); err != nil {
+		t.Fatal(err)
 	}
-	rArgs.IntentTxn.Status = roachpb.COMMITTED
-	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), rArgs); err != nil {
+	if sum, _, err = tc.rng.checksum(clone); err != nil {
 		t.Fatal(err)
+	} else if bytes.Equal(sum, first.Checksum) {
+		t.Fatal("expected checksum to change after diverging write")
 	}
 
-	// Get original meta2 descriptor.
-	rlArgs := &roachpb.RangeLookupRequest{
-		MaxRanges: 1,
-		Reverse:   true,
+	// A write through raft advances the applied index and the checksum.
+	pArgs = putArgs(roachpb.Key("c"), []byte("value"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
 	}
-	var rlReply *roachpb.RangeLookupResponse
+	if third := computeChecksum(); third.AppliedIndex <= first.AppliedIndex ||
+		bytes.Equal(third.Checksum, first.Checksum) {
+		t.Fatalf("expected new index and checksum after write; got %+v (was %+v)", third, first)
+	}
+}
 
-	// Test ReverseScan without intents.
-	for _, c := range testCases {
-		clonedRLArgs := proto.Clone(rlArgs).(*roachpb.RangeLookupRequest)
-		clonedRLArgs.Key = keys.RangeMetaKey(roachpb.RKey(c.key))
-		reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-			ReadConsistency: roachpb.INCONSISTENT,
-		}, clonedRLArgs)
-		if err != nil {
-			t.Fatal(err)
-		}
-		rlReply = reply.(*roachpb.RangeLookupResponse)
-		seen := rlReply.Ranges[0]
-		if !(seen.StartKey.Equal(c.expected.StartKey) && seen.EndKey.Equal(c.expected.EndKey)) {
-			t.Errorf("expected descriptor %s; got %s", &c.expected, &seen)
-		}
+// TestReplicaComputeChecksumAlone verifies that a ComputeChecksum batched
+// with a write is rejected up front rather than being evaluated inside the
+// write's raft batch.
+func TestReplicaComputeChecksumAlone(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	ba := roachpb.BatchRequest{}
+	ba.Timestamp = tc.clock.Now()
+	pArgs := putArgs(roachpb.Key("a"), []byte("value"))
+	ba.Add(&pArgs)
+	ba.Add(&roachpb.ComputeChecksumRequest{Span: roachpb.Span{Key: roachpb.Key("a")}})
+	if _, pErr := tc.Sender().Send(tc.rng.context(), ba); !testutils.IsError(pErr.GoError(), "must be alone in a batch") {
+		t.Fatalf("expected ComputeChecksum to be rejected; got %v", pErr)
 	}
 
-	// Write the new descriptor as an intent.
-	intentRange := testRanges[withIntentRangeIndex]
-	data, err := proto.Marshal(&intentRange)
+	// The replica remains usable and the put was not applied.
+	gArgs := getArgs(roachpb.Key("a"))
+	reply, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &gArgs)
 	if err != nil {
 		t.Fatal(err)
 	}
-	pArgs := putArgs(keys.RangeMetaKey(roachpb.RKey(intentRange.EndKey)), data)
-	txn2 := &roachpb.Transaction{ID: uuid.NewUUID4(), Timestamp: tc.clock.Now(), Sequence: 1}
-	if _, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{Txn: txn2}, &pArgs); err != nil {
-		t.Fatal(err)
-	}
-
-	// Test ReverseScan with intents.
-	for _, c := range testCases {
-		clonedRLArgs := proto.Clone(rlArgs).(*roachpb.RangeLookupRequest)
-		clonedRLArgs.Key = keys.RangeMetaKey(roachpb.RKey(c.key))
-		reply, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
-			ReadConsistency: roachpb.INCONSISTENT,
-		}, clonedRLArgs)
-		if err != nil {
-			t.Fatal(err)
-		}
-		rlReply = reply.(*roachpb.RangeLookupResponse)
-		seen := rlReply.Ranges[0]
-		if !(seen.StartKey.Equal(c.expected.StartKey) && seen.EndKey.Equal(c.expected.EndKey)) {
-			t.Errorf("expected descriptor %s; got %s", &c.expected, &seen)
-		}
+	if v := reply.(*roachpb.GetResponse).Value; v != nil {
+		t.Errorf("expected rejected batch to write nothing; got %+v", v)
 	}
 }
 
-func TestRangeLookup(t *testing.T) {
+// TestRangeScanSince verifies that a scan with a since timestamp returns
+// only the keys written or deleted after it.
+func TestRangeScanSince(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	tc := testContext{}
 	tc.Start(t)
 	defer tc.Stop()
 
-	expected := []roachpb.RangeDescriptor{*tc.rng.Desc()}
-	testCases := []struct {
-		key      roachpb.RKey
-		reverse  bool
-		expected []roachpb.RangeDescriptor
+	send := func(ts roachpb.Timestamp, args roachpb.Request) roachpb.Response {
+		resp, err := client.SendWrappedWith(tc.Sender(), tc.rng.context(), roachpb.Header{
+			Timestamp: ts,
+		}, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	// Keys "a" and "b" are last written at or before the cutoff at 2; "c"
+	// is written and "d" deleted after it.
+	for _, w := range []struct {
+		key string
+		ts  int64
 	}{
-
-		// Test with the first range (StartKey==KeyMin). Normally we look
-		// up this range in gossip instead of executing the RPC, but
-		// RangeLookup is still used when up-to-date information is
-		// required.
-		{key: roachpb.RKeyMin, reverse: false, expected: expected},
-		// Test with the last key in a meta prefix. This is an edge case in the
-		// implementation.
-		{key: keys.Addr(keys.Meta1KeyMax), reverse: false, expected: expected},
-		{key: keys.Addr(keys.Meta2KeyMax), reverse: false, expected: nil},
-		{key: keys.Addr(keys.Meta1KeyMax), reverse: true, expected: expected},
-		{key: keys.Addr(keys.Meta2KeyMax), reverse: true, expected: expected},
+		{"a", 1}, {"b", 2}, {"c", 3}, {"d", 1},
+	} {
+		pArgs := putArgs(roachpb.Key(w.key), []byte(w.key))
+		send(makeTS(w.ts, 0), &pArgs)
 	}
+	dArgs := deleteArgs(roachpb.Key("d"))
+	send(makeTS(4, 0), &dArgs)
 
-	for _, c := range testCases {
-		resp, err := client.SendWrapped(tc.Sender(), nil, &roachpb.RangeLookupRequest{
-			Span: roachpb.Span{
-				Key: c.key.AsRawKey(),
-			},
-			MaxRanges: 1,
-			Reverse:   c.reverse,
-		})
-		if err != nil {
-			if c.expected != nil {
-				t.Fatal(err)
-			}
-		} else {
-			reply := resp.(*roachpb.RangeLookupResponse)
-			if !reflect.DeepEqual(reply.Ranges, c.expected) {
-				t.Fatalf("expected %+v, got %+v", c.expected, reply.Ranges)
-			}
+	sArgs := scanArgs(roachpb.Key("a"), roachpb.Key("z"))
+	sArgs.Since = makeTS(2, 0)
+	reply := send(makeTS(6, 0), &sArgs).(*roachpb.ScanResponse)
+
+	expected := []struct {
+		key       string
+		tombstone bool
+	}{
+		{"c", false},
+		{"d", true},
+	}
+	if len(reply.Rows) != len(expected) {
+		t.Fatalf("expected %d rows; got %v", len(expected), reply.Rows)
+	}
+	for i, kv := range reply.Rows {
+		if string(kv.Key) != expected[i].key || kv.Value.IsTombstone() != expected[i].tombstone {
+			t.Errorf("%d: expected key %q (tombstone=%t); got %q (tombstone=%t)",
+				i, expected[i].key, expected[i].tombstone, kv.Key, kv.Value.IsTombstone())
 		}
 	}
 }
@@ -3344,22 +5057,206 @@ func benchmarkEvents(b *testing.B, sendEvents, consumeEvents bool) {
 	}
 }
 
-// BenchmarkWriteCmdNoEvents benchmarks write commands on a store that does not
-// publish events.
-func BenchmarkWriteCmdNoEvents(b *testing.B) {
-	benchmarkEvents(b, false, false)
+// BenchmarkWriteCmdNoEvents benchmarks write commands on a store that does not
+// publish events.
+func BenchmarkWriteCmdNoEvents(b *testing.B) {
+	benchmarkEvents(b, false, false)
+}
+
+// BenchmarkWriteCmdNoEvents benchmarks write commands on a store that publishes
+// events. There are no subscribers to the events, but they are still produced.
+func BenchmarkWriteCmdWithEvents(b *testing.B) {
+	benchmarkEvents(b, true, false)
+}
+
+// BenchmarkWriteConsumeEvents benchmarks write commands on a store that publishes
+// events. There is a single subscriber reading the events.
+func BenchmarkWriteCmdWithEventsAndConsumer(b *testing.B) {
+	benchmarkEvents(b, true, true)
+}
+
+// benchmarkWorkload drives concurrent Gets, Puts and Scans against a
+// single replica backed by an in-memory engine. readPercent is the
+// percentage of operations which are reads; of those, one in ten is a
+// short scan. In addition to the usual ns/op, the throughput and the 99th
+// percentile latency across all operations are logged.
+func benchmarkWorkload(b *testing.B, readPercent int) {
+	defer leaktest.AfterTest(b)
+	const numKeys = 1000
+	tc := testContext{}
+	tc.Start(b)
+	defer tc.Stop()
+
+	key := func(i int) roachpb.Key {
+		return roachpb.Key(fmt.Sprintf("bench-%04d", i))
+	}
+	for i := 0; i < numKeys; i++ {
+		pArgs := putArgs(key(i), []byte("value"))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	var mu sync.Mutex
+	var latencies []time.Duration
+	b.ResetTimer()
+	start := time.Now()
+	b.RunParallel(func(pb *testing.PB) {
+		rng, _ := randutil.NewPseudoRand()
+		var local []time.Duration
+		for pb.Next() {
+			i := rng.Intn(numKeys)
+			var args roachpb.Request
+			switch op := rng.Intn(100); {
+			case op >= readPercent:
+				pArgs := putArgs(key(i), []byte("value"))
+				args = &pArgs
+			case op%10 == 0:
+				sArgs := scanArgs(key(i), key(i+10))
+				args = &sArgs
+			default:
+				gArgs := getArgs(key(i))
+				args = &gArgs
+			}
+			opStart := time.Now()
+			if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), args); err != nil {
+				b.Error(err)
+				return
+			}
+			local = append(local, time.Since(opStart))
+		}
+		mu.Lock()
+		latencies = append(latencies, local...)
+		mu.Unlock()
+	})
+	elapsed := time.Since(start)
+	b.StopTimer()
+
+	if len(latencies) == 0 {
+		return
+	}
+	sort.Sort(durationSlice(latencies))
+	p99 := latencies[len(latencies)*99/100]
+	b.Logf("%d ops: %.0f ops/sec, p99 latency %s",
+		len(latencies), float64(len(latencies))/elapsed.Seconds(), p99)
+}
+
+type durationSlice []time.Duration
+
+func (s durationSlice) Len() int           { return len(s) }
+func (s durationSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s durationSlice) Less(i, j int) bool { return s[i] < s[j] }
+
+// BenchmarkReplicaReadOnly benchmarks a workload of concurrent Gets and
+// Scans.
+func BenchmarkReplicaReadOnly(b *testing.B) {
+	benchmarkWorkload(b, 100)
+}
+
+// BenchmarkReplicaWriteOnly benchmarks a workload of concurrent Puts.
+func BenchmarkReplicaWriteOnly(b *testing.B) {
+	benchmarkWorkload(b, 0)
+}
+
+// BenchmarkReplicaMixed benchmarks a workload of concurrent reads and
+// writes in equal proportion.
+func BenchmarkReplicaMixed(b *testing.B) {
+	benchmarkWorkload(b, 50)
+}
+
+// benchmarkPuts writes numKeys keys per iteration, either as individual
+// Puts or as a single WriteBatch.
+func benchmarkPuts(b *testing.B, numKeys int, useWriteBatch bool) {
+	defer leaktest.AfterTest(b)
+	tc := testContext{}
+	tc.Start(b)
+	defer tc.Stop()
+
+	value := []byte("value")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if useWriteBatch {
+			pairs := make([]roachpb.KeyValue, numKeys)
+			for j := range pairs {
+				pairs[j].Key = roachpb.Key(fmt.Sprintf("bench-%04d", j))
+				pairs[j].Value = roachpb.MakeValueFromBytes(value)
+			}
+			if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), roachpb.NewWriteBatch(pairs...)); err != nil {
+				b.Fatal(err)
+			}
+			continue
+		}
+		for j := 0; j < numKeys; j++ {
+			pArgs := putArgs(roachpb.Key(fmt.Sprintf("bench-%04d", j)), value)
+			if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// benchmarkScan scans 1000 rows per iteration, either accumulating them
+// with Scan or visiting them with ScanStream.
+func benchmarkScan(b *testing.B, stream bool) {
+	defer leaktest.AfterTest(b)
+	const numKeys = 1000
+	tc := testContext{}
+	tc.Start(b)
+	defer tc.Stop()
+
+	for i := 0; i < numKeys; i++ {
+		pArgs := putArgs(roachpb.Key(fmt.Sprintf("bench-%04d", i)), []byte("value"))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			b.Fatal(err)
+		}
+	}
+	h := roachpb.Header{Timestamp: tc.clock.Now()}
+	sArgs := scanArgs([]byte("bench-"), []byte("bench."))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var numRows int
+		if stream {
+			if _, _, err := tc.rng.ScanStream(tc.engine, h, sArgs, func(roachpb.KeyValue) error {
+				numRows++
+				return nil
+			}); err != nil {
+				b.Fatal(err)
+			}
+		} else {
+			reply, _, err := tc.rng.Scan(tc.engine, h, sArgs)
+			if err != nil {
+				b.Fatal(err)
+			}
+			numRows = len(reply.Rows)
+		}
+		if numRows != numKeys {
+			b.Fatalf("expected %d rows; got %d", numKeys, numRows)
+		}
+	}
+}
+
+// BenchmarkReplicaScan1000 benchmarks a scan of 1000 rows which are
+// accumulated in the response.
+func BenchmarkReplicaScan1000(b *testing.B) {
+	benchmarkScan(b, false)
+}
+
+// BenchmarkReplicaScanStream1000 benchmarks a scan of 1000 rows which are
+// visited one at a time.
+func BenchmarkReplicaScanStream1000(b *testing.B) {
+	benchmarkScan(b, true)
 }
 
-// BenchmarkWriteCmdNoEvents benchmarks write commands on a store that publishes
-// events. There are no subscribers to the events, but they are still produced.
-func BenchmarkWriteCmdWithEvents(b *testing.B) {
-	benchmarkEvents(b, true, false)
+// BenchmarkReplicaPut1000 benchmarks writing 1000 keys with one Put each.
+func BenchmarkReplicaPut1000(b *testing.B) {
+	benchmarkPuts(b, 1000, false)
 }
 
-// BenchmarkWriteConsumeEvents benchmarks write commands on a store that publishes
-// events. There is a single subscriber reading the events.
-func BenchmarkWriteCmdWithEventsAndConsumer(b *testing.B) {
-	benchmarkEvents(b, true, true)
+// BenchmarkReplicaWriteBatch1000 benchmarks writing 1000 keys with a
+// single WriteBatch.
+func BenchmarkReplicaWriteBatch1000(b *testing.B) {
+	benchmarkPuts(b, 1000, true)
 }
 
 type mockRangeManager struct {
@@ -3375,6 +5272,164 @@ func (mrm *mockRangeManager) ProposeRaftCommand(idKey cmdIDKey, cmd roachpb.Raft
 	return mrm.mockProposeRaftCommand(idKey, cmd)
 }
 
+// TestRangeRetryTransientProposalError verifies that write commands are
+// re-proposed after raft dropped them, up to the configured number of
+// retries, and that other errors are returned after a single attempt.
+func TestRangeRetryTransientProposalError(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	// Acquire the leader lease up front so that only the puts below are
+	// proposed through the mock.
+	pArgs := putArgs(roachpb.Key("a"), []byte("value"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	var proposals int
+	var injected []error
+	tc.rng.proposeRaftCommandFn = func(idKey cmdIDKey, cmd roachpb.RaftCommand) <-chan error {
+		proposals++
+		if len(injected) > 0 {
+			ch := make(chan error, 1)
+			ch <- injected[0]
+			injected = injected[1:]
+			return ch
+		}
+		return tc.store.ProposeRaftCommand(idKey, cmd)
+	}
+
+	// A dropped proposal on the first attempt is retried transparently.
+	injected = []error{multiraft.ErrProposalDropped}
+	pArgs = putArgs(roachpb.Key("b"), []byte("value"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+	if proposals != 2 {
+		t.Errorf("expected 2 proposals; got %d", proposals)
+	}
+
+	// Retries are bounded; the last error is returned.
+	proposals = 0
+	maxRetries := tc.store.ctx.RaftProposalRetryOptions.MaxRetries
+	injected = nil
+	for i := 0; i <= maxRetries; i++ {
+		injected = append(injected, multiraft.ErrProposalDropped)
+	}
+	pArgs = putArgs(roachpb.Key("c"), []byte("value"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); !testutils.IsError(err, "raft proposal dropped") {
+		t.Errorf("expected dropped proposal error; got %v", err)
+	}
+	if proposals != maxRetries+1 {
+		t.Errorf("expected %d proposals; got %d", maxRetries+1, proposals)
+	}
+
+	// Non-retryable errors are returned immediately.
+	proposals = 0
+	injected = []error{util.Errorf("permanent proposal error")}
+	pArgs = putArgs(roachpb.Key("d"), []byte("value"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); !testutils.IsError(err, "permanent proposal error") {
+		t.Errorf("expected permanent proposal error; got %v", err)
+	}
+	if proposals != 1 {
+		t.Errorf("expected 1 proposal; got %d", proposals)
+	}
+
+	// Errors which are retryable elsewhere are not re-proposed either.
+	proposals = 0
+	injected = []error{roachpb.NewRangeNotFoundError(tc.rng.Desc().RangeID)}
+	pArgs = putArgs(roachpb.Key("e"), []byte("value"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); !testutils.IsError(err, "range [0-9]+ was not found") {
+		t.Errorf("expected range not found error; got %v", err)
+	}
+	if proposals != 1 {
+		t.Errorf("expected 1 proposal; got %d", proposals)
+	}
+}
+
+// TestReplicaRaftOverload verifies that writes are rejected with a
+// retryable error once the replica's raft backlog reaches the configured
+// limit, without waiting for overlapping commands, and accepted again
+// after it drains.
+func TestReplicaRaftOverload(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	// Acquire the leader lease up front so that only the puts below are
+	// proposed through the mock.
+	pArgs := putArgs(roachpb.Key("a"), []byte("value"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	const maxPending = 3
+	tc.store.ctx.MaxPendingRaftCommands = maxPending
+	tc.store.ctx.RaftOverloadGracePeriod = 10 * time.Millisecond
+
+	// Hold proposals back until released, so that they pile up.
+	release := make(chan struct{})
+	tc.rng.proposeRaftCommandFn = func(idKey cmdIDKey, cmd roachpb.RaftCommand) <-chan error {
+		ch := make(chan error, 1)
+		go func() {
+			<-release
+			ch <- <-tc.store.ProposeRaftCommand(idKey, cmd)
+		}()
+		return ch
+	}
+
+	errs := make(chan error, maxPending)
+	for i := 0; i < maxPending; i++ {
+		go func(i int) {
+			pArgs := putArgs(roachpb.Key(fmt.Sprintf("b%d", i)), []byte("value"))
+			_, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs)
+			errs <- err
+		}(i)
+	}
+	util.SucceedsWithin(t, time.Second, func() error {
+		if n := tc.rng.Metrics().PendingRaftCommands; n != maxPending {
+			return util.Errorf("expected %d pending commands; got %d", maxPending, n)
+		}
+		return nil
+	})
+
+	// The range is saturated: further writes are shed, even one which
+	// overlaps a pending command and would otherwise wait for it in the
+	// command queue.
+	pArgs = putArgs(roachpb.Key("b0"), []byte("value"))
+	_, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs)
+	if oErr, ok := err.(*roachpb.RangeOverloadedError); !ok {
+		t.Fatalf("expected overload error; got %v", err)
+	} else if oErr.RangeID != tc.rng.Desc().RangeID || oErr.Pending != maxPending {
+		t.Errorf("unexpected overload error: %+v", oErr)
+	}
+	if rErr, ok := err.(retry.Retryable); !ok || !rErr.CanRetry() {
+		t.Errorf("expected overload error to be retryable; got %T", err)
+	}
+	// Reads are unaffected.
+	gArgs := getArgs(roachpb.Key("a"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &gArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once the backlog drains, writes are accepted again.
+	close(release)
+	for i := 0; i < maxPending; i++ {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := tc.rng.Metrics().PendingRaftCommands; n != 0 {
+		t.Errorf("expected no pending commands; got %d", n)
+	}
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestRequestLeaderEncounterGroupDeleteError verifies that a request leader proposal which fails with
 // multiraft.ErrGroupDeleted is converted to a RangeNotFoundError in the Store.
 func TestRequestLeaderEncounterGroupDeleteError(t *testing.T) {
@@ -3781,3 +5836,395 @@ func TestTerm(t *testing.T) {
 		t.Errorf("expected ErrUnavailable, got %s", err)
 	}
 }
+
+// TestRangeSnapshotRestore verifies that applying a snapshot replaces the
+// data of the range with the point-in-time image captured by Snapshot.
+func TestRangeSnapshotRestore(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	for _, k := range []string{"a", "b", "c"} {
+		pArgs := putArgs(roachpb.Key(k), []byte(k))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	scan := func() []roachpb.KeyValue {
+		kvs, _, err := engine.MVCCScan(tc.engine, roachpb.Key("a"), roachpb.Key("z"), 0, tc.clock.Now(), true, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return kvs
+	}
+	expKVs := scan()
+
+	snap, err := tc.rng.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Change the range's data after the snapshot was taken.
+	dArgs := deleteArgs(roachpb.Key("a"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &dArgs); err != nil {
+		t.Fatal(err)
+	}
+	pArgs := putArgs(roachpb.Key("d"), []byte("d"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+	if kvs := scan(); reflect.DeepEqual(kvs, expKVs) {
+		t.Fatalf("expected data to change after the snapshot; got %v", kvs)
+	}
+
+	if err := tc.rng.ApplySnapshot(snap); err != nil {
+		t.Fatal(err)
+	}
+	if kvs := scan(); !reflect.DeepEqual(kvs, expKVs) {
+		t.Errorf("expected %v after applying the snapshot; got %v", expKVs, kvs)
+	}
+	if appliedIndex := atomic.LoadUint64(&tc.rng.appliedIndex); appliedIndex != snap.Metadata.Index {
+		t.Errorf("expected applied index %d; got %d", snap.Metadata.Index, appliedIndex)
+	}
+}
+
+// TestRangeApplyCorruptSnapshot verifies that a snapshot whose data was
+// corrupted is dropped by the store before it is applied, and that a
+// snapshot without a checksum is still accepted.
+func TestRangeApplyCorruptSnapshot(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	value := []byte("snapshot value")
+	pArgs := putArgs(roachpb.Key("a"), value)
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+	snap, err := tc.rng.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rangeID := tc.rng.Desc().RangeID
+	if !tc.store.CanApplySnapshot(rangeID, snap) {
+		t.Fatal("expected intact snapshot to be accepted")
+	}
+
+	// Flip a byte of the value in the encoded snapshot.
+	corrupt := snap
+	i := bytes.Index(snap.Data, value)
+	if i == -1 {
+		t.Fatal("value not found in snapshot data")
+	}
+	corrupt.Data = append([]byte(nil), snap.Data...)
+	corrupt.Data[i] ^= 0x01
+	if tc.store.CanApplySnapshot(rangeID, corrupt) {
+		t.Fatal("expected corrupted snapshot to be dropped")
+	}
+
+	// Snapshots from nodes which don't compute checksums are not verified.
+	var snapData roachpb.RaftSnapshotData
+	if err := proto.Unmarshal(corrupt.Data, &snapData); err != nil {
+		t.Fatal(err)
+	}
+	snapData.Checksum = nil
+	legacy := snap
+	if legacy.Data, err = proto.Marshal(&snapData); err != nil {
+		t.Fatal(err)
+	}
+	if !tc.store.CanApplySnapshot(rangeID, legacy) {
+		t.Fatal("expected snapshot without checksum to be accepted")
+	}
+}
+
+// TestRangeSuggestSplitKey verifies that the suggested split key follows the
+// data size midpoint without a load signal and moves towards the keys which
+// receive most requests under a skewed access pattern.
+func TestRangeSuggestSplitKey(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	value := bytes.Repeat([]byte("x"), 1000)
+	for i := 0; i < 100; i++ {
+		pArgs := putArgs(roachpb.Key(fmt.Sprintf("k%02d", i)), value)
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Without sampled requests, the data is split in half.
+	tc.rng.sampler.reset()
+	desc := tc.rng.Desc()
+	sizeKey, err := engine.MVCCFindSplitKey(tc.engine, desc.RangeID, desc.StartKey, desc.EndKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	splitKey, err := tc.rng.SuggestSplitKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !splitKey.Equal(sizeKey) {
+		t.Errorf("expected size-based split key %q; got %q", sizeKey, splitKey)
+	}
+
+	// Direct all reads at the last fifth of the keys.
+	for i := 0; i < loadSampleSize*loadSampleInterval; i++ {
+		gArgs := getArgs(roachpb.Key(fmt.Sprintf("k%02d", 80+i%20)))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &gArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	splitKey, err = tc.rng.SuggestSplitKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if splitKey.Compare(roachpb.Key("k75")) <= 0 || splitKey.Compare(roachpb.Key("k99")) >= 0 {
+		t.Errorf("expected split key between %q and %q; got %q", "k75", "k99", splitKey)
+	}
+}
+
+// TestRangeApproximateMedianKey verifies that the median of the sampled
+// written keys is close to the true median once more keys have been
+// written than the sample retains, that the sample is discarded when the
+// range's bounds change, and that the data size midpoint is used until
+// enough keys have been sampled.
+func TestRangeApproximateMedianKey(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	const numKeys = 3 * writeSampleSize
+	for i := 0; i < numKeys; i++ {
+		pArgs := putArgs(roachpb.Key(fmt.Sprintf("k%04d", i)), []byte("value"))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(tc.rng.sampler.writeSample()); n != writeSampleSize {
+		t.Fatalf("expected %d sampled keys; got %d", writeSampleSize, n)
+	}
+
+	// The standard deviation of the sample median's rank is about 3% of
+	// numKeys; allow 15% in either direction.
+	medianKey, err := tc.rng.ApproximateMedianKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lo := roachpb.Key(fmt.Sprintf("k%04d", numKeys*35/100))
+	hi := roachpb.Key(fmt.Sprintf("k%04d", numKeys*65/100))
+	if medianKey.Compare(lo) < 0 || medianKey.Compare(hi) > 0 {
+		t.Errorf("expected approximate median between %q and %q; got %q", lo, hi, medianKey)
+	}
+
+	// Changing the range's bounds discards the sample, while other
+	// descriptor changes keep it.
+	desc := tc.rng.Desc()
+	newDesc := *desc
+	tc.rng.setDescWithoutProcessUpdate(&newDesc)
+	if n := len(tc.rng.sampler.writeSample()); n != writeSampleSize {
+		t.Fatalf("expected %d sampled keys; got %d", writeSampleSize, n)
+	}
+	newDesc.EndKey = roachpb.RKey("z")
+	tc.rng.setDescWithoutProcessUpdate(&newDesc)
+	tc.rng.setDescWithoutProcessUpdate(desc)
+	if n := len(tc.rng.sampler.writeSample()); n != 0 {
+		t.Fatalf("expected sample to be discarded; got %d keys", n)
+	}
+
+	// Without enough sampled writes, the data is split in half by size.
+	for i := 0; i < minKeySamples-1; i++ {
+		pArgs := putArgs(roachpb.Key(fmt.Sprintf("z%04d", i)), []byte("value"))
+		if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sizeKey, err := engine.MVCCFindSplitKey(tc.engine, desc.RangeID, desc.StartKey, desc.EndKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if medianKey, err = tc.rng.ApproximateMedianKey(); err != nil {
+		t.Fatal(err)
+	} else if !medianKey.Equal(sizeKey) {
+		t.Errorf("expected size-based median key %q; got %q", sizeKey, medianKey)
+	}
+}
+
+// TestReplicaLogPrefix verifies that messages logged through the replica's
+// logging helpers carry its range and store identity and are attributed to
+// the calling file.
+func TestReplicaLogPrefix(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	dir, err := ioutil.TempDir("", "replica_log_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.EnableLogFileOutput(dir)
+	defer func() {
+		log.DisableLogFileOutput()
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	start := time.Now().UnixNano()
+	tc.rng.warningf("replica log prefix test %d", 1)
+	log.Flush()
+	end := time.Now().UnixNano()
+
+	entries, err := log.FetchEntriesFromFiles(log.WarningLog, start, end, 10,
+		regexp.MustCompile("replica log prefix test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry; got %d", len(entries))
+	}
+	entry := entries[0]
+	var args []interface{}
+	for _, arg := range entry.Args {
+		args = append(args, arg.Str)
+	}
+	expected := fmt.Sprintf("[r%d/s%d] replica log prefix test 1", tc.rng.Desc().RangeID, tc.store.StoreID())
+	if msg := fmt.Sprintf(entry.Format, args...); msg != expected {
+		t.Errorf("expected message %q; got %q", expected, msg)
+	}
+	if entry.RangeID == nil || *entry.RangeID != tc.rng.Desc().RangeID {
+		t.Errorf("expected structured range ID %d; got %v", tc.rng.Desc().RangeID, entry.RangeID)
+	}
+	if !strings.HasSuffix(entry.File, "replica_test.go") {
+		t.Errorf("expected entry to be attributed to replica_test.go; got %s", entry.File)
+	}
+}
+
+// waitForApplied blocks until the locally proposed command with the given
+// ID has been applied to the state machine or until the timeout elapses.
+// An error is returned if raft rejected the command, or if the command is
+// neither pending nor among the most recently retired commands, as is the
+// case for commands proposed elsewhere.
+func (r *Replica) waitForApplied(id cmdIDKey, timeout time.Duration) error {
+	findRetired := func() (retiredCmd, bool) {
+		r.RLock()
+		defer r.RUnlock()
+		for _, retired := range r.retiredCmds {
+			if retired.applied != nil && retired.idKey == id {
+				return retired, true
+			}
+		}
+		return retiredCmd{}, false
+	}
+
+	var applied <-chan struct{}
+	r.RLock()
+	if cmd, ok := r.pendingCmds[id]; ok {
+		applied = cmd.applied
+	}
+	r.RUnlock()
+	if applied == nil {
+		retired, ok := findRetired()
+		if !ok {
+			return util.Errorf("unknown command %x", id)
+		}
+		applied = retired.applied
+	}
+	select {
+	case <-applied:
+	case <-time.After(timeout):
+		return util.Errorf("command %x not applied after %s", id, timeout)
+	}
+	// A command is retired before its applied channel is closed.
+	if retired, _ := findRetired(); retired.err != nil {
+		return util.Errorf("command %x was rejected: %s", id, retired.err)
+	}
+	return nil
+}
+
+// TestReplicaWaitForApplied verifies that waitForApplied returns once a
+// proposed command has been applied, times out for a command that never
+// makes it through raft, and fails for rejected and unknown commands.
+func TestReplicaWaitForApplied(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	// Acquire the leader lease up front.
+	pArgs := putArgs(roachpb.Key("a"), []byte("value"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+
+	ba := roachpb.BatchRequest{}
+	ba.Timestamp = tc.clock.Now()
+	pArgs = putArgs(roachpb.Key("b"), []byte("value"))
+	ba.Add(&pArgs)
+	errChan, proposed := tc.rng.proposeRaftCommand(tc.rng.context(), ba)
+	if err := tc.rng.waitForApplied(proposed.idKey, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errChan; err != nil {
+		t.Fatal(err)
+	}
+	if val, _, err := engine.MVCCGet(tc.engine, roachpb.Key("b"), tc.clock.Now(), true, nil); err != nil || val == nil {
+		t.Fatalf("expected value for \"b\" once applied; got %v, %v", val, err)
+	}
+	// Once applied, the command is still known to have been applied.
+	if err := tc.rng.waitForApplied(proposed.idKey, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.rng.waitForApplied(cmdIDKey("unknown"), time.Millisecond); !testutils.IsError(err, "unknown command") {
+		t.Fatalf("expected unknown command error; got %v", err)
+	}
+
+	// A command rejected by raft reports the rejection.
+	var rejectedID cmdIDKey
+	tc.rng.proposeRaftCommandFn = func(id cmdIDKey, _ roachpb.RaftCommand) <-chan error {
+		rejectedID = id
+		ch := make(chan error, 1)
+		ch <- util.Errorf("permanent proposal error")
+		return ch
+	}
+	pArgs = putArgs(roachpb.Key("c"), []byte("value"))
+	if _, err := client.SendWrapped(tc.Sender(), tc.rng.context(), &pArgs); !testutils.IsError(err, "permanent proposal error") {
+		t.Fatalf("expected proposal error; got %v", err)
+	}
+	if err := tc.rng.waitForApplied(rejectedID, time.Millisecond); !testutils.IsError(err, "rejected: permanent proposal error") {
+		t.Fatalf("expected rejection error; got %v", err)
+	}
+
+	// A command which is never proposed to raft is never applied.
+	tc.rng.proposeRaftCommandFn = func(cmdIDKey, roachpb.RaftCommand) <-chan error {
+		return make(chan error, 1)
+	}
+	ba = roachpb.BatchRequest{}
+	ba.Timestamp = tc.clock.Now()
+	pArgs = putArgs(roachpb.Key("d"), []byte("value"))
+	ba.Add(&pArgs)
+	_, proposed = tc.rng.proposeRaftCommand(tc.rng.context(), ba)
+	if err := tc.rng.waitForApplied(proposed.idKey, 10*time.Millisecond); !testutils.IsError(err, "not applied after") {
+		t.Fatalf("expected timeout error; got %v", err)
+	}
+
+	// Only the most recently retired commands are remembered.
+	tc.rng.Lock()
+	for i := 0; i < maxRetiredCmds; i++ {
+		cmd := &pendingCmd{idKey: cmdIDKey(fmt.Sprintf("retired-%d", i)), applied: make(chan struct{})}
+		close(cmd.applied)
+		tc.rng.retirePendingCmdLocked(cmd)
+	}
+	tc.rng.Unlock()
+	if err := tc.rng.waitForApplied(rejectedID, time.Millisecond); !testutils.IsError(err, "unknown command") {
+		t.Fatalf("expected unknown command error; got %v", err)
+	}
+	if err := tc.rng.waitForApplied(cmdIDKey("retired-0"), time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/config"
+	"github.com/cockroachdb/cockroach/gossip"
+	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/roachpb"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+)
+
+// TestReplicateQueueShouldQueue verifies that the replicate queue schedules
+// ranges which have fewer replicas than their zone config requires, and
+// leaves fully replicated ranges alone.
+func TestReplicateQueueShouldQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	stopper, _, sp, _ := createTestAllocator()
+	defer stopper.Stop()
+	mockStorePool(sp, []roachpb.StoreID{1, 2, 3}, nil)
+	// Rebalancing is disabled so that only the replication action decides.
+	replQ := newReplicateQueue(tc.gossip, MakeAllocator(sp, AllocatorOptions{}), tc.clock, AllocatorOptions{})
+
+	// Despite faking the zone configs, we still need to have a gossip entry.
+	if err := tc.gossip.AddInfoProto(gossip.KeySystemConfig, &config.SystemConfig{}, 0); err != nil {
+		t.Fatal(err)
+	}
+	cfg := tc.gossip.GetSystemConfig()
+	if cfg == nil {
+		t.Fatal("nil config")
+	}
+
+	// Keep the range clear of the table span so no split is pending.
+	desc := *tc.rng.Desc()
+	desc.StartKey, desc.EndKey = roachpb.RKeyMin, roachpb.RKey("/")
+	if err := tc.rng.setDesc(&desc); err != nil {
+		t.Fatal(err)
+	}
+	if len(desc.Replicas) != 1 {
+		t.Fatalf("expected a single replica; got %d", len(desc.Replicas))
+	}
+
+	testCases := []struct {
+		replicas int
+		shouldQ  bool
+	}{
+		{1, false},
+		{3, true},
+	}
+	for i, test := range testCases {
+		zone := &config.ZoneConfig{RangeMaxBytes: 64 << 20}
+		for j := 0; j < test.replicas; j++ {
+			zone.ReplicaAttrs = append(zone.ReplicaAttrs, roachpb.Attributes{})
+		}
+		config.TestingSetZoneConfig(keys.RootNamespaceID, zone)
+
+		shouldQ, priority := replQ.shouldQueue(roachpb.ZeroTimestamp, tc.rng, cfg)
+		if shouldQ != test.shouldQ {
+			t.Errorf("%d: should queue expected %t; got %t", i, test.shouldQ, shouldQ)
+		}
+		if shouldQ && priority <= 0 {
+			t.Errorf("%d: expected positive priority for up-replication; got %f", i, priority)
+		}
+	}
+}
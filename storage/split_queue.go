@@ -110,9 +110,16 @@ func (sq *splitQueue) process(now roachpb.Timestamp, rng *Replica,
 	}
 	// FIXME: why is this implementation not the same as the one above?
 	if float64(rng.stats.GetSize())/float64(zone.RangeMaxBytes) > 1 {
-		log.Infof("splitting %s size=%d max=%d", rng, rng.stats.GetSize(), zone.RangeMaxBytes)
+		// Split so that the recent request load, and not only the data, is
+		// divided between the two halves.
+		splitKey, err := rng.SuggestSplitKey()
+		if err != nil {
+			return util.Errorf("unable to determine split key for %s: %s", rng, err)
+		}
+		log.Infof("splitting %s size=%d max=%d at key %s", rng, rng.stats.GetSize(), zone.RangeMaxBytes, splitKey)
 		if _, err = client.SendWrapped(rng, rng.context(), &roachpb.AdminSplitRequest{
-			Span: roachpb.Span{Key: desc.StartKey.AsRawKey()},
+			Span:     roachpb.Span{Key: desc.StartKey.AsRawKey()},
+			SplitKey: splitKey,
 		}); err != nil {
 			return err
 		}
@@ -50,6 +50,8 @@ const (
 	defaultRaftTickInterval         = 100 * time.Millisecond
 	defaultHeartbeatIntervalTicks   = 3
 	defaultRaftElectionTimeoutTicks = 15
+	defaultScanTimeBudget           = 5 * time.Second
+	defaultRaftOverloadGracePeriod  = 100 * time.Millisecond
 	// ttlStoreGossip is time-to-live for store-related info.
 	ttlStoreGossip = 2 * time.Minute
 )
@@ -63,6 +65,25 @@ var (
 		Multiplier:     2,
 	}
 
+	// defaultRaftProposalRetryOptions are default retry options for
+	// re-proposing write commands which raft dropped, e.g. while
+	// leadership of the group is in flux.
+	defaultRaftProposalRetryOptions = retry.Options{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     200 * time.Millisecond,
+		Multiplier:     2,
+		MaxRetries:     5,
+	}
+
+	// defaultRebalanceRetryOptions are default retry options for waiting
+	// on a newly added replica to catch up during a rebalance.
+	defaultRebalanceRetryOptions = retry.Options{
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		MaxRetries:     15,
+	}
+
 	// TestStoreContext has some fields initialized with values relevant
 	// in tests.
 	TestStoreContext = StoreContext{
@@ -278,6 +299,33 @@ type StoreContext struct {
 	// encountered sending commands to ranges.
 	RangeRetryOptions retry.Options
 
+	// RaftProposalRetryOptions are the retry options when a write command
+	// is dropped by raft. If unset, defaultRaftProposalRetryOptions are
+	// used. MaxRetries should be set, as the retries happen while the
+	// command holds its place in the command queue.
+	RaftProposalRetryOptions retry.Options
+
+	// RebalanceRetryOptions govern how long Replica.Rebalance waits for a
+	// new replica to catch up before giving up and removing it again.
+	// MaxRetries must be set.
+	RebalanceRetryOptions retry.Options
+
+	// MaxPendingRaftCommands, if positive, is the number of commands a
+	// replica may have proposed to raft but not yet applied before further
+	// writes are shed with a retryable error. Zero disables the limit.
+	MaxPendingRaftCommands int
+
+	// RaftOverloadGracePeriod is how long a write arriving at a replica at
+	// its MaxPendingRaftCommands limit waits for the backlog to drain
+	// before it is rejected.
+	RaftOverloadGracePeriod time.Duration
+
+	// ScanTimeBudget is the maximum wall time a single Scan command may
+	// spend iterating before it returns its partial results along with a
+	// resume key. DistSender does not continue a timed out scan on the
+	// next range, so the caller resumes it from the returned key.
+	ScanTimeBudget time.Duration
+
 	// RaftTickInterval is the resolution of the Raft timer; other raft timeouts
 	// are defined in terms of multiples of this value.
 	RaftTickInterval time.Duration
@@ -332,6 +380,12 @@ func (sc *StoreContext) Valid() bool {
 // TODO(tschottdorf) see if this ought to be configurable via flags.
 func (sc *StoreContext) setDefaults() {
 	sc.RangeRetryOptions = defaultRangeRetryOptions
+	if sc.RaftProposalRetryOptions == (retry.Options{}) {
+		sc.RaftProposalRetryOptions = defaultRaftProposalRetryOptions
+	}
+	if sc.RebalanceRetryOptions.MaxRetries == 0 {
+		sc.RebalanceRetryOptions = defaultRebalanceRetryOptions
+	}
 
 	if sc.RaftTickInterval == 0 {
 		sc.RaftTickInterval = defaultRaftTickInterval
@@ -342,6 +396,12 @@ func (sc *StoreContext) setDefaults() {
 	if sc.RaftElectionTimeoutTicks == 0 {
 		sc.RaftElectionTimeoutTicks = defaultRaftElectionTimeoutTicks
 	}
+	if sc.ScanTimeBudget == 0 {
+		sc.ScanTimeBudget = defaultScanTimeBudget
+	}
+	if sc.RaftOverloadGracePeriod == 0 {
+		sc.RaftOverloadGracePeriod = defaultRaftOverloadGracePeriod
+	}
 }
 
 // NewStore returns a new instance of a store.
@@ -671,10 +731,10 @@ func (s *Store) maybeGossipSystemConfig() error {
 func (s *Store) systemGossipUpdate(cfg *config.SystemConfig) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	// For every range, update its MaxBytes and check if it needs to be split.
+	// For every range, apply the zone config and check if it needs to be split.
 	for _, rng := range s.replicas {
-		if zone, err := cfg.GetZoneConfigForKey(rng.Desc().StartKey); err == nil {
-			rng.SetMaxBytes(zone.RangeMaxBytes)
+		if err := rng.ApplyConfigChange(cfg); err != nil && log.V(1) {
+			log.Info(err)
 		}
 		s.splitQueue.MaybeAdd(rng, s.ctx.Clock.Now())
 	}
@@ -1220,9 +1280,26 @@ func (s *Store) Attrs() roachpb.Attributes {
 	return s.engine.Attrs()
 }
 
-// Capacity returns the capacity of the underlying storage engine.
+// Capacity returns the capacity of the underlying storage engine along
+// with the number of ranges held by the store. The available bytes are
+// clamped to [0, capacity], so an engine which cannot size its storage and
+// reports zero capacity is treated as full rather than as having space
+// beyond its total.
 func (s *Store) Capacity() (roachpb.StoreCapacity, error) {
-	return s.engine.Capacity()
+	capacity, err := s.engine.Capacity()
+	if err != nil {
+		return roachpb.StoreCapacity{}, err
+	}
+	if capacity.Capacity < 0 {
+		capacity.Capacity = 0
+	}
+	if capacity.Available > capacity.Capacity {
+		capacity.Available = capacity.Capacity
+	} else if capacity.Available < 0 {
+		capacity.Available = 0
+	}
+	capacity.RangeCount = int32(s.ReplicaCount())
+	return capacity, nil
 }
 
 // Descriptor returns a StoreDescriptor including current store
@@ -1232,7 +1309,6 @@ func (s *Store) Descriptor() (*roachpb.StoreDescriptor, error) {
 	if err != nil {
 		return nil, err
 	}
-	capacity.RangeCount = int32(s.ReplicaCount())
 	// Initialize the store descriptor.
 	return &roachpb.StoreDescriptor{
 		StoreID:  s.Ident.StoreID,
@@ -1249,6 +1325,92 @@ func (s *Store) ReplicaCount() int {
 	return len(s.replicas)
 }
 
+// VisitReplicas invokes the visitor on each of the store's replicas in key
+// order, until the visitor returns false.
+func (s *Store) VisitReplicas(visitor func(*Replica) bool) {
+	newStoreRangeSet(s).Visit(visitor)
+}
+
+// RangeScanResult is the block of rows a store-level Scan read from a
+// single range.
+type RangeScanResult struct {
+	RangeID roachpb.RangeID
+	// The keys covered by the block. The blocks of a scan are contiguous:
+	// each one starts where its predecessor ended.
+	Span roachpb.Span
+	Rows []roachpb.KeyValue
+}
+
+// Scan scans [span.Key, span.EndKey) across the ranges held by the store,
+// returning the rows grouped into one block per range so that a
+// distributed executor can attribute them and retry a range on its own.
+// Each range is read with its own ScanRequest, but all at the same
+// timestamp. If maxResults is positive, at most that many rows are
+// returned; the span of the last block then ends at the key from which to
+// resume. Blocks read before an error are returned along with it,
+// including a RangeKeyMismatchError for keys not held by this store.
+func (s *Store) Scan(ctx context.Context, h roachpb.Header, span roachpb.Span, maxResults int64) ([]RangeScanResult, *roachpb.Error) {
+	if h.Timestamp.Equal(roachpb.ZeroTimestamp) && h.Txn == nil {
+		h.Timestamp = s.Clock().Now()
+	}
+	var results []RangeScanResult
+	for key := span.Key; key.Compare(span.EndKey) < 0; {
+		rng := s.LookupReplica(keys.Addr(key), nil)
+		if rng == nil {
+			return results, roachpb.NewError(roachpb.NewRangeKeyMismatchError(key, span.EndKey, nil))
+		}
+		replica := rng.GetReplica()
+		if replica == nil {
+			return results, roachpb.NewError(util.Errorf("own replica missing in range"))
+		}
+		desc := rng.Desc()
+		endKey := span.EndKey
+		if rangeEnd := desc.EndKey.AsRawKey(); rangeEnd.Compare(endKey) < 0 {
+			endKey = rangeEnd
+		}
+
+		ba := roachpb.BatchRequest{}
+		ba.Header = h
+		ba.RangeID = desc.RangeID
+		ba.Replica = *replica
+		ba.Add(&roachpb.ScanRequest{
+			Span:       roachpb.Span{Key: key, EndKey: endKey},
+			MaxResults: maxResults,
+		})
+		br, pErr := s.Send(ctx, ba)
+		if pErr != nil {
+			return results, pErr
+		}
+		reply := br.Responses[0].GetInner().(*roachpb.ScanResponse)
+
+		// Continue within the range if the scan stopped short of its end
+		// for any reason other than the result limit.
+		next := endKey
+		switch reply.ResumeReason {
+		case roachpb.MAX_RESULTS, roachpb.MAX_BYTES, roachpb.TIME_BUDGET:
+			next = reply.ResumeKey
+		}
+		if n := len(results); n > 0 && results[n-1].RangeID == desc.RangeID {
+			results[n-1].Span.EndKey = next
+			results[n-1].Rows = append(results[n-1].Rows, reply.Rows...)
+		} else {
+			results = append(results, RangeScanResult{
+				RangeID: desc.RangeID,
+				Span:    roachpb.Span{Key: key, EndKey: next},
+				Rows:    reply.Rows,
+			})
+		}
+		key = next
+
+		if maxResults > 0 {
+			if maxResults -= int64(len(reply.Rows)); maxResults <= 0 {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
 // Send fetches a range based on the header's replica, assembles
 // method, args & reply into a Raft Cmd struct and executes the
 // command using the fetched range.
@@ -1721,8 +1883,25 @@ func (s *Store) RaftLocker() sync.Locker {
 }
 
 // CanApplySnapshot implements the multiraft.Storage interface.
-// The caller must hold the store's lock.
+// The snapshot is parsed and verified before the store's lock is
+// acquired.
 func (s *Store) CanApplySnapshot(rangeID roachpb.RangeID, snap raftpb.Snapshot) bool {
+	// TODO(bdarnell): can we avoid parsing this twice?
+	var parsedSnap roachpb.RaftSnapshotData
+	if err := parsedSnap.Unmarshal(snap.Data); err != nil {
+		return false
+	}
+
+	// Drop a corrupted snapshot here; failing to apply it later is fatal.
+	// Snapshots sent by nodes which predate checksums carry none.
+	if len(parsedSnap.Checksum) > 0 && !bytes.Equal(parsedSnap.Checksum, snapshotChecksum(parsedSnap.KV)) {
+		log.Warningf("dropping snapshot for range %d at index %d: checksum mismatch",
+			rangeID, snap.Metadata.Index)
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if r, ok := s.replicas[rangeID]; ok && r.isInitialized() {
 		// We have the range and it's initialized, so let the snapshot
 		// through.
@@ -1731,12 +1910,6 @@ func (s *Store) CanApplySnapshot(rangeID roachpb.RangeID, snap raftpb.Snapshot)
 
 	// We don't have the range (or we have an uninitialized
 	// placeholder). Will we be able to create/initialize it?
-	// TODO(bdarnell): can we avoid parsing this twice?
-	var parsedSnap roachpb.RaftSnapshotData
-	if err := parsedSnap.Unmarshal(snap.Data); err != nil {
-		return false
-	}
-
 	if s.hasOverlappingReplicaLocked(&parsedSnap.RangeDescriptor) {
 		// We have a conflicting range, so we must block the snapshot.
 		// When such a conflict exists, it will be resolved by one range
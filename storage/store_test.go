@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"reflect"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -355,6 +356,142 @@ func TestStoreRemoveReplicaOldDescriptor(t *testing.T) {
 	}
 }
 
+// TestStoreLookupAndVisitReplicas verifies that LookupReplica finds the
+// replica owning each key and that VisitReplicas visits replicas in key
+// order.
+func TestStoreLookupAndVisitReplicas(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	store, _, stopper := createTestStore(t)
+	defer stopper.Stop()
+
+	rng1, err := store.GetReplica(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RemoveReplica(rng1, *rng1.Desc()); err != nil {
+		t.Fatal(err)
+	}
+	// Add the ranges out of key order; range i covers [b%02d, b%02d+1).
+	const count = 5
+	for _, i := range []int{3, 0, 4, 1, 2} {
+		rng := createRange(store, roachpb.RangeID(i+1), roachpb.RKey(fmt.Sprintf("b%02d", i)), roachpb.RKey(fmt.Sprintf("b%02d", i+1)))
+		if err := store.AddReplicaTest(rng); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if c := store.ReplicaCount(); c != count {
+		t.Fatalf("expected %d replicas; got %d", count, c)
+	}
+
+	for i := 0; i < count; i++ {
+		for _, key := range []string{fmt.Sprintf("b%02d", i), fmt.Sprintf("b%02dz", i)} {
+			rng := store.LookupReplica(roachpb.RKey(key), nil)
+			if rng == nil || rng.Desc().RangeID != roachpb.RangeID(i+1) {
+				t.Errorf("expected key %q in range %d; got %v", key, i+1, rng)
+			}
+		}
+	}
+	for _, key := range []string{"a", fmt.Sprintf("b%02d", count)} {
+		if rng := store.LookupReplica(roachpb.RKey(key), nil); rng != nil {
+			t.Errorf("expected no range for key %q; got %s", key, rng)
+		}
+	}
+
+	var visited []roachpb.RangeID
+	store.VisitReplicas(func(rng *Replica) bool {
+		visited = append(visited, rng.Desc().RangeID)
+		return len(visited) < 3
+	})
+	if exp := []roachpb.RangeID{1, 2, 3}; !reflect.DeepEqual(visited, exp) {
+		t.Errorf("expected visit order %v; got %v", exp, visited)
+	}
+}
+
+// fixedCapacityEngine is an engine which reports a fixed capacity.
+type fixedCapacityEngine struct {
+	engine.Engine
+	capacity roachpb.StoreCapacity
+}
+
+func (e fixedCapacityEngine) Capacity() (roachpb.StoreCapacity, error) {
+	return e.capacity, nil
+}
+
+// TestStoreCapacity verifies that the store combines the capacity reported
+// by its engine with its range count, clamps nonsensical engine figures and
+// gossips the result in its descriptor.
+func TestStoreCapacity(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	store, _, stopper := createTestStoreWithoutStart(t)
+	defer stopper.Stop()
+	eng := &fixedCapacityEngine{
+		Engine:   store.engine,
+		capacity: roachpb.StoreCapacity{Capacity: 1000, Available: 400},
+	}
+	store.engine = eng
+	if err := store.Gossip().AddInfoProto(gossip.KeySystemConfig,
+		&config.SystemConfig{}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Start(stopper); err != nil {
+		t.Fatal(err)
+	}
+	store.WaitForInit()
+
+	// Replace the bootstrap range with three others.
+	rng1, err := store.GetReplica(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RemoveReplica(rng1, *rng1.Desc()); err != nil {
+		t.Fatal(err)
+	}
+	for i, span := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}} {
+		rng := createRange(store, roachpb.RangeID(i+2), roachpb.RKey(span[0]), roachpb.RKey(span[1]))
+		if err := store.AddReplicaTest(rng); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := roachpb.StoreCapacity{Capacity: 1000, Available: 400, RangeCount: 3}
+	if c, err := store.Capacity(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(c, expected) {
+		t.Errorf("expected capacity %+v; got %+v", expected, c)
+	}
+
+	store.GossipStore()
+	var desc roachpb.StoreDescriptor
+	if err := store.Gossip().GetInfoProto(gossip.MakeStoreKey(store.StoreID()), &desc); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(desc.Capacity, expected) {
+		t.Errorf("expected gossiped capacity %+v; got %+v", expected, desc.Capacity)
+	}
+
+	// An engine which cannot report its size yields an empty capacity with
+	// nothing available, but still the correct range count.
+	for _, c := range []roachpb.StoreCapacity{
+		{},
+		{Capacity: 0, Available: 400},
+		{Capacity: -1, Available: -1},
+	} {
+		eng.capacity = c
+		if c, err := store.Capacity(); err != nil {
+			t.Fatal(err)
+		} else if exp := (roachpb.StoreCapacity{RangeCount: 3}); !reflect.DeepEqual(c, exp) {
+			t.Errorf("expected capacity %+v; got %+v", exp, c)
+		}
+	}
+	// Available space exceeding the total is clamped to the total.
+	eng.capacity = roachpb.StoreCapacity{Capacity: 1000, Available: 2000}
+	if c, err := store.Capacity(); err != nil {
+		t.Fatal(err)
+	} else if c.Available != 1000 {
+		t.Errorf("expected available space clamped to 1000; got %+v", c)
+	}
+}
+
 func TestStoreRangeSet(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	store, _, stopper := createTestStore(t)
@@ -738,6 +875,204 @@ func TestStoreSendOutOfRange(t *testing.T) {
 	}
 }
 
+// TestStoreSendAcrossRangeGap verifies that requests are routed to the
+// range owning their key, and that a key falling into a gap in the store's
+// keyspace is rejected with a RangeKeyMismatchError.
+func TestStoreSendAcrossRangeGap(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	store, _, stopper := createTestStore(t)
+	defer stopper.Stop()
+
+	// Split into [KeyMin,b), [b,d) and [d,KeyMax) and remove the middle
+	// range, leaving a gap.
+	rngB := splitTestRange(store, roachpb.RKeyMin, roachpb.RKey("b"), t)
+	rngD := splitTestRange(store, roachpb.RKey("b"), roachpb.RKey("d"), t)
+	if err := store.RemoveReplica(rngB, *rngB.Desc()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		key     string
+		rangeID roachpb.RangeID
+	}{
+		{"a", 1},
+		{"e", rngD.Desc().RangeID},
+	} {
+		if rng := store.LookupReplica(roachpb.RKey(test.key), nil); rng == nil || rng.Desc().RangeID != test.rangeID {
+			t.Fatalf("expected key %q to be owned by range %d; got %v", test.key, test.rangeID, rng)
+		}
+		pArgs := putArgs(roachpb.Key(test.key), []byte("value"))
+		if _, err := client.SendWrapped(store.testSender(), nil, &pArgs); err != nil {
+			t.Fatalf("%q: %s", test.key, err)
+		}
+		gArgs := getArgs(roachpb.Key(test.key))
+		reply, err := client.SendWrapped(store.testSender(), nil, &gArgs)
+		if err != nil {
+			t.Fatalf("%q: %s", test.key, err)
+		}
+		if reply.(*roachpb.GetResponse).Value == nil {
+			t.Errorf("%q: expected value to be readable", test.key)
+		}
+	}
+
+	if rng := store.LookupReplica(roachpb.RKey("c"), nil); rng != nil {
+		t.Fatalf("expected no range for key in gap; got %s", rng)
+	}
+	gArgs := getArgs(roachpb.Key("c"))
+	if _, err := client.SendWrapped(store.testSender(), nil, &gArgs); err == nil {
+		t.Fatal("expected error for key in gap")
+	} else if _, ok := err.(*roachpb.RangeKeyMismatchError); !ok {
+		t.Fatalf("expected RangeKeyMismatchError; got %T: %s", err, err)
+	}
+}
+
+// TestStoreScan verifies that a store-level scan across several ranges
+// attributes every row to the range it came from, leaves no gaps between
+// blocks and honors the result limit.
+func TestStoreScan(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	store, _, stopper := createTestStore(t)
+	defer stopper.Stop()
+
+	// Split into [KeyMin,b), [b,d) and [d,KeyMax).
+	rngB := splitTestRange(store, roachpb.RKeyMin, roachpb.RKey("b"), t)
+	rngD := splitTestRange(store, roachpb.RKey("b"), roachpb.RKey("d"), t)
+	for _, key := range []string{"a", "a1", "b", "c", "d", "e", "f"} {
+		pArgs := putArgs(roachpb.Key(key), []byte("value-"+key))
+		if _, err := client.SendWrapped(store.testSender(), nil, &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type block struct {
+		rangeID  roachpb.RangeID
+		key, end string
+		rows     []string
+	}
+	testCases := []struct {
+		maxResults int64
+		expected   []block
+	}{
+		{0, []block{
+			{1, "a", "b", []string{"a", "a1"}},
+			{rngB.Desc().RangeID, "b", "d", []string{"b", "c"}},
+			{rngD.Desc().RangeID, "d", "z", []string{"d", "e", "f"}},
+		}},
+		// The limit is reached inside the second range.
+		{3, []block{
+			{1, "a", "b", []string{"a", "a1"}},
+			{rngB.Desc().RangeID, "b", "b\x00", []string{"b"}},
+		}},
+		// The limit is reached exactly at the end of the first range.
+		{2, []block{
+			{1, "a", "a1\x00", []string{"a", "a1"}},
+		}},
+	}
+	for i, test := range testCases {
+		results, pErr := store.Scan(context.Background(), roachpb.Header{},
+			roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("z")}, test.maxResults)
+		if pErr != nil {
+			t.Fatalf("%d: %s", i, pErr)
+		}
+		var actual []block
+		for _, r := range results {
+			b := block{rangeID: r.RangeID, key: string(r.Span.Key), end: string(r.Span.EndKey)}
+			for _, kv := range r.Rows {
+				b.rows = append(b.rows, string(kv.Key))
+			}
+			actual = append(actual, b)
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%d: expected %+v; got %+v", i, test.expected, actual)
+		}
+	}
+
+	// A scan running into keys not held by the store returns the blocks
+	// read so far along with the error.
+	if err := store.RemoveReplica(rngD, *rngD.Desc()); err != nil {
+		t.Fatal(err)
+	}
+	results, pErr := store.Scan(context.Background(), roachpb.Header{},
+		roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("z")}, 0)
+	if _, ok := pErr.GoError().(*roachpb.RangeKeyMismatchError); !ok {
+		t.Fatalf("expected RangeKeyMismatchError; got %v", pErr)
+	}
+	if len(results) != 2 || !results[1].Span.EndKey.Equal(roachpb.Key("d")) {
+		t.Errorf("expected two blocks ending at \"d\"; got %+v", results)
+	}
+}
+
+// TestStoreSplitRangeLookups verifies that lookups running concurrently
+// with splits always find an owning range, that every key is owned by
+// exactly the expected range afterwards, and that splits which don't
+// carve a suffix off the original range are rejected.
+func TestStoreSplitRangeLookups(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	store, _, stopper := createTestStore(t)
+	defer stopper.Stop()
+
+	const numSplits = 10
+	var keys []roachpb.RKey
+	for i := 0; i < numSplits; i++ {
+		keys = append(keys, roachpb.RKey(fmt.Sprintf("c%02d", i)), roachpb.RKey(fmt.Sprintf("c%02dz", i)))
+	}
+
+	done := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(errCh)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			for _, key := range keys {
+				if store.LookupReplica(key, nil) == nil {
+					errCh <- util.Errorf("no range found for key %q during split", key)
+					return
+				}
+			}
+		}
+	}()
+
+	for i := 0; i < numSplits; i++ {
+		splitKey := roachpb.RKey(fmt.Sprintf("c%02d", i))
+		newRng := createRange(store, roachpb.RangeID(i+2), splitKey, roachpb.RKeyMax)
+		if err := store.SplitRange(store.LookupReplica(splitKey, nil), newRng); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(done)
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+
+	for i, key := range keys {
+		rng := store.LookupReplica(key, nil)
+		if expID := roachpb.RangeID(i/2 + 2); rng == nil || rng.Desc().RangeID != expID {
+			t.Errorf("expected key %q to be owned by range %d; got %v", key, expID, rng)
+		}
+	}
+
+	last := store.LookupReplica(roachpb.RKey("d"), nil)
+	for i, newRng := range []*Replica{
+		// Split key before the original range's start.
+		createRange(store, 100, roachpb.RKey("b"), roachpb.RKeyMax),
+		// Split key equal to the original range's start.
+		createRange(store, 101, last.Desc().StartKey, roachpb.RKeyMax),
+		// End key doesn't match the original range.
+		createRange(store, 102, roachpb.RKey("e"), roachpb.RKey("f")),
+	} {
+		if err := store.SplitRange(last, newRng); !testutils.IsError(err, "not splittable") {
+			t.Errorf("%d: expected split to be rejected; got %v", i, err)
+		}
+	}
+	if c := store.ReplicaCount(); c != numSplits+1 {
+		t.Errorf("expected %d replicas; got %d", numSplits+1, c)
+	}
+}
+
 // TestStoreRangeIDAllocation verifies that  range IDs are
 // allocated in successive blocks.
 func TestStoreRangeIDAllocation(t *testing.T) {
@@ -90,6 +90,12 @@ func InfoDepth(depth int, args ...interface{}) {
 	logDepth(nil, depth+1, InfoLog, "", args)
 }
 
+// InfocDepth logs to the INFO log like Infoc, offsetting the caller's stack
+// frame by 'depth'.
+func InfocDepth(ctx context.Context, depth int, format string, args ...interface{}) {
+	logDepth(ctx, depth+1, InfoLog, format, args)
+}
+
 // Warningc logs to the WARNING and INFO logs. It extracts values from the
 // context using the Field keys specified in this package and logs them along
 // with the given message and any additional pairs specified as consecutive
@@ -118,6 +124,12 @@ func WarningDepth(depth int, args ...interface{}) {
 	logDepth(nil, depth+1, WarningLog, "", args)
 }
 
+// WarningcDepth logs to the WARNING and INFO logs like Warningc, offsetting
+// the caller's stack frame by 'depth'.
+func WarningcDepth(ctx context.Context, depth int, format string, args ...interface{}) {
+	logDepth(ctx, depth+1, WarningLog, format, args)
+}
+
 // Errorc logs to the ERROR, WARNING, and INFO logs. It extracts values from
 // Field keys specified in this package and logs them along with the given
 // message and any additional pairs specified as consecutive elements in kvs.
@@ -144,6 +156,12 @@ func ErrorDepth(depth int, args ...interface{}) {
 	logDepth(nil, depth+1, ErrorLog, "", args)
 }
 
+// ErrorcDepth logs to the ERROR, WARNING, and INFO logs like Errorc,
+// offsetting the caller's stack frame by 'depth'.
+func ErrorcDepth(ctx context.Context, depth int, format string, args ...interface{}) {
+	logDepth(ctx, depth+1, ErrorLog, format, args)
+}
+
 // Fatalc logs to the INFO, WARNING, ERROR, and FATAL logs, including a stack
 // trace of all running goroutines, then calls os.Exit(255). It extracts values
 // from the context using the Field keys specified in this package and logs